@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ran-codes/zenodo-cli/internal/config"
+)
+
+// auditEntry is one line of the write-tool audit log.
+type auditEntry struct {
+	Time         time.Time `json:"time"`
+	Tool         string    `json:"tool"`
+	DepositionID string    `json:"deposition_id"`
+	// Phase is preview, confirm or rejected.
+	Phase   string `json:"phase"`
+	Outcome string `json:"outcome"`
+	Detail  string `json:"detail,omitempty"`
+	BaseURL string `json:"base_url,omitempty"`
+	// Identity is a fingerprint of the caller's Zenodo token (HTTP transport only).
+	Identity string `json:"identity,omitempty"`
+}
+
+// auditLog appends JSON lines to a file. Every write-tool call is recorded,
+// including previews and rejected confirmations.
+type auditLog struct {
+	mu      sync.Mutex
+	path    string
+	baseURL string
+}
+
+// auditLogPath resolves the audit log location: ZENODO_MCP_AUDIT_LOG, then
+// the mcp.audit_log config key, then mcp-audit.log in the config directory.
+func auditLogPath(cfg *config.Config) string {
+	if p := os.Getenv("ZENODO_MCP_AUDIT_LOG"); p != "" {
+		return p
+	}
+	if p := fmt.Sprintf("%v", cfg.Get("mcp.audit_log")); p != "" && p != "<nil>" {
+		return p
+	}
+	return filepath.Join(config.GetConfigDir(), "mcp-audit.log")
+}
+
+func (a *auditLog) record(e auditEntry) {
+	e.Time = time.Now().UTC()
+	e.BaseURL = a.baseURL
+	line, err := json.Marshal(e)
+	if err != nil {
+		log.Printf("audit: %v", err)
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(a.path), 0700); err != nil {
+		log.Printf("audit: %v", err)
+		return
+	}
+	f, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		log.Printf("audit: %v", err)
+		return
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		log.Printf("audit: %v", err)
+	}
+}
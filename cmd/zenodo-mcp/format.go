@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+	"github.com/ran-codes/zenodo-cli/internal/output"
+)
+
+// Field presets for the fields parameter.
+const (
+	// defaultListFields keeps list and search results small.
+	defaultListFields = "id,title,community,links.doi,metadata.publication_date,metadata.resource_type.type,stats.version_views,stats.version_downloads"
+	statsFields       = "id,title,links.doi,stats.views,stats.downloads,stats.unique_views,stats.unique_downloads,stats.version_views,stats.version_downloads"
+)
+
+// formatParams are the tool options shared by record-returning tools.
+func formatParams(defaultFields string) []mcp.ToolOption {
+	fieldsDesc := `Comma-separated fields to return, dotted paths allowed (e.g. "id,title,links.doi,stats.downloads"). "stats" selects identifiers and usage statistics; "all" returns full records.`
+	if defaultFields != "" {
+		fieldsDesc += " Default: " + defaultFields
+	}
+	return []mcp.ToolOption{
+		mcp.WithString("fields", mcp.Description(fieldsDesc)),
+		mcp.WithString("format",
+			mcp.Description("Response format: json (default), csv, bibtex or datacite (XML). bibtex and datacite fetch each record's citation."),
+			mcp.Enum("json", "csv", "bibtex", "datacite"),
+		),
+	}
+}
+
+// resolveFields applies the fields presets.
+func resolveFields(req mcp.CallToolRequest, defaultFields string) string {
+	switch fields := req.GetString("fields", ""); fields {
+	case "":
+		return defaultFields
+	case "all":
+		return ""
+	case "stats":
+		return statsFields
+	default:
+		return fields
+	}
+}
+
+// recordsResult renders a page of records in the requested format and field
+// projection. The structured content always carries total and next_cursor;
+// hits are included with json output.
+func recordsResult(client *api.Client, req mcp.CallToolRequest, progress *progressReporter, pg page[map[string]any], defaultFields string) (*mcp.CallToolResult, error) {
+	fields := resolveFields(req, defaultFields)
+	out := page[map[string]any]{Total: pg.Total, NextCursor: pg.NextCursor}
+	switch format := req.GetString("format", "json"); format {
+	case "json":
+		hits, err := output.Project(pg.Hits, fields)
+		if err != nil {
+			return nil, err
+		}
+		out.Hits = hits.([]map[string]any)
+		return structuredResult(out)
+	case "csv":
+		var buf bytes.Buffer
+		if err := output.Format(&buf, pg.Hits, "csv", fields); err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultStructured(out, buf.String()), nil
+	case "bibtex", "datacite":
+		text, err := rawRecords(client, progress, pg.Hits, format)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructured(out, text), nil
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("unsupported format %q (use json, csv, bibtex or datacite)", format)), nil
+	}
+}
+
+// recordResult renders a single record; fields default to the full record.
+// The structured content is the (projected) record in every format.
+func recordResult(client *api.Client, req mcp.CallToolRequest, progress *progressReporter, record *model.Record) (*mcp.CallToolResult, error) {
+	fields := resolveFields(req, "")
+	var structured any = record
+	if fields != "" {
+		rows, err := output.Project(record, fields)
+		if err != nil {
+			return nil, err
+		}
+		structured = rows.([]map[string]any)[0]
+	}
+	switch format := req.GetString("format", "json"); format {
+	case "json":
+		return structuredResult(structured)
+	case "csv":
+		var buf bytes.Buffer
+		if err := output.Format(&buf, record, "csv", fields); err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultStructured(structured, buf.String()), nil
+	case "bibtex", "datacite":
+		text, err := rawRecords(client, progress, []*model.Record{record}, format)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructured(structured, text), nil
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("unsupported format %q (use json, csv, bibtex or datacite)", format)), nil
+	}
+}
+
+// rawRecords fetches each record's BibTeX or DataCite serialization,
+// reporting progress per record.
+func rawRecords(client *api.Client, progress *progressReporter, records any, format string) (string, error) {
+	mimeType := mimeBibTeX
+	if format == "datacite" {
+		mimeType = mimeDataCite
+	}
+	ids, err := recordIDs(records)
+	if err != nil {
+		return "", err
+	}
+	parts := make([]string, 0, len(ids))
+	for i, id := range ids {
+		data, err := client.GetRaw(fmt.Sprintf("/records/%d", id), mimeType)
+		if err != nil {
+			return "", fmt.Errorf("fetching %s for record %d: %w", format, id, err)
+		}
+		parts = append(parts, strings.TrimSpace(string(data)))
+		if len(ids) > 1 {
+			progress.step(len(ids)-i-1, fmt.Sprintf("fetched %s for record %d", format, id))
+		}
+	}
+	return strings.Join(parts, "\n\n"), nil
+}
+
+// recordIDs extracts the "id" of each record.
+func recordIDs(records any) ([]int, error) {
+	b, err := json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+	var items []struct {
+		ID int `json:"id"`
+	}
+	if err := json.Unmarshal(b, &items); err != nil {
+		return nil, err
+	}
+	ids := make([]int, 0, len(items))
+	for _, it := range items {
+		if it.ID != 0 {
+			ids = append(ids, it.ID)
+		}
+	}
+	return ids, nil
+}
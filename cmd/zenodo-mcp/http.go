@@ -0,0 +1,171 @@
+package main
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/ran-codes/zenodo-cli/internal/api"
+)
+
+// zenodoTokenHeader carries the caller's Zenodo token when Authorization is
+// used for the endpoint's own bearer auth.
+const zenodoTokenHeader = "X-Zenodo-Token"
+
+// connection is the per-request state of the HTTP transport.
+type connection struct {
+	client *api.Client
+	// identity is a fingerprint of the caller's Zenodo token, used to bind
+	// confirm tokens and in the audit log.
+	identity string
+}
+
+type connectionKey struct{}
+
+func withConnection(ctx context.Context, c connection) context.Context {
+	return context.WithValue(ctx, connectionKey{}, c)
+}
+
+// connectionFrom returns the per-request connection, if the request came
+// over the HTTP transport.
+func connectionFrom(ctx context.Context) (connection, bool) {
+	c, ok := ctx.Value(connectionKey{}).(connection)
+	return c, ok
+}
+
+// clientFrom returns the caller's API client, or fallback for the stdio
+// transport, which uses the token from the keyring.
+func clientFrom(ctx context.Context, fallback *api.Client) *api.Client {
+	if c, ok := connectionFrom(ctx); ok {
+		return c.client
+	}
+	return fallback
+}
+
+// identityFrom returns the caller's identity, or "" for the stdio transport.
+func identityFrom(ctx context.Context) string {
+	c, _ := connectionFrom(ctx)
+	return c.identity
+}
+
+// tokenFingerprint identifies a token without revealing it.
+func tokenFingerprint(token string) string {
+	if token == "" {
+		return "anonymous"
+	}
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:6])
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer" header.
+func bearerToken(r *http.Request) string {
+	h := r.Header.Get("Authorization")
+	if len(h) > 7 && strings.EqualFold(h[:7], "Bearer ") {
+		return strings.TrimSpace(h[7:])
+	}
+	return ""
+}
+
+// maxPooledClients caps the clients a clientPool keeps, so a long-running
+// server does not grow with every distinct token it has seen.
+const maxPooledClients = 256
+
+// clientPool keeps one API client per Zenodo token, so each caller has its
+// own rate limiter across requests. When it is full, the least recently
+// used client is dropped.
+type clientPool struct {
+	mu      sync.Mutex
+	baseURL string
+	max     int
+	clients map[string]*list.Element // values are *pooledClient
+	lru     *list.List               // most recently used first
+}
+
+type pooledClient struct {
+	token  string
+	client *api.Client
+}
+
+func newClientPool(baseURL string, max int) *clientPool {
+	return &clientPool{baseURL: baseURL, max: max, clients: make(map[string]*list.Element), lru: list.New()}
+}
+
+func (p *clientPool) get(token string) *api.Client {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if e, ok := p.clients[token]; ok {
+		p.lru.MoveToFront(e)
+		return e.Value.(*pooledClient).client
+	}
+	c := newAPIClient(p.baseURL, token)
+	p.clients[token] = p.lru.PushFront(&pooledClient{token: token, client: c})
+	for p.lru.Len() > p.max {
+		oldest := p.lru.Back()
+		p.lru.Remove(oldest)
+		delete(p.clients, oldest.Value.(*pooledClient).token)
+	}
+	return c
+}
+
+// newHTTPHandler serves the MCP server over HTTP:
+//
+//	/mcp              streamable HTTP transport
+//	/sse, /message    legacy HTTP+SSE transport
+//	/healthz          health check (never authenticated)
+//
+// The caller's Zenodo token is taken from "Authorization: Bearer", never
+// from the keyring. If authToken is set, Authorization must carry it instead
+// and the Zenodo token moves to the X-Zenodo-Token header.
+func newHTTPHandler(s *server.MCPServer, baseURL, authToken string) http.Handler {
+	pool := newClientPool(baseURL, maxPooledClients)
+	contextFunc := func(ctx context.Context, r *http.Request) context.Context {
+		token := bearerToken(r)
+		if authToken != "" {
+			token = r.Header.Get(zenodoTokenHeader)
+		}
+		return withConnection(ctx, connection{client: pool.get(token), identity: tokenFingerprint(token)})
+	}
+
+	streamable := server.NewStreamableHTTPServer(s,
+		server.WithEndpointPath("/mcp"),
+		server.WithHTTPContextFunc(contextFunc),
+	)
+	sse := server.NewSSEServer(s,
+		server.WithSSEEndpoint("/sse"),
+		server.WithMessageEndpoint("/message"),
+		server.WithSSEContextFunc(contextFunc),
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok", "name": serverName, "version": serverVersion})
+	})
+	mux.Handle("/mcp", requireBearer(authToken, streamable))
+	mux.Handle("/sse", requireBearer(authToken, sse.SSEHandler()))
+	mux.Handle("/message", requireBearer(authToken, sse.MessageHandler()))
+	return mux
+}
+
+// requireBearer rejects requests whose bearer token does not match want.
+// An empty want disables the check.
+func requireBearer(want string, next http.Handler) http.Handler {
+	if want == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(bearerToken(r)), []byte(want)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="zenodo-mcp"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
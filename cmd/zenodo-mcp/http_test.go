@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/config"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// zenodoStub serves /records/{id} and records the Authorization header of
+// every request.
+type zenodoStub struct {
+	*httptest.Server
+	mu    sync.Mutex
+	auths []string
+}
+
+func newZenodoStub(t *testing.T) *zenodoStub {
+	t.Helper()
+	z := &zenodoStub{}
+	z.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		z.mu.Lock()
+		z.auths = append(z.auths, r.Header.Get("Authorization"))
+		z.mu.Unlock()
+		json.NewEncoder(w).Encode(model.Record{ID: 42, Metadata: model.Metadata{Title: "Ocean data"}})
+	}))
+	t.Cleanup(z.Close)
+	return z
+}
+
+func (z *zenodoStub) lastAuth() string {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	if len(z.auths) == 0 {
+		return ""
+	}
+	return z.auths[len(z.auths)-1]
+}
+
+// newHTTPTestServer serves the full MCP server over HTTP in-process.
+func newHTTPTestServer(t *testing.T, zenodoURL, authToken string) *httptest.Server {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newServer(cfg, api.NewClient(zenodoURL, ""), zenodoURL)
+	srv := httptest.NewServer(newHTTPHandler(s, zenodoURL, authToken))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func callRecordsGet(t *testing.T, url string, headers map[string]string) (*mcp.CallToolResult, error) {
+	t.Helper()
+	c, err := client.NewStreamableHttpClient(url, transport.WithHTTPHeaders(headers))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	ctx := context.Background()
+	if err := c.Start(ctx); err != nil {
+		return nil, err
+	}
+	var init mcp.InitializeRequest
+	init.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	if _, err := c.Initialize(ctx, init); err != nil {
+		return nil, err
+	}
+	var req mcp.CallToolRequest
+	req.Params.Name = "records_get"
+	req.Params.Arguments = map[string]any{"id": 42}
+	return c.CallTool(ctx, req)
+}
+
+func TestHTTP_PerConnectionToken(t *testing.T) {
+	z := newZenodoStub(t)
+	srv := newHTTPTestServer(t, z.URL, "")
+
+	res, err := callRecordsGet(t, srv.URL+"/mcp", map[string]string{"Authorization": "Bearer alice-token"})
+	if err != nil {
+		t.Fatalf("CallTool() error: %v", err)
+	}
+	if res.IsError || !strings.Contains(res.Content[0].(mcp.TextContent).Text, "Ocean data") {
+		t.Errorf("result = %+v", res)
+	}
+	if got := z.lastAuth(); got != "Bearer alice-token" {
+		t.Errorf("upstream Authorization = %q, want alice's token", got)
+	}
+
+	// Without a token the request is anonymous, never the keyring token.
+	if _, err := callRecordsGet(t, srv.URL+"/mcp", nil); err != nil {
+		t.Fatalf("anonymous CallTool() error: %v", err)
+	}
+	if got := z.lastAuth(); got != "" {
+		t.Errorf("upstream Authorization = %q, want none", got)
+	}
+}
+
+func TestHTTP_EndpointAuth(t *testing.T) {
+	z := newZenodoStub(t)
+	srv := newHTTPTestServer(t, z.URL, "endpoint-secret")
+
+	if _, err := callRecordsGet(t, srv.URL+"/mcp", map[string]string{"Authorization": "Bearer wrong"}); err == nil {
+		t.Error("expected wrong endpoint token to be rejected")
+	}
+
+	resp, err := http.Post(srv.URL+"/mcp", "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", resp.StatusCode)
+	}
+
+	_, err = callRecordsGet(t, srv.URL+"/mcp", map[string]string{
+		"Authorization":   "Bearer endpoint-secret",
+		zenodoTokenHeader: "bob-token",
+	})
+	if err != nil {
+		t.Fatalf("CallTool() error: %v", err)
+	}
+	if got := z.lastAuth(); got != "Bearer bob-token" {
+		t.Errorf("upstream Authorization = %q, want bob's Zenodo token", got)
+	}
+}
+
+func TestHTTP_Health(t *testing.T) {
+	srv := newHTTPTestServer(t, "http://127.0.0.1:0", "endpoint-secret")
+
+	resp, err := http.Get(srv.URL + "/healthz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var body map[string]string
+	json.NewDecoder(resp.Body).Decode(&body)
+	if resp.StatusCode != http.StatusOK || body["status"] != "ok" {
+		t.Errorf("healthz = %d %v", resp.StatusCode, body)
+	}
+}
+
+func TestConfirmations_BoundToIdentity(t *testing.T) {
+	c := newConfirmations()
+	token, _, err := c.issue(pendingWrite{tool: "deposit_publish", id: "1", identity: tokenFingerprint("alice")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.redeem(token, "deposit_publish", "1", tokenFingerprint("bob")); err == nil {
+		t.Error("expected a token issued to alice to be rejected for bob")
+	}
+}
+
+func TestClientPool_EvictsLeastRecentlyUsed(t *testing.T) {
+	p := newClientPool("http://example.org/api", 2)
+	a := p.get("alice")
+	p.get("bob")
+	if p.get("alice") != a {
+		t.Fatal("alice's client was not reused")
+	}
+	p.get("carol") // evicts bob, the least recently used
+	if len(p.clients) != 2 || p.lru.Len() != 2 {
+		t.Fatalf("pool holds %d clients, want 2", len(p.clients))
+	}
+	if _, ok := p.clients["bob"]; ok {
+		t.Error("bob's client was kept")
+	}
+	if p.get("alice") != a {
+		t.Error("alice's client was evicted")
+	}
+}
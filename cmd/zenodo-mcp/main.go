@@ -4,20 +4,39 @@ import (
 	"context"
 	_ "embed"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"strings"
 
+	"github.com/fatih/color"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/ran-codes/zenodo-cli/internal/api"
 	"github.com/ran-codes/zenodo-cli/internal/config"
+	"github.com/ran-codes/zenodo-cli/internal/deposit"
+	"github.com/ran-codes/zenodo-cli/internal/listing"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+	"github.com/ran-codes/zenodo-cli/internal/validate"
+	"github.com/ran-codes/zenodo-cli/internal/vocab"
 )
 
 //go:embed instructions.md
 var baseInstructions string
 
+const (
+	serverName    = "zenodo"
+	serverVersion = "0.1.0"
+)
+
 func main() {
+	transport := flag.String("transport", "stdio", "Transport: stdio or http")
+	listen := flag.String("listen", ":8080", "Address to listen on with --transport http")
+	authToken := flag.String("auth-token", os.Getenv("ZENODO_MCP_AUTH_TOKEN"), "Bearer token required by the HTTP endpoint (default $ZENODO_MCP_AUTH_TOKEN)")
+	flag.Parse()
+
 	// Load config and resolve token using the same chain as the CLI.
 	cfg, err := config.Load()
 	if err != nil {
@@ -29,35 +48,126 @@ func main() {
 		profile = cfg.DefaultProfile()
 	}
 
-	kr := config.NewKeyring()
-	token := config.ResolveTokenFull("", kr, cfg, profile)
-
 	sandbox := os.Getenv("ZENODO_SANDBOX") == "true" || os.Getenv("ZENODO_SANDBOX") == "1"
 	baseURL := cfg.ResolveBaseURL(profile, sandbox)
+	if !sandbox {
+		if instanceCaps, err = cfg.ProfileCapabilities(profile); err != nil {
+			log.Printf("warning: ignoring stored capabilities: %v", err)
+		}
+	}
+
+	// Write tools use the profile's write API, as the CLI's deposit
+	// commands do.
+	if writesAllowed(cfg) {
+		if writeMode, err = deposit.ResolveMode(cfg.GetProfileValue(profile, "mode"), instanceCaps); err != nil {
+			log.Fatalf("profile %q: %v", profile, err)
+		}
+	}
+
+	// Diffs are returned as tool text, never to a terminal.
+	color.NoColor = true
+
+	// Validate against cached vocabularies without fetching on startup;
+	// `zenodo vocab update` refreshes the cache.
+	store := vocab.NewStore(baseURL, nil)
+	store.Caps = instanceCaps
+	validate.UseVocabularies(store.LoadSet(false, func(kind vocab.Kind, err error) {
+		log.Printf("warning: vocabulary %s: %v", kind, err)
+	}))
+	validate.UseAwards(store.Awards(false))
+
+	switch *transport {
+	case "stdio":
+		kr := config.NewKeyring()
+		token := config.ResolveTokenFull("", kr, cfg, profile)
+		client := newAPIClient(baseURL, token)
+
+		s := newServer(cfg, client, baseURL)
+		// The user's published records are added as browsable resources
+		// once they have been listed.
+		if token != "" {
+			go loadMyRecords(s, client)
+		}
+
+		stdio := server.NewStdioServer(s)
+		if err := stdio.Listen(context.Background(), os.Stdin, os.Stdout); err != nil {
+			log.Fatalf("mcp server error: %v", err)
+		}
+
+	case "http":
+		// Each request brings its own Zenodo token; the keyring token is
+		// never used, so the fallback client is anonymous.
+		s := newServer(cfg, newAPIClient(baseURL, ""), baseURL)
+		if *authToken == "" {
+			log.Printf("warning: HTTP endpoint has no bearer auth; set --auth-token or ZENODO_MCP_AUTH_TOKEN")
+		}
+		log.Printf("zenodo-mcp listening on %s (streamable HTTP at /mcp, SSE at /sse, health at /healthz)", *listen)
+		if err := http.ListenAndServe(*listen, newHTTPHandler(s, baseURL, *authToken)); err != nil {
+			log.Fatalf("mcp server error: %v", err)
+		}
 
-	client := api.NewClient(baseURL, token)
+	default:
+		log.Fatalf("unknown transport %q (use stdio or http)", *transport)
+	}
+}
+
+// instanceCaps are the profile's probed instance capabilities, or nil.
+var instanceCaps *model.Capabilities
+
+// writeMode is the write API of the profile, used by the write tools.
+var writeMode = deposit.ModeDeposit
 
+// newAPIClient returns an API client adapted to instanceCaps.
+func newAPIClient(baseURL, token string) *api.Client {
+	c := api.NewClient(baseURL, token)
+	if instanceCaps != nil {
+		c.UseCapabilities(instanceCaps)
+	}
+	return c
+}
+
+// newServer creates the MCP server with all tools, prompts and resources.
+// client is the default API client, used when a request carries none.
+func newServer(cfg *config.Config, client *api.Client, baseURL string) *server.MCPServer {
 	// Build server instructions with user context.
 	instructions := buildInstructions(cfg)
+	allowWrites := writesAllowed(cfg)
+	if allowWrites {
+		instructions += writeInstructions
+	}
 
 	// Create MCP server.
-	s := server.NewMCPServer("zenodo", "0.1.0",
+	s := server.NewMCPServer(serverName, serverVersion,
 		server.WithInstructions(instructions),
+		server.WithResourceCapabilities(false, true),
 	)
 
 	// Register tools.
-	s.AddTool(recordsListTool(), recordsListHandler(client))
+	s.AddTool(recordsListTool(), recordsListHandler(client, configuredORCID(cfg)))
 	s.AddTool(recordsSearchTool(), recordsSearchHandler(client))
 	s.AddTool(recordsGetTool(), recordsGetHandler(client))
 	s.AddTool(recordsVersionsTool(), recordsVersionsHandler(client))
 	s.AddTool(communitiesListTool(), communitiesListHandler(client))
 	s.AddTool(licensesSearchTool(), licensesSearchHandler(client))
 
-	// Run over stdio.
-	stdio := server.NewStdioServer(s)
-	if err := stdio.Listen(context.Background(), os.Stdin, os.Stdout); err != nil {
-		log.Fatalf("mcp server error: %v", err)
+	// Register prompts, pre-filled with the configured ORCID.
+	registerPrompts(s, configuredORCID(cfg))
+
+	// Register resources.
+	registerResources(s, client)
+
+	// Write tools stay off unless explicitly enabled.
+	if allowWrites {
+		w := &writeTools{
+			client:  client,
+			mode:    writeMode,
+			confirm: newConfirmations(),
+			audit:   &auditLog{path: auditLogPath(cfg), baseURL: baseURL},
+		}
+		w.register(s)
 	}
+
+	return s
 }
 
 // buildInstructions combines the embedded markdown instructions with dynamic user context.
@@ -65,14 +175,22 @@ func buildInstructions(cfg *config.Config) string {
 	instructions := baseInstructions
 
 	// Append user's ORCID if configured.
-	orcid := fmt.Sprintf("%v", cfg.Get("orcid"))
-	if orcid != "" && orcid != "<nil>" {
+	if orcid := configuredORCID(cfg); orcid != "" {
 		instructions += fmt.Sprintf("\n## User context\n\n- The authenticated user's ORCID is: %s\n- When asked about \"my records\" or similar, search both creators.orcid and contributors.orcid with this ORCID.\n", orcid)
 	}
 
 	return instructions
 }
 
+// configuredORCID returns the user's ORCID from config, or "" if unset.
+func configuredORCID(cfg *config.Config) string {
+	orcid := fmt.Sprintf("%v", cfg.Get("orcid"))
+	if orcid == "<nil>" {
+		return ""
+	}
+	return orcid
+}
+
 // jsonResult marshals v to JSON and returns it as an MCP text result.
 func jsonResult(v any) (*mcp.CallToolResult, error) {
 	b, err := json.MarshalIndent(v, "", "  ")
@@ -82,87 +200,183 @@ func jsonResult(v any) (*mcp.CallToolResult, error) {
 	return mcp.NewToolResultText(string(b)), nil
 }
 
+// collectPages fetches up to pages consecutive pages starting at pageNum,
+// stopping early on the last page. It returns the rows, the total number
+// of matches and the next page to fetch, or 0 if there is none.
+func collectPages(progress *progressReporter, pageNum, pages int, fetch func(pageNum, pagesLeft int) (*listing.Result, error)) (rows []map[string]any, total, next int, err error) {
+	for i := 0; i < pages; i++ {
+		res, err := fetch(pageNum+i, pages-i-1)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		rows = append(rows, res.Rows...)
+		total = max(res.Total, len(rows))
+		if !res.More {
+			return rows, total, 0, nil
+		}
+		if pages > 1 {
+			progress.step(pages-i-1, fmt.Sprintf("fetched page %d", pageNum+i))
+		}
+	}
+	return rows, total, pageNum + pages, nil
+}
+
 // --- records_list ---
 
 func recordsListTool() mcp.Tool {
-	return mcp.NewTool("records_list",
-		mcp.WithDescription("List the authenticated user's own Zenodo records and drafts"),
-		mcp.WithNumber("page", mcp.Description("Page number (default 1)")),
-		mcp.WithNumber("size", mcp.Description("Results per page (default 10)")),
-		mcp.WithString("status", mcp.Description("Filter by status: draft or published")),
+	opts := []mcp.ToolOption{
+		mcp.WithDescription("List the user's Zenodo records. Modes: authored (records where an ORCID is a creator or contributor; the default when an ORCID is configured), uploaded (records and drafts uploaded by this account), community (all records in one community), communities (records across all of the user's communities)."),
+		mcp.WithString("mode", mcp.Description("Listing mode"), mcp.Enum("authored", "uploaded", "community", "communities")),
+		mcp.WithString("orcid", mcp.Description("ORCID for authored mode (default: the configured ORCID)")),
+		mcp.WithString("community", mcp.Description("Community slug: required for community mode, optional filter for authored mode")),
+		mcp.WithString("status", mcp.Description("Filter by status: draft or published (uploaded and community modes)")),
 		mcp.WithString("sort", mcp.Description("Sort order, e.g. mostrecent, bestmatch")),
+	}
+	opts = append(opts, pagingParams(true)...)
+	opts = append(opts, formatParams(defaultListFields)...)
+	opts = append(opts,
+		outputSchema[page[model.Record]](),
 		mcp.WithReadOnlyHintAnnotation(true),
 		mcp.WithDestructiveHintAnnotation(false),
 		mcp.WithOpenWorldHintAnnotation(true),
 	)
+	return mcp.NewTool("records_list", opts...)
 }
 
-func recordsListHandler(client *api.Client) server.ToolHandlerFunc {
+func recordsListHandler(fallback *api.Client, configuredORCID string) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		params := api.RecordListParams{
-			Page:   req.GetInt("page", 1),
-			Size:   req.GetInt("size", 10),
-			Status: req.GetString("status", ""),
-			Sort:   req.GetString("sort", ""),
+		client := clientFrom(ctx, fallback)
+		key := queryKey(req, "mode", "orcid", "community", "status", "sort")
+		pageNum, size, err := pageArgs(req, "records_list", key)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		orcid := req.GetString("orcid", configuredORCID)
+		community := req.GetString("community", "")
+
+		mode := req.GetString("mode", "")
+		if mode == "" {
+			mode = "uploaded"
+			if orcid != "" {
+				mode = "authored"
+			}
 		}
-		records, err := client.ListUserRecords(params)
+		switch {
+		case mode == "authored" && orcid == "":
+			return mcp.NewToolResultError("authored mode needs an ORCID: pass orcid, or configure one with `zenodo config set orcid <id>`"), nil
+		case mode == "community" && community == "":
+			return mcp.NewToolResultError("community mode needs a community slug"), nil
+		}
+
+		progress := newProgress(ctx, req)
+		fetch := func(pageNum, pagesLeft int) (*listing.Result, error) {
+			params := api.RecordListParams{
+				Page:   pageNum,
+				Size:   size,
+				Status: req.GetString("status", ""),
+				Sort:   req.GetString("sort", ""),
+			}
+			switch mode {
+			case "authored":
+				params.Community = community
+				return listing.Authored(client, orcid, params)
+			case "uploaded":
+				return listing.Uploaded(client, params)
+			case "community":
+				return listing.Community(client, community, params)
+			case "communities":
+				var warnings []string
+				result, _, err := listing.AllCommunities(client, params, func(slug string, err error) {
+					warnings = append(warnings, fmt.Sprintf("%s: %v", slug, err))
+				}, func(done, total int, slug string) {
+					progress.step(total-done, "fetched records of community "+slug)
+				})
+				if err == nil && len(warnings) > 0 {
+					log.Printf("records_list: skipped communities: %s", strings.Join(warnings, "; "))
+				}
+				return result, err
+			default:
+				return nil, fmt.Errorf("unknown mode %q", mode)
+			}
+		}
+		rows, total, next, err := collectPages(progress, pageNum, pagesArg(req), fetch)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
-		return jsonResult(records)
+		pg := page[map[string]any]{Total: total, Hits: rows, NextCursor: nextCursor("records_list", key, next, size)}
+		return recordsResult(client, req, progress, pg, defaultListFields)
 	}
 }
 
 // --- records_search ---
 
 func recordsSearchTool() mcp.Tool {
-	return mcp.NewTool("records_search",
+	opts := []mcp.ToolOption{
 		mcp.WithDescription("Search published Zenodo records using Elasticsearch query syntax"),
 		mcp.WithString("q", mcp.Required(), mcp.Description("Search query (Elasticsearch syntax)")),
-		mcp.WithNumber("page", mcp.Description("Page number (default 1)")),
-		mcp.WithNumber("size", mcp.Description("Results per page (default 10)")),
 		mcp.WithString("sort", mcp.Description("Sort order, e.g. mostrecent, bestmatch")),
 		mcp.WithString("community", mcp.Description("Filter by community slug")),
+	}
+	opts = append(opts, pagingParams(true)...)
+	opts = append(opts, formatParams(defaultListFields)...)
+	opts = append(opts,
+		outputSchema[page[model.Record]](),
 		mcp.WithReadOnlyHintAnnotation(true),
 		mcp.WithDestructiveHintAnnotation(false),
 		mcp.WithOpenWorldHintAnnotation(true),
 	)
+	return mcp.NewTool("records_search", opts...)
 }
 
-func recordsSearchHandler(client *api.Client) server.ToolHandlerFunc {
+func recordsSearchHandler(fallback *api.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client := clientFrom(ctx, fallback)
 		q, err := req.RequireString("q")
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
-		params := api.RecordListParams{
-			Page:      req.GetInt("page", 1),
-			Size:      req.GetInt("size", 10),
-			Sort:      req.GetString("sort", ""),
-			Community: req.GetString("community", ""),
+		key := queryKey(req, "q", "sort", "community")
+		pageNum, size, err := pageArgs(req, "records_search", key)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		result, err := client.SearchRecords(q, params)
+		progress := newProgress(ctx, req)
+		rows, total, next, err := collectPages(progress, pageNum, pagesArg(req), func(pageNum, _ int) (*listing.Result, error) {
+			return listing.Search(client, q, api.RecordListParams{
+				Page:      pageNum,
+				Size:      size,
+				Sort:      req.GetString("sort", ""),
+				Community: req.GetString("community", ""),
+			})
+		})
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
-		return jsonResult(result)
+		pg := page[map[string]any]{Total: total, Hits: rows, NextCursor: nextCursor("records_search", key, next, size)}
+		return recordsResult(client, req, progress, pg, defaultListFields)
 	}
 }
 
 // --- records_get ---
 
 func recordsGetTool() mcp.Tool {
-	return mcp.NewTool("records_get",
+	opts := []mcp.ToolOption{
 		mcp.WithDescription("Get a single published Zenodo record by its numeric ID"),
 		mcp.WithNumber("id", mcp.Required(), mcp.Description("Record ID")),
+	}
+	opts = append(opts, formatParams("")...)
+	opts = append(opts,
+		outputSchema[model.Record](),
 		mcp.WithReadOnlyHintAnnotation(true),
 		mcp.WithDestructiveHintAnnotation(false),
 		mcp.WithOpenWorldHintAnnotation(true),
 	)
+	return mcp.NewTool("records_get", opts...)
 }
 
-func recordsGetHandler(client *api.Client) server.ToolHandlerFunc {
+func recordsGetHandler(fallback *api.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client := clientFrom(ctx, fallback)
 		id, err := req.RequireInt("id")
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
@@ -171,24 +385,30 @@ func recordsGetHandler(client *api.Client) server.ToolHandlerFunc {
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
-		return jsonResult(record)
+		return recordResult(client, req, newProgress(ctx, req), record)
 	}
 }
 
 // --- records_versions ---
 
 func recordsVersionsTool() mcp.Tool {
-	return mcp.NewTool("records_versions",
+	opts := []mcp.ToolOption{
 		mcp.WithDescription("List all versions of a Zenodo record"),
 		mcp.WithNumber("id", mcp.Required(), mcp.Description("Record ID (any version)")),
+	}
+	opts = append(opts, formatParams(defaultListFields)...)
+	opts = append(opts,
+		outputSchema[page[model.Record]](),
 		mcp.WithReadOnlyHintAnnotation(true),
 		mcp.WithDestructiveHintAnnotation(false),
 		mcp.WithOpenWorldHintAnnotation(true),
 	)
+	return mcp.NewTool("records_versions", opts...)
 }
 
-func recordsVersionsHandler(client *api.Client) server.ToolHandlerFunc {
+func recordsVersionsHandler(fallback *api.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client := clientFrom(ctx, fallback)
 		id, err := req.RequireInt("id")
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
@@ -197,60 +417,87 @@ func recordsVersionsHandler(client *api.Client) server.ToolHandlerFunc {
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
-		return jsonResult(result)
+		rows, err := listing.NormalizeCommunities(result.Hits.Hits)
+		if err != nil {
+			return nil, err
+		}
+		pg := page[map[string]any]{Total: result.Hits.Total, Hits: rows}
+		return recordsResult(client, req, newProgress(ctx, req), pg, defaultListFields)
 	}
 }
 
 // --- communities_list ---
 
 func communitiesListTool() mcp.Tool {
-	return mcp.NewTool("communities_list",
+	opts := []mcp.ToolOption{
 		mcp.WithDescription("List the authenticated user's Zenodo communities"),
 		mcp.WithString("q", mcp.Description("Search query to filter communities")),
-		mcp.WithNumber("page", mcp.Description("Page number (default 1)")),
-		mcp.WithNumber("size", mcp.Description("Results per page (default 10)")),
+	}
+	opts = append(opts, pagingParams(false)...)
+	opts = append(opts,
+		outputSchema[page[model.Community]](),
 		mcp.WithReadOnlyHintAnnotation(true),
 		mcp.WithDestructiveHintAnnotation(false),
 		mcp.WithOpenWorldHintAnnotation(true),
 	)
+	return mcp.NewTool("communities_list", opts...)
 }
 
-func communitiesListHandler(client *api.Client) server.ToolHandlerFunc {
+func communitiesListHandler(fallback *api.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client := clientFrom(ctx, fallback)
 		q := req.GetString("q", "")
-		page := req.GetInt("page", 1)
-		size := req.GetInt("size", 10)
-		result, err := client.ListUserCommunities(q, page, size)
+		key := queryKey(req, "q")
+		pageNum, size, err := pageArgs(req, "communities_list", key)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		result, err := client.ListUserCommunities(q, pageNum, size)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
-		return jsonResult(result)
+		pg := page[model.Community]{Total: result.Hits.Total, Hits: result.Hits.Hits}
+		if pageNum*size < result.Hits.Total {
+			pg.NextCursor = nextCursor("communities_list", key, pageNum+1, size)
+		}
+		return structuredResult(pg)
 	}
 }
 
 // --- licenses_search ---
 
 func licensesSearchTool() mcp.Tool {
-	return mcp.NewTool("licenses_search",
+	opts := []mcp.ToolOption{
 		mcp.WithDescription("Search available Zenodo licenses"),
 		mcp.WithString("q", mcp.Description("Search query to filter licenses")),
-		mcp.WithNumber("page", mcp.Description("Page number (default 1)")),
-		mcp.WithNumber("size", mcp.Description("Results per page (default 10)")),
+	}
+	opts = append(opts, pagingParams(false)...)
+	opts = append(opts,
+		outputSchema[page[model.License]](),
 		mcp.WithReadOnlyHintAnnotation(true),
 		mcp.WithDestructiveHintAnnotation(false),
 		mcp.WithOpenWorldHintAnnotation(true),
 	)
+	return mcp.NewTool("licenses_search", opts...)
 }
 
-func licensesSearchHandler(client *api.Client) server.ToolHandlerFunc {
+func licensesSearchHandler(fallback *api.Client) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client := clientFrom(ctx, fallback)
 		q := req.GetString("q", "")
-		page := req.GetInt("page", 1)
-		size := req.GetInt("size", 10)
-		result, err := client.SearchLicenses(q, page, size)
+		key := queryKey(req, "q")
+		pageNum, size, err := pageArgs(req, "licenses_search", key)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
-		return jsonResult(result)
+		result, err := client.SearchLicenses(q, pageNum, size)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		pg := page[model.License]{Total: result.Hits.Total, Hits: result.Hits.Hits}
+		if pageNum*size < result.Hits.Total {
+			pg.NextCursor = nextCursor("licenses_search", key, pageNum+1, size)
+		}
+		return structuredResult(pg)
 	}
 }
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	defaultPageSize = 10
+	// maxPages caps how many pages one call may fetch.
+	maxPages = 10
+)
+
+// page is the structured result of a paginated tool. Hits is omitted when
+// the text content carries the results in another format (CSV, BibTeX).
+type page[T any] struct {
+	Total      int    `json:"total" jsonschema:"required" jsonschema_description:"Number of matching results"`
+	Hits       []T    `json:"hits,omitempty" jsonschema_description:"Results on this page, projected to the requested fields"`
+	NextCursor string `json:"next_cursor,omitempty" jsonschema_description:"Pass as cursor to fetch the next page; absent on the last page"`
+}
+
+// outputSchema derives a tool's output schema from a Go type. Fields are
+// only required when tagged jsonschema:"required", so projected results
+// still validate.
+func outputSchema[T any]() mcp.ToolOption {
+	r := jsonschema.Reflector{
+		DoNotReference:             true,
+		Anonymous:                  true,
+		AllowAdditionalProperties:  true,
+		RequiredFromJSONSchemaTags: true,
+	}
+	var zero T
+	schema := r.Reflect(zero)
+	schema.Version = ""
+	schema.Type = "object"
+	b, err := json.Marshal(schema)
+	if err != nil {
+		panic(fmt.Sprintf("output schema for %T: %v", zero, err))
+	}
+	return mcp.WithRawOutputSchema(b)
+}
+
+// structuredResult returns v as structured content, with indented JSON as
+// the text content for clients that do not read structured content.
+func structuredResult(v any) (*mcp.CallToolResult, error) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshaling result: %w", err)
+	}
+	return mcp.NewToolResultStructured(v, string(b)), nil
+}
+
+// pagingParams are the tool options of paginated tools. multi adds the
+// pages option for tools that can fetch several pages in one call.
+func pagingParams(multi bool) []mcp.ToolOption {
+	opts := []mcp.ToolOption{
+		mcp.WithNumber("size", mcp.Description(fmt.Sprintf("Results per page (default %d)", defaultPageSize))),
+		mcp.WithString("cursor", mcp.Description("next_cursor from a previous call with the same arguments, to continue where it left off")),
+	}
+	if multi {
+		opts = append(opts, mcp.WithNumber("pages", mcp.Description(fmt.Sprintf("Number of pages to fetch in this call (default 1, max %d). Progress is reported per page.", maxPages))))
+	}
+	return opts
+}
+
+// cursor is the decoded form of next_cursor. Tool and Query tie it to the
+// call it came from, so it cannot be replayed against a different query.
+type cursor struct {
+	Tool  string `json:"t"`
+	Query string `json:"q"`
+	Page  int    `json:"p"`
+	Size  int    `json:"s"`
+}
+
+// queryKey fingerprints the arguments that define a query.
+func queryKey(req mcp.CallToolRequest, names ...string) string {
+	h := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(h, "%s=%s\x00", name, req.GetString(name, ""))
+	}
+	return hex.EncodeToString(h.Sum(nil)[:6])
+}
+
+func encodeCursor(c cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	var c cursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err == nil {
+		err = json.Unmarshal(b, &c)
+	}
+	if err != nil || c.Page < 1 || c.Size < 1 {
+		return cursor{}, fmt.Errorf("invalid cursor")
+	}
+	return c, nil
+}
+
+// pageArgs returns the page and page size to fetch: from the cursor
+// argument if present, else the first page with the size argument.
+func pageArgs(req mcp.CallToolRequest, tool, key string) (pageNum, size int, err error) {
+	if raw := strings.TrimSpace(req.GetString("cursor", "")); raw != "" {
+		c, err := decodeCursor(raw)
+		if err != nil {
+			return 0, 0, err
+		}
+		if c.Tool != tool || c.Query != key {
+			return 0, 0, fmt.Errorf("cursor belongs to a different query; call %s again without a cursor", tool)
+		}
+		return c.Page, c.Size, nil
+	}
+	size = req.GetInt("size", defaultPageSize)
+	if size < 1 {
+		size = defaultPageSize
+	}
+	return 1, size, nil
+}
+
+// pagesArg returns the number of pages to fetch, clamped to 1..maxPages.
+func pagesArg(req mcp.CallToolRequest) int {
+	n := req.GetInt("pages", 1)
+	if n < 1 {
+		return 1
+	}
+	if n > maxPages {
+		return maxPages
+	}
+	return n
+}
+
+// nextCursor returns the cursor for nextPage, or "" if nextPage is 0.
+func nextCursor(tool, key string, nextPage, size int) string {
+	if nextPage == 0 {
+		return ""
+	}
+	return encodeCursor(cursor{Tool: tool, Query: key, Page: nextPage, Size: size})
+}
+
+// progressReporter sends MCP progress notifications for a request that
+// carried a progress token. A nil reporter, or one without a token, does
+// nothing.
+type progressReporter struct {
+	ctx   context.Context
+	srv   *server.MCPServer
+	token mcp.ProgressToken
+	done  int
+}
+
+func newProgress(ctx context.Context, req mcp.CallToolRequest) *progressReporter {
+	p := &progressReporter{ctx: ctx, srv: server.ServerFromContext(ctx)}
+	if req.Params.Meta != nil {
+		p.token = req.Params.Meta.ProgressToken
+	}
+	return p
+}
+
+// step reports one unit of work done, with remaining units still to come.
+// Progress only increases, as the protocol requires; the total may grow as
+// more work is discovered.
+func (p *progressReporter) step(remaining int, message string) {
+	if p == nil {
+		return
+	}
+	p.done++
+	if p.token == nil || p.srv == nil {
+		return
+	}
+	p.srv.SendNotificationToClient(p.ctx, "notifications/progress", map[string]any{
+		"progressToken": p.token,
+		"progress":      p.done,
+		"total":         p.done + remaining,
+		"message":       message,
+	})
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/ran-codes/zenodo-cli/internal/listing"
+)
+
+// registerPrompts adds prompt templates for common research-data tasks.
+// orcid is the configured ORCID, used when a prompt's orcid argument is empty.
+func registerPrompts(s *server.MCPServer, orcid string) {
+	s.AddPrompt(summarizeImpactPrompt(), summarizeImpactHandler(orcid))
+	s.AddPrompt(draftMetadataPrompt(), draftMetadataHandler(orcid))
+	s.AddPrompt(checkFAIRPrompt(), checkFAIRHandler())
+	s.AddPrompt(citationListPrompt(), citationListHandler(orcid))
+}
+
+// orcidArg returns the orcid argument, falling back to the configured ORCID.
+func orcidArg(req mcp.GetPromptRequest, configured string) (string, error) {
+	if v := strings.TrimSpace(req.Params.Arguments["orcid"]); v != "" {
+		return v, nil
+	}
+	if configured != "" {
+		return configured, nil
+	}
+	return "", fmt.Errorf("orcid is required (no ORCID configured; set one with `zenodo config set orcid <id>`)")
+}
+
+func promptResult(description, text string) *mcp.GetPromptResult {
+	return mcp.NewGetPromptResult(description, []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text)),
+	})
+}
+
+// --- summarize_impact ---
+
+func summarizeImpactPrompt() mcp.Prompt {
+	return mcp.NewPrompt("summarize_impact",
+		mcp.WithPromptDescription("Summarize a researcher's Zenodo impact: outputs, views, downloads and communities"),
+		mcp.WithArgument("orcid", mcp.ArgumentDescription("ORCID to summarize (default: the configured ORCID)")),
+		mcp.WithArgument("since", mcp.ArgumentDescription("Only count records published since this date (YYYY-MM-DD)")),
+	)
+}
+
+func summarizeImpactHandler(configured string) server.PromptHandlerFunc {
+	return func(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		orcid, err := orcidArg(req, configured)
+		if err != nil {
+			return nil, err
+		}
+		q := listing.ORCIDQuery(orcid)
+		if since := req.Params.Arguments["since"]; since != "" {
+			q = fmt.Sprintf("(%s) AND publication_date:[%s TO *]", q, since)
+		}
+
+		text := fmt.Sprintf(`Summarize the Zenodo impact of the researcher with ORCID %s.
+
+1. Use records_search with q: %q, with size 100, passing next_cursor back as cursor until it is absent.
+2. For each record note the title, resource type, publication date, DOI, communities and stats (unique views and downloads, for this version and all versions).
+3. Report:
+   - totals: number of outputs by resource type, total views and downloads
+   - the five most viewed and five most downloaded records, with DOIs
+   - the communities the work appears in
+   - trends over time by publication year
+4. Keep the summary to one page and note any records whose stats are missing.`, orcid, q)
+		return promptResult("Zenodo impact summary for "+orcid, text), nil
+	}
+}
+
+// --- draft_dataset_metadata ---
+
+func draftMetadataPrompt() mcp.Prompt {
+	return mcp.NewPrompt("draft_dataset_metadata",
+		mcp.WithPromptDescription("Draft Zenodo deposit metadata for a new dataset"),
+		mcp.WithArgument("description", mcp.ArgumentDescription("What the dataset contains and how it was produced"), mcp.RequiredArgument()),
+		mcp.WithArgument("title", mcp.ArgumentDescription("Working title")),
+		mcp.WithArgument("orcid", mcp.ArgumentDescription("ORCID of the first creator (default: the configured ORCID)")),
+		mcp.WithArgument("license", mcp.ArgumentDescription("Preferred license, e.g. cc-by-4.0")),
+	)
+}
+
+func draftMetadataHandler(configured string) server.PromptHandlerFunc {
+	return func(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args := req.Params.Arguments
+		desc := strings.TrimSpace(args["description"])
+		if desc == "" {
+			return nil, fmt.Errorf("description is required")
+		}
+
+		var b strings.Builder
+		b.WriteString("Draft Zenodo deposit metadata (legacy deposit JSON format) for a new dataset.\n\n")
+		fmt.Fprintf(&b, "Dataset description from the user:\n%s\n\n", desc)
+		if title := args["title"]; title != "" {
+			fmt.Fprintf(&b, "Working title: %s\n", title)
+		}
+		if orcid, err := orcidArg(req, configured); err == nil {
+			fmt.Fprintf(&b, "First creator ORCID: %s. Use records_search with q: %q to find how this person's name and affiliation appear on their existing records, and reuse them.\n", orcid, listing.ORCIDQuery(orcid))
+		}
+		if license := args["license"]; license != "" {
+			fmt.Fprintf(&b, "Preferred license: %s. Confirm the identifier with licenses_search.\n", license)
+		} else {
+			b.WriteString("Suggest an open license and confirm its identifier with licenses_search.\n")
+		}
+		b.WriteString(`
+Produce a JSON object with: title, description (HTML), upload_type "dataset",
+publication_date (today unless stated), access_right, license, creators (name as
+"Family, Given", orcid, affiliation), keywords (5-10), related_identifiers where
+the description mentions papers, code or other datasets (with scheme and
+relation), grants if funding is mentioned, and version.
+
+List any information you had to guess, and ask the user to confirm it before the
+metadata is used with deposit_update.`)
+		return promptResult("Draft dataset metadata", b.String()), nil
+	}
+}
+
+// --- check_fairness ---
+
+func checkFAIRPrompt() mcp.Prompt {
+	return mcp.NewPrompt("check_fairness",
+		mcp.WithPromptDescription("Check a record against FAIR principles and suggest improvements"),
+		mcp.WithArgument("id", mcp.ArgumentDescription("Zenodo record ID"), mcp.RequiredArgument()),
+	)
+}
+
+func checkFAIRHandler() server.PromptHandlerFunc {
+	return func(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		id := strings.TrimSpace(req.Params.Arguments["id"])
+		if id == "" {
+			return nil, fmt.Errorf("id is required")
+		}
+		text := fmt.Sprintf(`Assess how FAIR Zenodo record %s is. Read it with records_get (id %s) or the zenodo://records/%s resource.
+
+Check each indicator, answering pass, partial or fail with a one-line reason:
+
+Findable
+- persistent identifier (DOI) present
+- rich metadata: descriptive title, description of at least 50 words, keywords
+- creators identified by ORCID
+
+Accessible
+- access_right is machine-readable; embargoed or restricted records explain access conditions
+- files are present and downloadable (for open records)
+
+Interoperable
+- files use open, standard formats (e.g. CSV, JSON, NetCDF, TIFF rather than proprietary formats)
+- related identifiers link to papers, code or datasets with a scheme and relation
+- affiliations identified (ideally by ROR)
+
+Reusable
+- an open license is set
+- funding (grants) and version are recorded
+- the description explains provenance and how the data was produced
+
+Finish with the three changes that would improve the record most. If write tools are
+available, offer to apply metadata changes with deposit_update.`, id, id, id)
+		return promptResult("FAIR check for record "+id, text), nil
+	}
+}
+
+// --- citation_list ---
+
+func citationListPrompt() mcp.Prompt {
+	return mcp.NewPrompt("citation_list",
+		mcp.WithPromptDescription("Prepare a citation list of a researcher's Zenodo records"),
+		mcp.WithArgument("orcid", mcp.ArgumentDescription("ORCID whose records to cite (default: the configured ORCID)")),
+		mcp.WithArgument("style", mcp.ArgumentDescription("Citation style, e.g. APA, Chicago or bibtex (default: APA)")),
+		mcp.WithArgument("type", mcp.ArgumentDescription("Only include this resource type, e.g. dataset or software")),
+	)
+}
+
+func citationListHandler(configured string) server.PromptHandlerFunc {
+	return func(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		orcid, err := orcidArg(req, configured)
+		if err != nil {
+			return nil, err
+		}
+		style := req.Params.Arguments["style"]
+		if style == "" {
+			style = "APA"
+		}
+		q := listing.ORCIDQuery(orcid)
+		if typ := req.Params.Arguments["type"]; typ != "" {
+			q = fmt.Sprintf("(%s) AND resource_type.type:%s", q, typ)
+		}
+
+		text := fmt.Sprintf(`Prepare a citation list of the Zenodo records of the researcher with ORCID %s.
+
+1. Use records_search with q: %q, with size 100, passing next_cursor back as cursor until it is absent.
+2. Keep only the latest version of each concept record (same conceptrecid).
+3. Read zenodo://records/{id}/bibtex for each record to get accurate author lists and DOIs.
+4. Format every entry in %s style, ordered by publication date (newest first), and include the DOI as a URL.
+5. If the style is bibtex, return one BibTeX entry per record instead.`, orcid, q, style)
+		return promptResult("Citation list for "+orcid, text), nil
+	}
+}
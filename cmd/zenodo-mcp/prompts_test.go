@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func getPrompt(t *testing.T, s *server.MCPServer, name string, args map[string]string) (string, error) {
+	t.Helper()
+	c := newTestClient(t, s)
+	var req mcp.GetPromptRequest
+	req.Params.Name = name
+	req.Params.Arguments = args
+	res, err := c.GetPrompt(context.Background(), req)
+	if err != nil {
+		return "", err
+	}
+	return res.Messages[0].Content.(mcp.TextContent).Text, nil
+}
+
+func TestPrompts_UseConfiguredORCID(t *testing.T) {
+	s := server.NewMCPServer("zenodo", "test")
+	registerPrompts(s, "0000-0002-1825-0097")
+
+	for _, name := range []string{"summarize_impact", "citation_list"} {
+		text, err := getPrompt(t, s, name, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !strings.Contains(text, "creators.orcid:0000-0002-1825-0097 OR contributors.orcid:0000-0002-1825-0097") {
+			t.Errorf("%s does not use the configured ORCID:\n%s", name, text)
+		}
+	}
+
+	text, err := getPrompt(t, s, "citation_list", map[string]string{"orcid": "0000-0001-5109-3700", "style": "bibtex"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(text, "0000-0001-5109-3700") || !strings.Contains(text, "bibtex style") {
+		t.Errorf("argument override ignored:\n%s", text)
+	}
+}
+
+func TestPrompts_RequireORCIDWhenUnconfigured(t *testing.T) {
+	s := server.NewMCPServer("zenodo", "test")
+	registerPrompts(s, "")
+
+	if _, err := getPrompt(t, s, "summarize_impact", nil); err == nil {
+		t.Error("expected error without an ORCID")
+	}
+	// draft_dataset_metadata works without an ORCID.
+	if _, err := getPrompt(t, s, "draft_dataset_metadata", map[string]string{"description": "Ocean temperatures"}); err != nil {
+		t.Errorf("draft_dataset_metadata: %v", err)
+	}
+}
+
+func TestPrompts_CheckFairness(t *testing.T) {
+	s := server.NewMCPServer("zenodo", "test")
+	registerPrompts(s, "")
+
+	text, err := getPrompt(t, s, "check_fairness", map[string]string{"id": "42"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(text, "zenodo://records/42") {
+		t.Errorf("prompt does not reference the record:\n%s", text)
+	}
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+const (
+	mimeJSON     = "application/json"
+	mimeBibTeX   = "application/x-bibtex"
+	mimeDataCite = "application/vnd.datacite.datacite+xml"
+)
+
+// myRecordsPageSize is the page size used when listing the user's own records.
+const myRecordsPageSize = 100
+
+// registerResources adds the zenodo:// resource templates and the
+// zenodo://me/records resource.
+func registerResources(s *server.MCPServer, client *api.Client) {
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate("zenodo://records/{id}", "Zenodo record",
+			mcp.WithTemplateDescription("A published record's full metadata and file list"),
+			mcp.WithTemplateMIMEType(mimeJSON),
+		),
+		recordResourceHandler(client),
+	)
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate("zenodo://records/{id}/bibtex", "Zenodo record (BibTeX)",
+			mcp.WithTemplateDescription("BibTeX citation for a published record"),
+			mcp.WithTemplateMIMEType(mimeBibTeX),
+		),
+		rawRecordResourceHandler(client, mimeBibTeX),
+	)
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate("zenodo://records/{id}/datacite", "Zenodo record (DataCite XML)",
+			mcp.WithTemplateDescription("DataCite XML metadata for a published record"),
+			mcp.WithTemplateMIMEType(mimeDataCite),
+		),
+		rawRecordResourceHandler(client, mimeDataCite),
+	)
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate("zenodo://records/{id}/versions", "Zenodo record versions",
+			mcp.WithTemplateDescription("All versions of a record"),
+			mcp.WithTemplateMIMEType(mimeJSON),
+		),
+		versionsResourceHandler(client),
+	)
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate("zenodo://communities/{slug}", "Zenodo community",
+			mcp.WithTemplateDescription("A community's metadata, by slug or ID"),
+			mcp.WithTemplateMIMEType(mimeJSON),
+		),
+		communityResourceHandler(client),
+	)
+	s.AddResource(
+		mcp.NewResource("zenodo://me/records", "My Zenodo records",
+			mcp.WithResourceDescription("The authenticated user's records and drafts"),
+			mcp.WithMIMEType(mimeJSON),
+		),
+		myRecordsResourceHandler(s, client),
+	)
+}
+
+// templateArg returns a URI template variable from a resource read request.
+func templateArg(req mcp.ReadResourceRequest, name string) string {
+	switch v := req.Params.Arguments[name].(type) {
+	case []string:
+		if len(v) > 0 {
+			return v[0]
+		}
+	case string:
+		return v
+	}
+	return ""
+}
+
+func recordIDArg(req mcp.ReadResourceRequest) (int, error) {
+	raw := templateArg(req, "id")
+	id, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid record ID %q in %s", raw, req.Params.URI)
+	}
+	return id, nil
+}
+
+// jsonResource marshals v as the single JSON content of a resource.
+func jsonResource(uri string, v any) ([]mcp.ResourceContents, error) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshaling resource: %w", err)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: uri, MIMEType: mimeJSON, Text: string(b)},
+	}, nil
+}
+
+func recordResourceHandler(fallback *api.Client) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		client := clientFrom(ctx, fallback)
+		id, err := recordIDArg(req)
+		if err != nil {
+			return nil, err
+		}
+		record, err := client.GetRecord(id)
+		if err != nil {
+			return nil, err
+		}
+		return jsonResource(req.Params.URI, record)
+	}
+}
+
+// rawRecordResourceHandler serves a record in a non-JSON serialization via GetRaw.
+func rawRecordResourceHandler(fallback *api.Client, mimeType string) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		client := clientFrom(ctx, fallback)
+		id, err := recordIDArg(req)
+		if err != nil {
+			return nil, err
+		}
+		data, err := client.GetRaw(fmt.Sprintf("/records/%d", id), mimeType)
+		if err != nil {
+			return nil, err
+		}
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{URI: req.Params.URI, MIMEType: mimeType, Text: string(data)},
+		}, nil
+	}
+}
+
+func versionsResourceHandler(fallback *api.Client) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		client := clientFrom(ctx, fallback)
+		id, err := recordIDArg(req)
+		if err != nil {
+			return nil, err
+		}
+		result, err := client.ListVersions(id)
+		if err != nil {
+			return nil, err
+		}
+		return jsonResource(req.Params.URI, result)
+	}
+}
+
+func communityResourceHandler(fallback *api.Client) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		client := clientFrom(ctx, fallback)
+		slug := templateArg(req, "slug")
+		if slug == "" {
+			return nil, fmt.Errorf("missing community slug in %s", req.Params.URI)
+		}
+		community, err := client.GetCommunity(slug)
+		if err != nil {
+			return nil, err
+		}
+		return jsonResource(req.Params.URI, community)
+	}
+}
+
+// myRecord is an entry of zenodo://me/records. URI is set for published
+// records, which can be read as zenodo://records/{id}.
+type myRecord struct {
+	ID       int    `json:"id"`
+	Title    string `json:"title"`
+	State    string `json:"state"`
+	DOI      string `json:"doi,omitempty"`
+	Modified string `json:"modified"`
+	URI      string `json:"uri,omitempty"`
+}
+
+// listMyRecords fetches every deposition of the authenticated user.
+func listMyRecords(client *api.Client) ([]model.Deposition, error) {
+	var all []model.Deposition
+	for page := 1; ; page++ {
+		deps, err := client.ListUserRecords(api.RecordListParams{Page: page, Size: myRecordsPageSize})
+		if err != nil {
+			return all, err
+		}
+		all = append(all, deps...)
+		if len(deps) < myRecordsPageSize {
+			return all, nil
+		}
+	}
+}
+
+func myRecordsResourceHandler(s *server.MCPServer, fallback *api.Client) server.ResourceHandlerFunc {
+	return func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		client := clientFrom(ctx, fallback)
+		deps, err := listMyRecords(client)
+		if err != nil {
+			return nil, err
+		}
+		// Resources are global to the server, so only the single-user
+		// (stdio) server lists the user's records as browsable resources.
+		if _, perConnection := connectionFrom(ctx); !perConnection {
+			publishMyRecords(s, client, deps)
+		}
+
+		entries := make([]myRecord, 0, len(deps))
+		for _, d := range deps {
+			e := myRecord{ID: d.ID, Title: d.Metadata.Title, State: d.State, DOI: d.DOI, Modified: d.Modified.Format("2006-01-02")}
+			if d.Submitted {
+				e.URI = fmt.Sprintf("zenodo://records/%d", d.ID)
+			}
+			entries = append(entries, e)
+		}
+		return jsonResource(req.Params.URI, entries)
+	}
+}
+
+// publishMyRecords registers each of the user's published records as a
+// concrete resource, so clients can browse them in resources/list.
+func publishMyRecords(s *server.MCPServer, client *api.Client, deps []model.Deposition) {
+	handler := recordResourceHandler(client)
+	var resources []server.ServerResource
+	for _, d := range deps {
+		if !d.Submitted {
+			continue
+		}
+		uri := fmt.Sprintf("zenodo://records/%d", d.ID)
+		id := strconv.Itoa(d.ID)
+		resources = append(resources, server.ServerResource{
+			Resource: mcp.NewResource(uri, d.Metadata.Title,
+				mcp.WithResourceDescription(fmt.Sprintf("Zenodo record %d", d.ID)),
+				mcp.WithMIMEType(mimeJSON),
+			),
+			Handler: func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+				req.Params.Arguments = map[string]any{"id": id}
+				return handler(ctx, req)
+			},
+		})
+	}
+	if len(resources) > 0 {
+		s.AddResources(resources...)
+	}
+}
+
+// loadMyRecords populates the browsable record resources at startup. It
+// runs in the background so a slow API does not delay the handshake.
+func loadMyRecords(s *server.MCPServer, client *api.Client) {
+	deps, err := listMyRecords(client)
+	if err != nil {
+		log.Printf("listing records for resources: %v", err)
+	}
+	publishMyRecords(s, client, deps)
+}
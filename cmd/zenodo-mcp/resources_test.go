@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// newTestClient starts an in-process MCP client against s.
+func newTestClient(t *testing.T, s *server.MCPServer) *client.Client {
+	t.Helper()
+	c, err := client.NewInProcessClient(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err := c.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	var init mcp.InitializeRequest
+	init.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	if _, err := c.Initialize(ctx, init); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func readResource(t *testing.T, c *client.Client, uri string) mcp.TextResourceContents {
+	t.Helper()
+	var req mcp.ReadResourceRequest
+	req.Params.URI = uri
+	res, err := c.ReadResource(context.Background(), req)
+	if err != nil {
+		t.Fatalf("ReadResource(%s) error: %v", uri, err)
+	}
+	return res.Contents[0].(mcp.TextResourceContents)
+}
+
+func TestResources(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/records/42" && r.Header.Get("Accept") == mimeBibTeX:
+			w.Write([]byte("@misc{doe_2024}"))
+		case r.URL.Path == "/records/42":
+			json.NewEncoder(w).Encode(model.Record{ID: 42, Metadata: model.Metadata{Title: "Ocean data"}})
+		case r.URL.Path == "/records/42/versions":
+			json.NewEncoder(w).Encode(model.RecordSearchResult{Hits: model.RecordHits{Total: 2}})
+		case r.URL.Path == "/communities/my-org":
+			json.NewEncoder(w).Encode(model.Community{Slug: "my-org"})
+		case r.URL.Path == "/deposit/depositions":
+			json.NewEncoder(w).Encode([]model.Deposition{
+				{ID: 42, Submitted: true, State: "done", Metadata: model.Metadata{Title: "Ocean data"}},
+				{ID: 43, State: "unsubmitted", Metadata: model.Metadata{Title: "Draft"}},
+			})
+		default:
+			t.Errorf("unexpected %s", r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	s := server.NewMCPServer("zenodo", "test", server.WithResourceCapabilities(false, true))
+	registerResources(s, api.NewClient(srv.URL, "tok"))
+	c := newTestClient(t, s)
+
+	if got := readResource(t, c, "zenodo://records/42"); got.MIMEType != mimeJSON || !strings.Contains(got.Text, "Ocean data") {
+		t.Errorf("record = %+v", got)
+	}
+	if got := readResource(t, c, "zenodo://records/42/bibtex"); got.MIMEType != mimeBibTeX || got.Text != "@misc{doe_2024}" {
+		t.Errorf("bibtex = %+v", got)
+	}
+	if got := readResource(t, c, "zenodo://records/42/versions"); !strings.Contains(got.Text, `"total": 2`) {
+		t.Errorf("versions = %s", got.Text)
+	}
+	if got := readResource(t, c, "zenodo://communities/my-org"); !strings.Contains(got.Text, "my-org") {
+		t.Errorf("community = %s", got.Text)
+	}
+
+	mine := readResource(t, c, "zenodo://me/records")
+	var entries []myRecord
+	json.Unmarshal([]byte(mine.Text), &entries)
+	if len(entries) != 2 || entries[0].URI != "zenodo://records/42" || entries[1].URI != "" {
+		t.Errorf("me/records = %+v", entries)
+	}
+
+	// Reading me/records publishes the user's published records for browsing.
+	list, err := c.ListResources(context.Background(), mcp.ListResourcesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var uris []string
+	for _, r := range list.Resources {
+		uris = append(uris, r.URI)
+	}
+	if strings.Join(uris, ",") != "zenodo://me/records,zenodo://records/42" {
+		t.Errorf("resources = %v", uris)
+	}
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// searchServer answers /records searches with two records and serves their
+// BibTeX. The last search query is stored in lastQ.
+func searchServer(t *testing.T, lastQ *string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/records":
+			*lastQ = r.URL.Query().Get("q")
+			json.NewEncoder(w).Encode(model.RecordSearchResult{Hits: model.RecordHits{Total: 25, Hits: []model.Record{
+				{ID: 1, Title: "One", Metadata: model.Metadata{Title: "One", Communities: []model.CommunityRef{{ID: "lab"}}}, Stats: model.Stats{Downloads: 5}},
+				{ID: 2, Title: "Two", Metadata: model.Metadata{Title: "Two"}},
+			}}})
+		case "/records/1", "/records/2":
+			if r.Header.Get("Accept") != mimeBibTeX {
+				t.Errorf("Accept = %q", r.Header.Get("Accept"))
+			}
+			w.Write([]byte("@misc{r" + strings.TrimPrefix(r.URL.Path, "/records/") + "}\n"))
+		default:
+			t.Errorf("unexpected %s", r.URL)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRecordsList_AuthoredByConfiguredORCID(t *testing.T) {
+	var q string
+	srv := searchServer(t, &q)
+	h := recordsListHandler(api.NewClient(srv.URL, "tok"), "0000-0002-1825-0097")
+
+	_, text := callTool(t, h, map[string]any{"fields": "id,community,stats.downloads"})
+	if q != "creators.orcid:0000-0002-1825-0097 OR contributors.orcid:0000-0002-1825-0097" {
+		t.Errorf("q = %q", q)
+	}
+	var got struct {
+		Total int              `json:"total"`
+		Hits  []map[string]any `json:"hits"`
+	}
+	if err := json.Unmarshal([]byte(text), &got); err != nil {
+		t.Fatalf("result is not JSON: %v\n%s", err, text)
+	}
+	if got.Total != 25 || len(got.Hits) != 2 || got.Hits[0]["community"] != "lab" || got.Hits[0]["stats.downloads"] != float64(5) {
+		t.Errorf("result = %+v", got)
+	}
+	if _, ok := got.Hits[0]["title"]; ok {
+		t.Error("title should be projected out")
+	}
+}
+
+func TestRecordsList_AuthoredNeedsORCID(t *testing.T) {
+	var q string
+	srv := searchServer(t, &q)
+	res, _ := callTool(t, recordsListHandler(api.NewClient(srv.URL, "tok"), ""), map[string]any{"mode": "authored"})
+	if !res.IsError {
+		t.Error("expected error without an ORCID")
+	}
+}
+
+func TestRecordsSearch_Formats(t *testing.T) {
+	var q string
+	srv := searchServer(t, &q)
+	h := recordsSearchHandler(api.NewClient(srv.URL, "tok"))
+
+	_, text := callTool(t, h, map[string]any{"q": "ocean", "format": "csv", "fields": "id,title"})
+	if text != "id,title\n1,One\n2,Two\n" {
+		t.Errorf("csv = %q", text)
+	}
+
+	_, text = callTool(t, h, map[string]any{"q": "ocean", "format": "bibtex"})
+	if text != "@misc{r1}\n\n@misc{r2}" {
+		t.Errorf("bibtex = %q", text)
+	}
+
+	_, text = callTool(t, h, map[string]any{"q": "ocean", "fields": "stats"})
+	if !strings.Contains(text, `"stats.downloads": 5`) || strings.Contains(text, "metadata") {
+		t.Errorf("stats preset = %s", text)
+	}
+}
+
+func TestRecordsSearch_Cursor(t *testing.T) {
+	var q string
+	srv := searchServer(t, &q)
+	h := recordsSearchHandler(api.NewClient(srv.URL, "tok"))
+
+	res, _ := callTool(t, h, map[string]any{"q": "ocean", "size": 10})
+	first := res.StructuredContent.(page[map[string]any])
+	if first.Total != 25 || len(first.Hits) != 2 || first.NextCursor == "" {
+		t.Fatalf("first page = %+v", first)
+	}
+	c, err := decodeCursor(first.NextCursor)
+	if err != nil || c.Page != 2 || c.Size != 10 {
+		t.Errorf("cursor = %+v, %v", c, err)
+	}
+
+	// Page 3 of 10 covers the last of 25 matches.
+	res, _ = callTool(t, h, map[string]any{"q": "ocean", "cursor": nextCursor("records_search", c.Query, 3, 10)})
+	if last := res.StructuredContent.(page[map[string]any]); last.NextCursor != "" {
+		t.Errorf("last page has next_cursor %q", last.NextCursor)
+	}
+
+	// A cursor cannot be replayed against another query.
+	res, _ = callTool(t, h, map[string]any{"q": "rivers", "cursor": first.NextCursor})
+	if !res.IsError {
+		t.Error("expected error for a cursor from another query")
+	}
+	res, _ = callTool(t, h, map[string]any{"q": "ocean", "cursor": "not-a-cursor"})
+	if !res.IsError {
+		t.Error("expected error for an invalid cursor")
+	}
+}
+
+func TestRecordsSearch_PagesReportProgress(t *testing.T) {
+	var q string
+	srv := searchServer(t, &q)
+	// Notifications need a client session, which the streamable HTTP
+	// transport provides.
+	mcpSrv := newHTTPTestServer(t, srv.URL, "")
+	c, err := client.NewStreamableHttpClient(mcpSrv.URL + "/mcp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	ctx := context.Background()
+	if err := c.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	var init mcp.InitializeRequest
+	init.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	if _, err := c.Initialize(ctx, init); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var progress []float64
+	c.OnNotification(func(n mcp.JSONRPCNotification) {
+		if n.Method != "notifications/progress" {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		progress = append(progress, n.Params.AdditionalFields["progress"].(float64))
+	})
+
+	var req mcp.CallToolRequest
+	req.Params.Name = "records_search"
+	req.Params.Arguments = map[string]any{"q": "ocean", "size": 2, "pages": 3}
+	req.Params.Meta = &mcp.Meta{ProgressToken: "p1"}
+	res, err := c.CallTool(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got page[map[string]any]
+	b, _ := json.Marshal(res.StructuredContent)
+	json.Unmarshal(b, &got)
+	if len(got.Hits) != 6 {
+		t.Errorf("hits = %d, want 6 from 3 pages", len(got.Hits))
+	}
+	if c, _ := decodeCursor(got.NextCursor); c.Page != 4 {
+		t.Errorf("next page = %d, want 4", c.Page)
+	}
+
+	// One notification per page. The transport may drop the last one when
+	// it races the response, so only the first two are required.
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for {
+		mu.Lock()
+		n := len(progress)
+		mu.Unlock()
+		if n == 3 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(progress) < 2 || progress[0] != 1 || progress[1] != 2 {
+		t.Errorf("progress = %v", progress)
+	}
+}
+
+func TestOutputSchemas(t *testing.T) {
+	for _, tool := range []mcp.Tool{recordsListTool(), recordsSearchTool(), recordsGetTool(), recordsVersionsTool(), communitiesListTool(), licensesSearchTool()} {
+		var schema struct {
+			Type       string                     `json:"type"`
+			Required   []string                   `json:"required"`
+			Properties map[string]json.RawMessage `json:"properties"`
+		}
+		if err := json.Unmarshal(tool.RawOutputSchema, &schema); err != nil {
+			t.Fatalf("%s: %v", tool.Name, err)
+		}
+		if schema.Type != "object" {
+			t.Errorf("%s: type = %q", tool.Name, schema.Type)
+		}
+		if tool.Name == "records_get" {
+			if _, ok := schema.Properties["metadata"]; !ok || len(schema.Required) != 0 {
+				t.Errorf("records_get schema = %s", tool.RawOutputSchema)
+			}
+			continue
+		}
+		if _, ok := schema.Properties["next_cursor"]; !ok || len(schema.Required) != 1 || schema.Required[0] != "total" {
+			t.Errorf("%s schema = %s", tool.Name, tool.RawOutputSchema)
+		}
+	}
+}
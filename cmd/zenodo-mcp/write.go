@@ -0,0 +1,435 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/config"
+	"github.com/ran-codes/zenodo-cli/internal/deposit"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+	"github.com/ran-codes/zenodo-cli/internal/output"
+	"github.com/ran-codes/zenodo-cli/internal/validate"
+)
+
+// confirmTTL is how long a preview's confirm token stays valid.
+const confirmTTL = 10 * time.Minute
+
+const writeInstructions = `
+## Write tools
+
+deposit_update, deposit_publish, deposit_new_version and deposit_discard are
+enabled. Each works in two steps:
+
+1. Call the tool without confirm_token. Nothing is changed; the result is a
+   preview (for updates and publishing, a diff) and a confirm_token.
+2. Show the preview to the user. Only if they approve, call the same tool
+   again with the same id and the confirm_token.
+
+Tokens are single-use and expire after 10 minutes. If the deposition changes
+after the preview, the confirmation is rejected and a new preview is needed.
+All calls are written to an audit log.
+`
+
+// writesAllowed reports whether write tools are enabled, via the
+// ZENODO_MCP_ALLOW_WRITES env var or the mcp.allow_writes config key.
+func writesAllowed(cfg *config.Config) bool {
+	if v := os.Getenv("ZENODO_MCP_ALLOW_WRITES"); v != "" {
+		ok, _ := strconv.ParseBool(v)
+		return ok
+	}
+	ok, _ := strconv.ParseBool(fmt.Sprintf("%v", cfg.Get("mcp.allow_writes")))
+	return ok
+}
+
+// pendingWrite is an action that has been previewed and awaits confirmation.
+type pendingWrite struct {
+	tool string
+	id   string
+	// identity is the connection that requested the preview; over HTTP a
+	// token can only be redeemed with the same Zenodo token.
+	identity string
+	// modified is the deposition's modification time at preview; a change
+	// in between invalidates the confirmation.
+	modified time.Time
+	// metadata is the merged metadata shown in a deposit_update preview.
+	// It is what gets applied, whatever the confirming call passes.
+	metadata *model.Metadata
+	expires  time.Time
+}
+
+// confirmations issues and redeems single-use confirm tokens.
+type confirmations struct {
+	mu      sync.Mutex
+	pending map[string]pendingWrite
+	now     func() time.Time
+}
+
+func newConfirmations() *confirmations {
+	return &confirmations{pending: make(map[string]pendingWrite), now: time.Now}
+}
+
+// issue stores a pending write and returns its single-use token and expiry.
+func (c *confirmations) issue(p pendingWrite) (string, time.Time, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", time.Time{}, fmt.Errorf("generating confirm token: %w", err)
+	}
+	token := hex.EncodeToString(b)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for t, old := range c.pending {
+		if now.After(old.expires) {
+			delete(c.pending, t)
+		}
+	}
+	p.expires = now.Add(confirmTTL)
+	c.pending[token] = p
+	return token, p.expires, nil
+}
+
+// redeem consumes a token, checking it was issued to the same connection
+// for the same tool and deposition.
+func (c *confirmations) redeem(token, tool, id, identity string) (pendingWrite, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p, ok := c.pending[token]
+	if !ok {
+		return p, fmt.Errorf("unknown or already used confirm_token; call %s without confirm_token to get a new preview", tool)
+	}
+	delete(c.pending, token)
+	if p.identity != identity {
+		return p, fmt.Errorf("confirm_token was issued to a different connection")
+	}
+	if p.tool != tool || p.id != id {
+		return p, fmt.Errorf("confirm_token was issued for %s on deposition %s, not %s on %s", p.tool, p.id, tool, id)
+	}
+	if c.now().After(p.expires) {
+		return p, fmt.Errorf("confirm_token expired; call %s without confirm_token to get a new preview", tool)
+	}
+	return p, nil
+}
+
+// writeTools holds the shared state of the write tools.
+type writeTools struct {
+	client *api.Client
+	// mode selects the write API, as for the CLI's deposit commands.
+	mode    deposit.Mode
+	confirm *confirmations
+	audit   *auditLog
+}
+
+// preview is returned by the first, non-mutating call of a write tool.
+type preview struct {
+	Action       string    `json:"action"`
+	DepositionID string    `json:"deposition_id"`
+	Title        string    `json:"title"`
+	State        string    `json:"state"`
+	Diff         string    `json:"diff,omitempty"`
+	Note         string    `json:"note,omitempty"`
+	ConfirmToken string    `json:"confirm_token,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at,omitzero"`
+	NextStep     string    `json:"next_step,omitempty"`
+}
+
+func (w *writeTools) register(s *server.MCPServer) {
+	s.AddTool(depositUpdateTool(), w.handler("deposit_update", deposit.Backend.Get, w.previewUpdate, w.applyUpdate))
+	s.AddTool(depositPublishTool(), w.handler("deposit_publish", deposit.Backend.Get, w.previewPublish, w.applyPublish))
+	s.AddTool(depositNewVersionTool(), w.handler("deposit_new_version", deposit.Backend.Published, w.previewNewVersion, w.applyNewVersion))
+	s.AddTool(depositDiscardTool(), w.handler("deposit_discard", deposit.Backend.Get, w.previewDiscard, w.applyDiscard))
+}
+
+// loadFunc fetches what a tool acts on: the draft, or for a new version
+// the published record.
+type loadFunc func(b deposit.Backend, id string) (*deposit.Draft, error)
+type previewFunc func(b deposit.Backend, req mcp.CallToolRequest, d *deposit.Draft) (*preview, *pendingWrite, error)
+type applyFunc func(b deposit.Backend, d *deposit.Draft, p pendingWrite) (any, error)
+
+// handler implements the two-step flow shared by all write tools: without
+// confirm_token it previews and issues a token; with one it re-checks the
+// deposition and applies the previewed action. Both go through the write
+// API of the profile's mode.
+func (w *writeTools) handler(tool string, load loadFunc, doPreview previewFunc, doApply applyFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		backend := deposit.New(clientFrom(ctx, w.client), w.mode)
+		identity := identityFrom(ctx)
+		id, err := requireID(req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		entry := auditEntry{Tool: tool, DepositionID: id, Phase: "preview", Identity: identity}
+		fail := func(err error) (*mcp.CallToolResult, error) {
+			entry.Outcome, entry.Detail = "error", err.Error()
+			w.audit.record(entry)
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		token := req.GetString("confirm_token", "")
+		if token == "" {
+			d, err := load(backend, id)
+			if err != nil {
+				return fail(err)
+			}
+			pv, pending, err := doPreview(backend, req, d)
+			if err != nil {
+				return fail(err)
+			}
+			if pending == nil {
+				entry.Outcome, entry.Detail = "noop", pv.Note
+				w.audit.record(entry)
+				return jsonResult(pv)
+			}
+			pending.tool, pending.id, pending.identity, pending.modified = tool, id, identity, d.Modified
+			pv.Action, pv.DepositionID, pv.Title, pv.State = tool, id, d.Metadata.Title, d.State
+			if pv.ConfirmToken, pv.ExpiresAt, err = w.confirm.issue(*pending); err != nil {
+				return fail(err)
+			}
+			pv.NextStep = fmt.Sprintf("Show this preview to the user. If they approve, call %s with id=%s and confirm_token.", tool, id)
+			entry.Outcome = "ok"
+			w.audit.record(entry)
+			return jsonResult(pv)
+		}
+
+		entry.Phase = "confirm"
+		pending, err := w.confirm.redeem(token, tool, id, identity)
+		if err != nil {
+			entry.Phase = "rejected"
+			return fail(err)
+		}
+		d, err := load(backend, id)
+		if err != nil {
+			return fail(err)
+		}
+		if !d.Modified.Equal(pending.modified) {
+			entry.Phase = "rejected"
+			return fail(fmt.Errorf("deposition %s changed since the preview; call %s without confirm_token to preview again", id, tool))
+		}
+		result, err := doApply(backend, d, pending)
+		if err != nil {
+			return fail(err)
+		}
+		entry.Outcome = "ok"
+		w.audit.record(entry)
+		return jsonResult(result)
+	}
+}
+
+// requireID returns the id argument: a number on Zenodo, or a string such
+// as "q5jr8-hny72" on other InvenioRDM instances.
+func requireID(req mcp.CallToolRequest) (string, error) {
+	switch v := req.GetArguments()["id"].(type) {
+	case string:
+		if v != "" {
+			return v, nil
+		}
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case int:
+		return strconv.Itoa(v), nil
+	}
+	return "", fmt.Errorf("required argument \"id\" not found")
+}
+
+// diffText renders output.DiffMetadata without colour for a tool result.
+func diffText(old, new any) (string, bool, error) {
+	var buf bytes.Buffer
+	changed, err := output.DiffMetadata(&buf, old, new)
+	return strings.TrimSpace(buf.String()), changed, err
+}
+
+// publishedMetadata returns the metadata of the last published version of a
+// draft, or nil if it has never been published.
+func (w *writeTools) publishedMetadata(b deposit.Backend, d *deposit.Draft) (*model.Metadata, error) {
+	if !d.Published {
+		return nil, nil
+	}
+	rec, err := b.Published(d.ID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching published version: %w", err)
+	}
+	return &rec.Metadata, nil
+}
+
+// --- deposit_update ---
+
+func depositUpdateTool() mcp.Tool {
+	return mcp.NewTool("deposit_update",
+		mcp.WithDescription("Update a deposition's metadata (GET-merge-PUT). The first call returns a diff preview and a confirm_token; call again with the token to apply. The deposition must be a draft or unlocked for editing."),
+		mcp.WithString("id", mcp.Required(), mcp.Description("Deposition ID (the record ID in rdm mode)")),
+		mcp.WithObject("metadata", mcp.Description("Partial metadata in Zenodo deposit format; top-level keys replace current values")),
+		mcp.WithString("title", mcp.Description("Set title")),
+		mcp.WithString("description", mcp.Description("Set description (HTML allowed)")),
+		mcp.WithString("confirm_token", mcp.Description("Token from the preview call; applies the previewed changes")),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+func (w *writeTools) previewUpdate(b deposit.Backend, req mcp.CallToolRequest, d *deposit.Draft) (*preview, *pendingWrite, error) {
+	merged := d.Metadata
+	if m, ok := req.GetArguments()["metadata"]; ok && m != nil {
+		data, err := json.Marshal(m)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := merged.MergeJSON(data); err != nil {
+			return nil, nil, fmt.Errorf("parsing metadata: %w", err)
+		}
+	}
+	if title := req.GetString("title", ""); title != "" {
+		merged.Title = title
+	}
+	if desc := req.GetString("description", ""); desc != "" {
+		merged.Description = desc
+	}
+
+	// Missing related-identifier schemes are filled in, and show in the diff.
+	validate.FillSchemes(&merged)
+	if errs := validate.Metadata(merged); len(errs) > 0 {
+		return nil, nil, fmt.Errorf("metadata validation failed:\n- %s", strings.Join(errs, "\n- "))
+	}
+	diff, changed, err := diffText(d.Metadata, merged)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !changed {
+		return &preview{Action: "deposit_update", DepositionID: d.ID, Title: d.Metadata.Title, State: d.State, Note: "No changes detected."}, nil, nil
+	}
+	return &preview{Diff: diff}, &pendingWrite{metadata: &merged}, nil
+}
+
+func (w *writeTools) applyUpdate(b deposit.Backend, d *deposit.Draft, p pendingWrite) (any, error) {
+	result, err := b.Update(d, *p.metadata)
+	if err != nil {
+		return nil, fmt.Errorf("updating deposition: %w", err)
+	}
+	return result, nil
+}
+
+// --- deposit_publish ---
+
+func depositPublishTool() mcp.Tool {
+	return mcp.NewTool("deposit_publish",
+		mcp.WithDescription("Publish a deposition. Publishing mints a DOI and cannot be undone. The first call returns a preview (a diff against the published version when re-publishing) and a confirm_token; call again with the token to publish."),
+		mcp.WithString("id", mcp.Required(), mcp.Description("Deposition ID (the record ID in rdm mode)")),
+		mcp.WithString("confirm_token", mcp.Description("Token from the preview call")),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+func (w *writeTools) previewPublish(b deposit.Backend, req mcp.CallToolRequest, d *deposit.Draft) (*preview, *pendingWrite, error) {
+	published, err := w.publishedMetadata(b, d)
+	if err != nil {
+		return nil, nil, err
+	}
+	if errs := validate.Metadata(d.Metadata); len(errs) > 0 {
+		return nil, nil, fmt.Errorf("metadata validation failed:\n- %s", strings.Join(errs, "\n- "))
+	}
+	if published == nil {
+		diff, _, err := diffText(model.Metadata{}, d.Metadata)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &preview{Diff: diff, Note: "First publication: a DOI will be minted and the record becomes public (subject to access_right)."}, &pendingWrite{}, nil
+	}
+	diff, _, err := diffText(*published, d.Metadata)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &preview{Diff: diff, Note: "Re-publishing replaces the published metadata with the changes above."}, &pendingWrite{}, nil
+}
+
+func (w *writeTools) applyPublish(b deposit.Backend, d *deposit.Draft, p pendingWrite) (any, error) {
+	return b.Publish(p.id)
+}
+
+// --- deposit_new_version ---
+
+func depositNewVersionTool() mcp.Tool {
+	return mcp.NewTool("deposit_new_version",
+		mcp.WithDescription("Create a new draft version of a published record, copying its metadata and files. The first call returns a preview and a confirm_token; call again with the token to create the draft."),
+		mcp.WithString("id", mcp.Required(), mcp.Description("Record ID of the latest published version")),
+		mcp.WithString("confirm_token", mcp.Description("Token from the preview call")),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+func (w *writeTools) previewNewVersion(b deposit.Backend, req mcp.CallToolRequest, d *deposit.Draft) (*preview, *pendingWrite, error) {
+	note := "A new draft version will be created with a copy of this record's metadata and files. It stays unpublished until deposit_publish."
+	if d.Metadata.Version != "" {
+		note += fmt.Sprintf(" Current version string: %q.", d.Metadata.Version)
+	}
+	return &preview{Note: note}, &pendingWrite{}, nil
+}
+
+// newVersionResult reports the draft created by deposit_new_version.
+type newVersionResult struct {
+	DraftID  string `json:"draft_id"`
+	DraftURL string `json:"draft_url,omitempty"`
+}
+
+func (w *writeTools) applyNewVersion(b deposit.Backend, d *deposit.Draft, p pendingWrite) (any, error) {
+	draft, err := b.NewVersion(p.id)
+	if err != nil {
+		return nil, err
+	}
+	return newVersionResult{DraftID: draft.ID, DraftURL: draft.URL}, nil
+}
+
+// --- deposit_discard ---
+
+func depositDiscardTool() mcp.Tool {
+	return mcp.NewTool("deposit_discard",
+		mcp.WithDescription("Discard unpublished changes on a deposition, reverting to the published version. The first call returns a preview of the changes that would be lost and a confirm_token; call again with the token to discard."),
+		mcp.WithString("id", mcp.Required(), mcp.Description("Deposition ID (the record ID in rdm mode)")),
+		mcp.WithString("confirm_token", mcp.Description("Token from the preview call")),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+func (w *writeTools) previewDiscard(b deposit.Backend, req mcp.CallToolRequest, d *deposit.Draft) (*preview, *pendingWrite, error) {
+	published, err := w.publishedMetadata(b, d)
+	if err != nil {
+		return nil, nil, err
+	}
+	if published == nil {
+		return nil, nil, fmt.Errorf("deposition %s has never been published; discard only reverts edits to a published record", d.ID)
+	}
+	diff, _, err := diffText(d.Metadata, *published)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &preview{Diff: diff, Note: "These unpublished changes will be reverted to the published version."}, &pendingWrite{}, nil
+}
+
+// applyDiscard returns the published version the record reverted to.
+func (w *writeTools) applyDiscard(b deposit.Backend, d *deposit.Draft, p pendingWrite) (any, error) {
+	if err := b.Discard(p.id); err != nil {
+		return nil, err
+	}
+	return b.Published(p.id)
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/config"
+	"github.com/ran-codes/zenodo-cli/internal/deposit"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+func validDeposition() model.Deposition {
+	return model.Deposition{
+		ID:       100,
+		State:    "unsubmitted",
+		Modified: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Metadata: model.Metadata{
+			Title:           "Old Title",
+			Description:     "A description",
+			UploadType:      "dataset",
+			PublicationDate: "2024-01-01",
+			AccessRight:     "open",
+			License:         json.RawMessage(`"cc-by-4.0"`),
+			Creators:        []model.Creator{{Name: "Doe, Jane"}},
+		},
+	}
+}
+
+// depositionServer serves deposition 100 and records PUTs.
+func depositionServer(t *testing.T, dep *model.Deposition, puts *[]model.Metadata) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/deposit/depositions/100":
+			json.NewEncoder(w).Encode(dep)
+		case r.Method == http.MethodPut && r.URL.Path == "/deposit/depositions/100":
+			var body struct {
+				Metadata model.Metadata `json:"metadata"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			*puts = append(*puts, body.Metadata)
+			json.NewEncoder(w).Encode(model.Deposition{ID: 100, Metadata: body.Metadata})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func callTool(t *testing.T, h func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]any) (*mcp.CallToolResult, string) {
+	t.Helper()
+	var req mcp.CallToolRequest
+	req.Params.Arguments = args
+	res, err := h(context.Background(), req)
+	if err != nil {
+		t.Fatalf("handler error: %v", err)
+	}
+	return res, res.Content[0].(mcp.TextContent).Text
+}
+
+func newTestWriteTools(t *testing.T, srvURL string) (*writeTools, string) {
+	t.Helper()
+	logPath := filepath.Join(t.TempDir(), "audit.log")
+	return &writeTools{
+		client:  api.NewClient(srvURL, "tok"),
+		confirm: newConfirmations(),
+		audit:   &auditLog{path: logPath},
+	}, logPath
+}
+
+func TestDepositUpdate_PreviewThenConfirm(t *testing.T) {
+	dep := validDeposition()
+	var puts []model.Metadata
+	srv := depositionServer(t, &dep, &puts)
+	defer srv.Close()
+
+	w, logPath := newTestWriteTools(t, srv.URL)
+	h := w.handler("deposit_update", deposit.Backend.Get, w.previewUpdate, w.applyUpdate)
+
+	res, text := callTool(t, h, map[string]any{"id": 100, "title": "New Title"})
+	if res.IsError {
+		t.Fatalf("preview error: %s", text)
+	}
+	var pv preview
+	json.Unmarshal([]byte(text), &pv)
+	if pv.ConfirmToken == "" || !strings.Contains(pv.Diff, "New Title") {
+		t.Fatalf("preview = %+v", pv)
+	}
+	if len(puts) != 0 {
+		t.Fatal("preview must not write")
+	}
+
+	// A token is bound to its tool.
+	pub := w.handler("deposit_publish", deposit.Backend.Get, w.previewPublish, w.applyPublish)
+	if res, _ := callTool(t, pub, map[string]any{"id": 100, "confirm_token": pv.ConfirmToken}); !res.IsError {
+		t.Error("expected token for deposit_update to be rejected by deposit_publish")
+	}
+
+	// The rejected attempt consumed the token; get a fresh one and confirm.
+	_, text = callTool(t, h, map[string]any{"id": 100, "title": "New Title"})
+	json.Unmarshal([]byte(text), &pv)
+	res, text = callTool(t, h, map[string]any{"id": 100, "confirm_token": pv.ConfirmToken, "title": "Sneaky"})
+	if res.IsError {
+		t.Fatalf("confirm error: %s", text)
+	}
+	if len(puts) != 1 || puts[0].Title != "New Title" {
+		t.Fatalf("puts = %+v, want the previewed title", puts)
+	}
+
+	// Tokens are single-use.
+	if res, _ := callTool(t, h, map[string]any{"id": 100, "confirm_token": pv.ConfirmToken}); !res.IsError {
+		t.Error("expected reused token to be rejected")
+	}
+
+	f, err := os.Open(logPath)
+	if err != nil {
+		t.Fatalf("audit log: %v", err)
+	}
+	defer f.Close()
+	var phases []string
+	for sc := bufio.NewScanner(f); sc.Scan(); {
+		var e auditEntry
+		json.Unmarshal(sc.Bytes(), &e)
+		phases = append(phases, e.Tool+":"+e.Phase+":"+e.Outcome)
+	}
+	want := []string{
+		"deposit_update:preview:ok",
+		"deposit_publish:rejected:error",
+		"deposit_update:preview:ok",
+		"deposit_update:confirm:ok",
+		"deposit_update:rejected:error",
+	}
+	if strings.Join(phases, ",") != strings.Join(want, ",") {
+		t.Errorf("audit phases = %v, want %v", phases, want)
+	}
+}
+
+func TestDepositUpdate_RejectsWhenChangedSincePreview(t *testing.T) {
+	dep := validDeposition()
+	var puts []model.Metadata
+	srv := depositionServer(t, &dep, &puts)
+	defer srv.Close()
+
+	w, _ := newTestWriteTools(t, srv.URL)
+	h := w.handler("deposit_update", deposit.Backend.Get, w.previewUpdate, w.applyUpdate)
+
+	_, text := callTool(t, h, map[string]any{"id": 100, "metadata": map[string]any{"keywords": []string{"ocean"}}})
+	var pv preview
+	json.Unmarshal([]byte(text), &pv)
+
+	dep.Modified = dep.Modified.Add(time.Minute)
+	res, _ := callTool(t, h, map[string]any{"id": 100, "confirm_token": pv.ConfirmToken})
+	if !res.IsError || len(puts) != 0 {
+		t.Errorf("expected rejection after a concurrent change, puts = %d", len(puts))
+	}
+}
+
+func TestDepositUpdate_NoChangesIssuesNoToken(t *testing.T) {
+	dep := validDeposition()
+	var puts []model.Metadata
+	srv := depositionServer(t, &dep, &puts)
+	defer srv.Close()
+
+	w, _ := newTestWriteTools(t, srv.URL)
+	_, text := callTool(t, w.handler("deposit_update", deposit.Backend.Get, w.previewUpdate, w.applyUpdate), map[string]any{"id": 100, "title": "Old Title"})
+	if strings.Contains(text, "confirm_token") {
+		t.Errorf("unexpected token for a no-op update: %s", text)
+	}
+}
+
+func TestDepositNewVersion_RDMMode(t *testing.T) {
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /records/q5jr8-hny72":
+			w.Write([]byte(`{"id": "q5jr8-hny72", "status": "published", "is_published": true,
+				"updated": "2024-01-01T00:00:00Z", "metadata": {"title": "Soil", "version": "1.0"}}`))
+		case "POST /records/q5jr8-hny72/versions":
+			w.Write([]byte(`{"id": "7tg2c-0x213", "status": "new_version_draft", "links": {"self_html": "https://rdm.example/uploads/7tg2c-0x213"}}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	w, _ := newTestWriteTools(t, srv.URL)
+	w.mode = deposit.ModeRDM
+	h := w.handler("deposit_new_version", deposit.Backend.Published, w.previewNewVersion, w.applyNewVersion)
+
+	_, text := callTool(t, h, map[string]any{"id": "q5jr8-hny72"})
+	var pv preview
+	json.Unmarshal([]byte(text), &pv)
+	if pv.ConfirmToken == "" || pv.Title != "Soil" {
+		t.Fatalf("preview = %s", text)
+	}
+	res, text := callTool(t, h, map[string]any{"id": "q5jr8-hny72", "confirm_token": pv.ConfirmToken})
+	if res.IsError {
+		t.Fatalf("confirm error: %s", text)
+	}
+	var result newVersionResult
+	json.Unmarshal([]byte(text), &result)
+	if result.DraftID != "7tg2c-0x213" {
+		t.Errorf("result = %s", text)
+	}
+	if len(calls) != 3 {
+		t.Errorf("calls = %v", calls)
+	}
+}
+
+func TestConfirmations_Expire(t *testing.T) {
+	c := newConfirmations()
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	token, _, err := c.issue(pendingWrite{tool: "deposit_publish", id: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now = now.Add(confirmTTL + time.Second)
+	if _, err := c.redeem(token, "deposit_publish", "1", ""); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Errorf("redeem() error = %v, want expired", err)
+	}
+}
+
+func TestWritesAllowed(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("ZENODO_MCP_ALLOW_WRITES", "")
+	if writesAllowed(cfg) {
+		t.Error("writes should be off by default")
+	}
+	cfg.Set("mcp.allow_writes", "true")
+	if !writesAllowed(cfg) {
+		t.Error("config key should enable writes")
+	}
+	t.Setenv("ZENODO_MCP_ALLOW_WRITES", "0")
+	if writesAllowed(cfg) {
+		t.Error("env var should override the config key")
+	}
+}
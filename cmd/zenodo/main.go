@@ -54,6 +54,11 @@ func exitCode(err error) int {
 		}
 	}
 
+	var checkErr *cli.CheckFailedError
+	if errors.As(err, &checkErr) {
+		return 6
+	}
+
 	// Check for validation error message pattern.
 	if err.Error() == "metadata validation failed" {
 		return 3
@@ -1,21 +1,104 @@
 package api
 
-// v0.1: Access links methods disabled — read-only release.
-// They will be re-enabled in a future version.
-
-/*
 import (
-	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/ran-codes/zenodo-cli/internal/model"
 )
 
+// recordAccessPath returns the path of a record's access endpoint, e.g.
+// "links" or "grants".
+func recordAccessPath(recordID, kind string) string {
+	return "/records/" + url.PathEscape(recordID) + "/access/" + kind
+}
+
 // ListAccessLinks returns the share/access links for a record.
-func (c *Client) ListAccessLinks(recordID int) ([]model.AccessLink, error) {
+func (c *Client) ListAccessLinks(recordID string) ([]model.AccessLink, error) {
 	var result model.AccessLinkList
-	if err := c.Get(fmt.Sprintf("/records/%d/access/links", recordID), nil, &result); err != nil {
+	if err := c.Get(recordAccessPath(recordID, "links"), nil, &result); err != nil {
 		return nil, err
 	}
 	return result.Hits.Hits, nil
 }
-*/
+
+// CreateAccessLink creates a share link for a record with the link's
+// permission, description and expiry date (YYYY-MM-DD, or "" for none).
+func (c *Client) CreateAccessLink(recordID string, link *model.AccessLink) (*model.AccessLink, error) {
+	body := map[string]interface{}{"permission": link.Permission}
+	if link.ExpiresAt != "" {
+		body["expires_at"] = link.ExpiresAt
+	}
+	if link.Description != "" {
+		body["description"] = link.Description
+	}
+	var result model.AccessLink
+	if err := c.Post(recordAccessPath(recordID, "links"), body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteAccessLink deletes a share link, which stops working at once.
+func (c *Client) DeleteAccessLink(recordID, linkID string) error {
+	return c.Delete(recordAccessPath(recordID, "links")+"/"+url.PathEscape(linkID), nil)
+}
+
+// ShareURL returns the address at which a share link opens the record.
+func (c *Client) ShareURL(recordID, token string) string {
+	site := strings.TrimSuffix(strings.TrimSuffix(c.baseURL, "/"), "/api")
+	return site + "/records/" + url.PathEscape(recordID) + "?" + url.Values{"token": {token}}.Encode()
+}
+
+// ListAccessGrants returns the users and groups a record is shared with.
+func (c *Client) ListAccessGrants(recordID string) ([]model.AccessGrant, error) {
+	var result model.AccessGrantList
+	if err := c.Get(recordAccessPath(recordID, "grants"), nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Hits.Hits, nil
+}
+
+// CreateAccessGrants shares a record with users or groups. If notify is
+// set, users are emailed, with message if it is not empty.
+func (c *Client) CreateAccessGrants(recordID string, grants []model.AccessGrant, notify bool, message string) ([]model.AccessGrant, error) {
+	for i := range grants {
+		if grants[i].Origin == "" {
+			grants[i].Origin = "api"
+		}
+	}
+	body := map[string]interface{}{"grants": grants, "notify": notify}
+	if message != "" {
+		body["message"] = message
+	}
+	var result model.AccessGrantList
+	if err := c.Post(recordAccessPath(recordID, "grants"), body, &result); err != nil {
+		return nil, err
+	}
+	return result.Hits.Hits, nil
+}
+
+// DeleteAccessGrant revokes a grant, by its ID among the record's grants.
+func (c *Client) DeleteAccessGrant(recordID string, grantID int) error {
+	return c.Delete(recordAccessPath(recordID, "grants")+"/"+strconv.Itoa(grantID), nil)
+}
+
+// ListAccessRequests returns the requests for access to the authenticated
+// user's restricted records, newest first, optionally only those about one
+// record, or only the open ones.
+func (c *Client) ListAccessRequests(recordID string, open bool) (*model.RequestSearchResult, error) {
+	q := "type:(" + model.RequestUserAccess + " OR " + model.RequestGuestAccess + ")"
+	if recordID != "" {
+		q += " AND topic.record:" + strconv.Quote(recordID)
+	}
+	query := url.Values{"q": {q}, "expand": {"1"}, "sort": {"newest"}, "size": {"100"}}
+	if open {
+		query.Set("is_open", "true")
+	}
+	var result model.RequestSearchResult
+	if err := c.Get("/user/requests", query, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
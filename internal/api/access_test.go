@@ -1,8 +1,5 @@
 package api
 
-// v0.1: Access links tests disabled — read-only release.
-
-/*
 import (
 	"encoding/json"
 	"net/http"
@@ -19,7 +16,7 @@ func TestListAccessLinks(t *testing.T) {
 		}
 		json.NewEncoder(w).Encode(model.AccessLinkList{
 			Hits: model.AccessLinkHits{
-				Hits: []model.AccessLink{{ID: "link-1"}},
+				Hits:  []model.AccessLink{{ID: "link-1", Permission: model.PermissionView}},
 				Total: 1,
 			},
 		})
@@ -27,15 +24,94 @@ func TestListAccessLinks(t *testing.T) {
 	defer srv.Close()
 
 	client := NewClient(srv.URL, "tok")
-	links, err := client.ListAccessLinks(123)
+	links, err := client.ListAccessLinks("123")
 	if err != nil {
 		t.Fatalf("error: %v", err)
 	}
 	if len(links) != 1 {
 		t.Errorf("links = %d", len(links))
 	}
-	if links[0].ID != "link-1" {
-		t.Errorf("id = %q", links[0].ID)
+	if links[0].ID != "link-1" || links[0].Permission != model.PermissionView {
+		t.Errorf("link = %+v", links[0])
+	}
+}
+
+func TestCreateAccessLink(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/records/123/access/links" {
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["permission"] != "preview" || body["expires_at"] != "2026-12-31" {
+			t.Errorf("body = %v", body)
+		}
+		if _, ok := body["description"]; ok {
+			t.Error("empty description sent")
+		}
+		w.Write([]byte(`{"id": "link-2", "token": "s3cret", "permission": "preview", "expires_at": "2026-12-31T00:00:00"}`))
+	}))
+	defer srv.Close()
+
+	link, err := NewClient(srv.URL, "tok").CreateAccessLink("123", &model.AccessLink{Permission: model.PermissionPreview, ExpiresAt: "2026-12-31"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.Token != "s3cret" {
+		t.Errorf("link = %+v", link)
+	}
+
+	if got := NewClient("https://zenodo.org/api", "").ShareURL("123", link.Token); got != "https://zenodo.org/records/123?token=s3cret" {
+		t.Errorf("ShareURL = %q", got)
+	}
+}
+
+func TestCreateAccessGrants(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/records/123/access/grants" {
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			Grants  []model.AccessGrant
+			Notify  bool
+			Message string
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body.Grants) != 1 || body.Grants[0].Subject.ID != "42" || body.Grants[0].Origin != "api" || !body.Notify || body.Message != "For review" {
+			t.Errorf("body = %+v", body)
+		}
+		w.Write([]byte(`{"hits": {"total": 1, "hits": [{"id": 0, "subject": {"type": "user", "id": "42"}, "permission": "view", "origin": "api"}]}}`))
+	}))
+	defer srv.Close()
+
+	var g model.AccessGrant
+	g.Subject.Type, g.Subject.ID, g.Permission = model.GrantUser, "42", model.PermissionView
+	grants, err := NewClient(srv.URL, "tok").CreateAccessGrants("123", []model.AccessGrant{g}, true, "For review")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(grants) != 1 || grants[0].Permission != model.PermissionView {
+		t.Errorf("grants = %+v", grants)
+	}
+}
+
+func TestListAccessRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/user/requests" || q.Get("is_open") != "true" ||
+			q.Get("q") != `type:(user-access-request OR guest-access-request) AND topic.record:"123"` {
+			t.Errorf("%s %v", r.URL.Path, q)
+		}
+		w.Write([]byte(`{"hits": {"total": 1, "hits": [{"id": "r-1", "type": "guest-access-request",
+			"status": "submitted", "is_open": true, "created_by": {"email": "guest@example.org"}, "topic": {"record": "123"}}]}}`))
+	}))
+	defer srv.Close()
+
+	result, err := NewClient(srv.URL, "tok").ListAccessRequests("123", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Hits.Hits) != 1 || result.Hits.Hits[0].Submitter() != "guest@example.org" {
+		t.Errorf("requests = %+v", result.Hits.Hits)
 	}
 }
-*/
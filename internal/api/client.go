@@ -10,6 +10,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/ran-codes/zenodo-cli/internal/model"
@@ -170,14 +171,33 @@ func (c *Client) BaseURL() string {
 // GetRaw performs a GET request with a custom Accept header and returns raw bytes.
 // Useful for non-JSON formats like BibTeX or DataCite XML.
 func (c *Client) GetRaw(path string, accept string) ([]byte, error) {
-	reqURL := c.baseURL + path
+	return c.getRaw(c.baseURL+path, path, accept)
+}
+
+// GetRawURL is like GetRaw but takes an absolute URL, for endpoints that live
+// outside the REST API base (e.g. OAI-PMH). The token is only sent if the
+// URL is on the API's host.
+func (c *Client) GetRawURL(rawURL string, accept string) ([]byte, error) {
+	path := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		path = u.Path
+	}
+	return c.getRaw(rawURL, path, accept)
+}
 
+// sameHost reports whether u is on the host (and port) of the base URL.
+func (c *Client) sameHost(u *url.URL) bool {
+	base, err := url.Parse(c.baseURL)
+	return err == nil && strings.EqualFold(base.Host, u.Host)
+}
+
+func (c *Client) getRaw(reqURL, path, accept string) ([]byte, error) {
 	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
 
-	if c.token != "" {
+	if c.token != "" && c.sameHost(req.URL) {
 		req.Header.Set("Authorization", "Bearer "+c.token)
 	}
 	req.Header.Set("Accept", accept)
@@ -209,3 +229,94 @@ func (c *Client) GetRaw(path string, accept string) ([]byte, error) {
 
 	return body, nil
 }
+
+// Download streams the content at fileURL into w. fileURL is an absolute link
+// as returned in a record's file links. Unlike other requests, downloads have
+// no overall timeout so large files are not cut off mid-transfer.
+func (c *Client) Download(fileURL string, w io.Writer) error {
+	req, err := http.NewRequest(http.MethodGet, fileURL, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	// File links come from record JSON; only the API host gets the token.
+	if c.token != "" && c.sameHost(req.URL) {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	slog.Debug("API request (download)", "url", fileURL)
+
+	path := strings.TrimPrefix(fileURL, c.baseURL)
+	if c.rateLimiter != nil {
+		c.rateLimiter.Wait(path)
+	}
+
+	dl := &http.Client{Transport: c.httpClient.Transport}
+	resp, err := dl.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if c.rateLimiter != nil {
+		c.rateLimiter.UpdateFromHeaders(resp, path)
+	}
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return parseAPIError(resp.StatusCode, body)
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("downloading %s: %w", fileURL, err)
+	}
+	return nil
+}
+
+// putStream PUTs a raw octet stream to an absolute URL (e.g. a bucket file
+// link) and decodes the JSON response. Like Download, it has no overall
+// timeout so large uploads are not cut off.
+func (c *Client) putStream(fileURL string, r io.Reader, result interface{}) error {
+	req, err := http.NewRequest(http.MethodPut, fileURL, r)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	if c.token != "" && c.sameHost(req.URL) {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Accept", "application/json")
+
+	slog.Debug("API request (upload)", "url", fileURL)
+
+	path := strings.TrimPrefix(fileURL, c.baseURL)
+	if c.rateLimiter != nil {
+		c.rateLimiter.Wait(path)
+	}
+
+	up := &http.Client{Transport: c.httpClient.Transport}
+	resp, err := up.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if c.rateLimiter != nil {
+		c.rateLimiter.UpdateFromHeaders(resp, path)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return parseAPIError(resp.StatusCode, body)
+	}
+	if result != nil && len(body) > 0 {
+		if err := json.Unmarshal(body, result); err != nil {
+			return fmt.Errorf("decoding response: %w", err)
+		}
+	}
+	return nil
+}
@@ -1,9 +1,12 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/ran-codes/zenodo-cli/internal/model"
@@ -68,6 +71,28 @@ func TestGet_NoAuthWhenEmpty(t *testing.T) {
 	}
 }
 
+func TestGetRawURL_AuthOnlyOnAPIHost(t *testing.T) {
+	var got []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Authorization"))
+		w.Write([]byte("<ok/>"))
+	})
+	apiSrv := httptest.NewServer(handler)
+	defer apiSrv.Close()
+	other := httptest.NewServer(handler)
+	defer other.Close()
+
+	client := NewClient(apiSrv.URL+"/api", "my-secret")
+	for _, u := range []string{apiSrv.URL + "/oai2d", other.URL + "/oai2d"} {
+		if _, err := client.GetRawURL(u, "text/xml"); err != nil {
+			t.Fatalf("GetRawURL(%s) error: %v", u, err)
+		}
+	}
+	if len(got) != 2 || got[0] != "Bearer my-secret" || got[1] != "" {
+		t.Errorf("authorization headers = %q", got)
+	}
+}
+
 func TestGet_QueryParams(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Query().Get("q") != "test" {
@@ -247,3 +272,65 @@ func TestGetRaw(t *testing.T) {
 		t.Errorf("got %q", string(data))
 	}
 }
+
+func TestDownload(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/records/123/files/data.csv/content" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			t.Errorf("auth = %q", r.Header.Get("Authorization"))
+		}
+		w.Write([]byte("a,b\n1,2\n"))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "tok")
+	var buf bytes.Buffer
+	if err := client.Download(srv.URL+"/records/123/files/data.csv/content", &buf); err != nil {
+		t.Fatalf("Download() error: %v", err)
+	}
+	if buf.String() != "a,b\n1,2\n" {
+		t.Errorf("got %q", buf.String())
+	}
+}
+
+func TestDownload_AuthOnlyOnAPIHost(t *testing.T) {
+	var got []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Authorization"))
+		w.Write([]byte("{}"))
+	})
+	apiSrv := httptest.NewServer(handler)
+	defer apiSrv.Close()
+	other := httptest.NewServer(handler)
+	defer other.Close()
+
+	client := NewClient(apiSrv.URL+"/api", "my-secret")
+	for _, u := range []string{apiSrv.URL + "/api/records/1/files/a/content", other.URL + "/a"} {
+		if err := client.Download(u, io.Discard); err != nil {
+			t.Fatalf("Download(%s) error: %v", u, err)
+		}
+	}
+	if err := client.putStream(other.URL+"/bucket/a", strings.NewReader("x"), nil); err != nil {
+		t.Fatalf("putStream error: %v", err)
+	}
+	if len(got) != 3 || got[0] != "Bearer my-secret" || got[1] != "" || got[2] != "" {
+		t.Errorf("authorization headers = %q", got)
+	}
+}
+
+func TestDownload_NotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "tok")
+	var buf bytes.Buffer
+	err := client.Download(srv.URL+"/missing", &buf)
+	apiErr, ok := err.(*model.APIError)
+	if !ok || apiErr.Status != 404 {
+		t.Errorf("expected 404 APIError, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"net/http"
 	"net/url"
 	"strconv"
 
@@ -29,6 +30,15 @@ func (c *Client) ListUserCommunities(q string, page, size int) (*model.Community
 	return &result, nil
 }
 
+// GetCommunity retrieves a community by slug or ID.
+func (c *Client) GetCommunity(idOrSlug string) (*model.Community, error) {
+	var result model.Community
+	if err := c.Get("/communities/"+url.PathEscape(idOrSlug), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // SearchCommunities searches or lists communities.
 func (c *Client) SearchCommunities(q string, page, size int) (*model.CommunitySearchResult, error) {
 	query := url.Values{}
@@ -50,3 +60,94 @@ func (c *Client) SearchCommunities(q string, page, size int) (*model.CommunitySe
 	}
 	return &result, nil
 }
+
+// ListCommunityMembers returns a community's members and their roles.
+// Members see everyone; others only the members who made themselves
+// visible, which public selects.
+func (c *Client) ListCommunityMembers(communityID string, public bool, page, size int) (*model.CommunityMemberSearchResult, error) {
+	query := url.Values{}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if size > 0 {
+		query.Set("size", strconv.Itoa(size))
+	} else {
+		query.Set("size", "100")
+	}
+
+	path := "/communities/" + url.PathEscape(communityID) + "/members"
+	if public {
+		path += "/public"
+	}
+	var result model.CommunityMemberSearchResult
+	if err := c.Get(path, query, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateCommunity replaces a community's slug, metadata, access settings
+// and custom fields, and returns the updated community.
+func (c *Client) UpdateCommunity(communityID string, community *model.Community) (*model.Community, error) {
+	body := map[string]interface{}{
+		"slug":     community.Slug,
+		"metadata": community.Metadata,
+	}
+	if community.Access != (model.CommunityAccess{}) {
+		body["access"] = community.Access
+	}
+	if len(community.CustomFields) > 0 {
+		body["custom_fields"] = community.CustomFields
+	}
+	var result model.Community
+	if err := c.Put("/communities/"+url.PathEscape(communityID), body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// recordCommunitiesPath returns the path of a published record's communities.
+func recordCommunitiesPath(recordID string) string {
+	return "/records/" + url.PathEscape(recordID) + "/communities"
+}
+
+// communityRefs is the request body naming communities by ID or slug.
+func communityRefs(communityIDs []string) map[string]interface{} {
+	refs := make([]map[string]string, len(communityIDs))
+	for i, id := range communityIDs {
+		refs[i] = map[string]string{"id": id}
+	}
+	return map[string]interface{}{"communities": refs}
+}
+
+// ListRecordCommunities returns the communities a published record is in.
+func (c *Client) ListRecordCommunities(recordID string) (*model.CommunitySearchResult, error) {
+	var result model.CommunitySearchResult
+	if err := c.Get(recordCommunitiesPath(recordID), url.Values{"size": {"100"}}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// AddRecordCommunities asks communities to include a published record. Each
+// community gets an inclusion request, which is accepted straight away if
+// the user may include records without review. Communities the record
+// could not be submitted to are listed in the result's errors.
+func (c *Client) AddRecordCommunities(recordID string, communityIDs []string) (*model.RecordCommunitiesResult, error) {
+	var result model.RecordCommunitiesResult
+	if err := c.Post(recordCommunitiesPath(recordID), communityRefs(communityIDs), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RemoveRecordCommunities removes a published record from communities.
+// Communities it could not be removed from are listed in the result's
+// errors.
+func (c *Client) RemoveRecordCommunities(recordID string, communityIDs []string) (*model.RecordCommunitiesResult, error) {
+	var result model.RecordCommunitiesResult
+	if err := c.do(http.MethodDelete, recordCommunitiesPath(recordID), nil, communityRefs(communityIDs), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -38,3 +38,131 @@ func TestSearchCommunities(t *testing.T) {
 		t.Errorf("id = %q", result.Hits.Hits[0].ID)
 	}
 }
+
+func TestGetCommunity(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/communities/my-org" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(model.Community{
+			ID:       "c-1",
+			Slug:     "my-org",
+			Metadata: model.CommunityMetadata{Title: "My Org"},
+		})
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "tok")
+	c, err := client.GetCommunity("my-org")
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if c.Slug != "my-org" || c.Metadata.Title != "My Org" {
+		t.Errorf("unexpected community: %+v", c)
+	}
+}
+
+func TestListCommunityMembers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/communities/c-1/members/public" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		w.Write([]byte(`{"hits": {"total": 1, "hits": [{"role": "curator", "visible": true,
+			"member": {"type": "user", "id": "7", "name": "Jane Doe", "description": "CERN"}}]}}`))
+	}))
+	defer srv.Close()
+
+	result, err := NewClient(srv.URL, "tok").ListCommunityMembers("c-1", true, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := result.Hits.Hits[0]; m.Role != model.RoleCurator || m.Member.Name != "Jane Doe" {
+		t.Errorf("member = %+v", m)
+	}
+}
+
+func TestUpdateCommunity(t *testing.T) {
+	var body map[string]json.RawMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/communities/c-1" {
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"id": "c-1", "slug": "my-org", "metadata": {"title": "New"}}`))
+	}))
+	defer srv.Close()
+
+	c := &model.Community{
+		ID: "c-1", Slug: "my-org",
+		Metadata:     model.CommunityMetadata{Title: "New", Type: &model.RDMVocabRef{ID: "organization"}},
+		Access:       model.CommunityAccess{Visibility: "public", ReviewPolicy: "closed"},
+		CustomFields: json.RawMessage(`{"subjects": []}`),
+		Links:        model.CommunityLinks{Self: "https://zenodo.org/api/communities/c-1"},
+	}
+	got, err := NewClient(srv.URL, "tok").UpdateCommunity(c.ID, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Metadata.Title != "New" {
+		t.Errorf("community = %+v", got)
+	}
+	for _, key := range []string{"slug", "metadata", "access", "custom_fields"} {
+		if _, ok := body[key]; !ok {
+			t.Errorf("body lacks %q: %v", key, body)
+		}
+	}
+	if _, ok := body["links"]; ok {
+		t.Error("body includes read-only links")
+	}
+}
+
+func TestAddRecordCommunities(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/records/123/communities" {
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			Communities []struct{ ID string }
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body.Communities) != 2 || body.Communities[0].ID != "c-1" {
+			t.Errorf("body = %+v", body)
+		}
+		w.Write([]byte(`{"processed": [{"community_id": "c-1", "request_id": "r-1",
+			"request": {"id": "r-1", "type": "community-inclusion", "status": "submitted", "is_open": true}}],
+			"errors": [{"community": "c-2", "message": "The record is already included in this community."}]}`))
+	}))
+	defer srv.Close()
+
+	result, err := NewClient(srv.URL, "tok").AddRecordCommunities("123", []string{"c-1", "c-2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Processed) != 1 || result.Processed[0].Request.Status != model.RequestSubmitted {
+		t.Errorf("processed = %+v", result.Processed)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].CommunityID != "c-2" {
+		t.Errorf("errors = %+v", result.Errors)
+	}
+}
+
+func TestRemoveRecordCommunities(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/records/123/communities" {
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Error("DELETE sent without a JSON body")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	result, err := NewClient(srv.URL, "tok").RemoveRecordCommunities("123", []string{"c-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Errors) != 0 {
+		t.Errorf("errors = %+v", result.Errors)
+	}
+}
@@ -2,6 +2,8 @@ package api
 
 import (
 	"fmt"
+	"io"
+	"net/url"
 
 	"github.com/ran-codes/zenodo-cli/internal/model"
 )
@@ -15,10 +17,18 @@ func (c *Client) GetDeposition(id int) (*model.Deposition, error) {
 	return &result, nil
 }
 
-// v0.1: Write methods disabled — read-only release.
-// They will be re-enabled in a future version.
+// CreateDeposition creates a new, empty-filed draft with the given metadata.
+func (c *Client) CreateDeposition(metadata model.Metadata) (*model.Deposition, error) {
+	body := map[string]interface{}{
+		"metadata": metadata,
+	}
+	var result model.Deposition
+	if err := c.Post("/deposit/depositions", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
 
-/*
 // UpdateDeposition updates the metadata of a deposition (full replacement PUT).
 func (c *Client) UpdateDeposition(id int, metadata model.Metadata) (*model.Deposition, error) {
 	body := map[string]interface{}{
@@ -57,4 +67,23 @@ func (c *Client) DiscardDeposition(id int) (*model.Deposition, error) {
 	}
 	return &result, nil
 }
-*/
+
+// NewVersion creates a new draft version of a published deposition. The
+// returned deposition is the original; the draft is at Links.LatestDraft.
+func (c *Client) NewVersion(id int) (*model.Deposition, error) {
+	var result model.Deposition
+	if err := c.Post(fmt.Sprintf("/deposit/depositions/%d/actions/newversion", id), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UploadFile streams a file into a deposition's bucket (links.bucket) under
+// the given name.
+func (c *Client) UploadFile(bucketURL, name string, r io.Reader) (*model.File, error) {
+	var result model.File
+	if err := c.putStream(bucketURL+"/"+url.PathEscape(name), r, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
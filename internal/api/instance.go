@@ -0,0 +1,130 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// probePaths are the optional endpoints Probe looks for. An endpoint that
+// exists but needs a token answers 401 or 403, so those count as present.
+var probePaths = []struct {
+	endpoint, path string
+}{
+	{model.EndpointDeposit, "/deposit/depositions?size=1"},
+	{model.EndpointDrafts, "/user/records?size=1"},
+	{model.EndpointCommunities, "/communities?size=1"},
+	{model.EndpointRequests, "/requests?size=1"},
+	{model.EndpointLicenses, "/licenses?size=1"},
+	{model.EndpointFunders, "/funders?size=1"},
+}
+
+// UseCapabilities adapts the client to an instance's probed capabilities:
+// its rate limits and search-limited endpoints.
+func (c *Client) UseCapabilities(caps *model.Capabilities) {
+	c.rateLimiter = NewRateLimiterFor(caps)
+}
+
+// Probe discovers which endpoints and vocabularies the instance at the
+// client's base URL serves, and its rate limits. vocabularies are the
+// /vocabularies/<kind> names to look for. It fails if the base URL does
+// not serve a records search.
+func (c *Client) Probe(vocabularies []string) (*model.Capabilities, error) {
+	status, header, body, err := c.probe(c.baseURL + "/records?size=1")
+	if err != nil {
+		return nil, err
+	}
+	var search struct {
+		Hits *json.RawMessage `json:"hits"`
+	}
+	if status != http.StatusOK || json.Unmarshal(body, &search) != nil || search.Hits == nil {
+		return nil, fmt.Errorf("%s does not look like a Zenodo or InvenioRDM API: GET /records returned %d", c.baseURL, status)
+	}
+
+	caps := &model.Capabilities{
+		Probed:          time.Now().UTC().Format(time.RFC3339),
+		Endpoints:       []string{model.EndpointRecords},
+		SearchRateLimit: rateLimitHeader(header),
+	}
+	for _, p := range probePaths {
+		status, header, _, err := c.probe(c.baseURL + p.path)
+		if err != nil {
+			return nil, err
+		}
+		if status < 400 || status == http.StatusUnauthorized || status == http.StatusForbidden {
+			caps.Endpoints = append(caps.Endpoints, p.endpoint)
+			if p.endpoint == model.EndpointDeposit || p.endpoint == model.EndpointDrafts {
+				caps.RateLimit = max(caps.RateLimit, rateLimitHeader(header))
+			}
+		}
+	}
+	for _, kind := range vocabularies {
+		status, _, _, err := c.probe(c.baseURL + "/vocabularies/" + kind + "?size=1")
+		if err != nil {
+			return nil, err
+		}
+		if status == http.StatusOK {
+			caps.Vocabularies = append(caps.Vocabularies, kind)
+		}
+	}
+	if len(caps.Vocabularies) > 0 {
+		caps.Endpoints = append(caps.Endpoints, model.EndpointVocabularies)
+	}
+	if status, _, body, err := c.probe(c.OAIEndpoint() + "?verb=Identify"); err == nil && status == http.StatusOK && strings.Contains(string(body), "<Identify>") {
+		caps.Endpoints = append(caps.Endpoints, model.EndpointOAI)
+	}
+
+	for _, prefix := range defaultSearchPrefixes {
+		if caps.Has(strings.TrimPrefix(prefix, "/")) {
+			caps.SearchPrefixes = append(caps.SearchPrefixes, prefix)
+		}
+	}
+	return caps, nil
+}
+
+// probe performs a GET and returns the status, headers and body without
+// treating error statuses as errors.
+func (c *Client) probe(reqURL string) (int, http.Header, []byte, error) {
+	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("creating request: %w", err)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	slog.Debug("API request (probe)", "url", reqURL)
+
+	path := strings.TrimPrefix(reqURL, c.baseURL)
+	if c.rateLimiter != nil {
+		c.rateLimiter.Wait(path)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("reading response: %w", err)
+	}
+	slog.Debug("API response (probe)", "status", resp.StatusCode)
+	return resp.StatusCode, resp.Header, body, nil
+}
+
+// rateLimitHeader returns the X-RateLimit-Limit header, which InvenioRDM
+// sends per minute, or 0.
+func rateLimitHeader(h http.Header) int {
+	n, _ := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	return n
+}
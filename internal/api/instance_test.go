@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+func TestProbe(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/records":
+			w.Header().Set("X-RateLimit-Limit", "60")
+			w.Write([]byte(`{"hits": {"hits": [], "total": 0}}`))
+		case "/api/user/records":
+			w.Header().Set("X-RateLimit-Limit", "500")
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/api/communities", "/api/funders", "/api/vocabularies/languages":
+			w.Write([]byte(`{"hits": {"hits": [], "total": 0}}`))
+		case "/oai2d":
+			w.Write([]byte(`<OAI-PMH><Identify><repositoryName>Test</repositoryName></Identify></OAI-PMH>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	caps, err := NewClient(srv.URL+"/api", "").Probe([]string{"languages", "licenses"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"records", "drafts", "communities", "funders", "vocabularies", "oai-pmh"}
+	if !slices.Equal(caps.Endpoints, want) {
+		t.Errorf("endpoints = %v, want %v", caps.Endpoints, want)
+	}
+	if !slices.Equal(caps.Vocabularies, []string{"languages"}) {
+		t.Errorf("vocabularies = %v", caps.Vocabularies)
+	}
+	if caps.RateLimit != 500 || caps.SearchRateLimit != 60 {
+		t.Errorf("rate limits = %d, %d", caps.RateLimit, caps.SearchRateLimit)
+	}
+	if !slices.Equal(caps.SearchPrefixes, []string{"/records", "/communities", "/vocabularies"}) {
+		t.Errorf("search prefixes = %v", caps.SearchPrefixes)
+	}
+	if caps.Has(model.EndpointDeposit) || caps.Probed == "" {
+		t.Errorf("caps = %+v", caps)
+	}
+}
+
+func TestProbe_NotAnAPI(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html>Welcome</html>`))
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL, "").Probe(nil)
+	if err == nil || !strings.Contains(err.Error(), "does not look like") {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// OAIParams holds the arguments of an OAI-PMH ListRecords request.
+// When ResumptionToken is set, the other arguments must be empty.
+type OAIParams struct {
+	MetadataPrefix  string
+	Set             string
+	From            string
+	Until           string
+	ResumptionToken string
+}
+
+func (p OAIParams) toQuery() url.Values {
+	q := url.Values{}
+	q.Set("verb", "ListRecords")
+	if p.ResumptionToken != "" {
+		q.Set("resumptionToken", p.ResumptionToken)
+		return q
+	}
+	q.Set("metadataPrefix", p.MetadataPrefix)
+	if p.Set != "" {
+		q.Set("set", p.Set)
+	}
+	if p.From != "" {
+		q.Set("from", p.From)
+	}
+	if p.Until != "" {
+		q.Set("until", p.Until)
+	}
+	return q
+}
+
+// OAIEndpoint derives the OAI-PMH endpoint from the REST API base URL,
+// e.g. https://zenodo.org/api → https://zenodo.org/oai2d.
+func (c *Client) OAIEndpoint() string {
+	return strings.TrimSuffix(strings.TrimSuffix(c.baseURL, "/"), "/api") + "/oai2d"
+}
+
+// OAIListRecords performs a single ListRecords request against endpoint.
+// A noRecordsMatch error is returned as an empty list rather than an error.
+func (c *Client) OAIListRecords(endpoint string, params OAIParams) (*model.OAIResponse, error) {
+	body, err := c.GetRawURL(endpoint+"?"+params.toQuery().Encode(), "text/xml")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp model.OAIResponse
+	if err := xml.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("decoding OAI-PMH response: %w", err)
+	}
+
+	for _, e := range resp.Errors {
+		if e.Code == "noRecordsMatch" {
+			resp.ListRecords = &model.OAIListRecords{}
+			continue
+		}
+		return nil, e
+	}
+	if resp.ListRecords == nil {
+		resp.ListRecords = &model.OAIListRecords{}
+	}
+	return &resp, nil
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOAIListRecords(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/oai2d" || q.Get("verb") != "ListRecords" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if q.Get("set") != "user-my-org" || q.Get("metadataPrefix") != "oai_datacite" || q.Get("from") != "2024-01-01" {
+			t.Errorf("query = %v", q)
+		}
+		w.Write([]byte(`<?xml version="1.0"?>
+<OAI-PMH xmlns="http://www.openarchives.org/OAI/2.0/">
+  <responseDate>2024-06-01T00:00:00Z</responseDate>
+  <ListRecords>
+    <record>
+      <header><identifier>oai:zenodo.org:1</identifier><datestamp>2024-05-01T00:00:00Z</datestamp><setSpec>user-my-org</setSpec></header>
+      <metadata><resource><titles><title>One</title></titles></resource></metadata>
+    </record>
+    <resumptionToken completeListSize="2" cursor="0">next-page</resumptionToken>
+  </ListRecords>
+</OAI-PMH>`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL+"/api", "tok")
+	resp, err := client.OAIListRecords(srv.URL+"/oai2d", OAIParams{MetadataPrefix: "oai_datacite", Set: "user-my-org", From: "2024-01-01"})
+	if err != nil {
+		t.Fatalf("OAIListRecords() error: %v", err)
+	}
+	if len(resp.ListRecords.Records) != 1 || resp.ListRecords.Records[0].Header.Identifier != "oai:zenodo.org:1" {
+		t.Errorf("records = %+v", resp.ListRecords.Records)
+	}
+	if resp.ListRecords.ResumptionToken.Token != "next-page" || resp.ListRecords.ResumptionToken.CompleteListSize != 2 {
+		t.Errorf("token = %+v", resp.ListRecords.ResumptionToken)
+	}
+}
+
+func TestOAIListRecords_NoRecordsMatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<OAI-PMH><responseDate>2024-06-01T00:00:00Z</responseDate><error code="noRecordsMatch">none</error></OAI-PMH>`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "tok")
+	resp, err := client.OAIListRecords(srv.URL+"/oai2d", OAIParams{MetadataPrefix: "oai_dc"})
+	if err != nil {
+		t.Fatalf("OAIListRecords() error: %v", err)
+	}
+	if len(resp.ListRecords.Records) != 0 {
+		t.Errorf("expected no records, got %d", len(resp.ListRecords.Records))
+	}
+}
+
+func TestOAIListRecords_ProtocolError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<OAI-PMH><error code="badResumptionToken">expired</error></OAI-PMH>`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "tok")
+	_, err := client.OAIListRecords(srv.URL+"/oai2d", OAIParams{ResumptionToken: "old"})
+	if err == nil || err.Error() != "OAI-PMH error badResumptionToken: expired" {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestOAIEndpoint(t *testing.T) {
+	if got := NewClient("https://zenodo.org/api", "").OAIEndpoint(); got != "https://zenodo.org/oai2d" {
+		t.Errorf("OAIEndpoint() = %q", got)
+	}
+}
+
+func TestOAIParams_ResumptionTokenIsExclusive(t *testing.T) {
+	q := OAIParams{MetadataPrefix: "oai_dc", Set: "s", ResumptionToken: "tok"}.toQuery()
+	if q.Get("metadataPrefix") != "" || q.Get("set") != "" || q.Get("resumptionToken") != "tok" {
+		t.Errorf("query = %v", q)
+	}
+}
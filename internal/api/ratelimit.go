@@ -7,14 +7,18 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
 )
 
 // RateLimiter implements dual token bucket rate limiting for the Zenodo API.
-// General bucket: 100 req/min, Search bucket: 30 req/min.
+// General bucket: 100 req/min, Search bucket: 30 req/min, unless an
+// instance's probed capabilities say otherwise.
 type RateLimiter struct {
-	mu      sync.Mutex
-	general *bucket
-	search  *bucket
+	mu             sync.Mutex
+	general        *bucket
+	search         *bucket
+	searchPrefixes []string
 }
 
 type bucket struct {
@@ -56,18 +60,50 @@ func (b *bucket) consume() {
 	b.tokens--
 }
 
+// Zenodo's limits, used for anything a probe did not determine.
+const (
+	defaultRateLimit       = 100
+	defaultSearchRateLimit = 30
+)
+
+// defaultSearchPrefixes are Zenodo's search-limited endpoints.
+var defaultSearchPrefixes = []string{"/records", "/communities", "/licenses", "/vocabularies"}
+
 // NewRateLimiter creates a rate limiter with Zenodo's limits.
 func NewRateLimiter() *RateLimiter {
+	return NewRateLimiterFor(nil)
+}
+
+// NewRateLimiterFor creates a rate limiter with an instance's probed limits
+// and search endpoints, falling back to Zenodo's where caps is nil or
+// incomplete.
+func NewRateLimiterFor(caps *model.Capabilities) *RateLimiter {
+	general, search, prefixes := defaultRateLimit, defaultSearchRateLimit, defaultSearchPrefixes
+	if caps != nil {
+		if caps.RateLimit > 0 {
+			general = caps.RateLimit
+		}
+		if caps.SearchRateLimit > 0 {
+			search = caps.SearchRateLimit
+		}
+		if len(caps.SearchPrefixes) > 0 {
+			prefixes = caps.SearchPrefixes
+		}
+	}
 	return &RateLimiter{
-		general: newBucket(100, 100),
-		search:  newBucket(30, 30),
+		general:        newBucket(float64(general), float64(general)),
+		search:         newBucket(float64(search), float64(search)),
+		searchPrefixes: prefixes,
 	}
 }
 
-// isSearchPath returns true if the path hits a search-limited endpoint.
-func isSearchPath(path string) bool {
-	searchPrefixes := []string{"/records", "/communities", "/licenses"}
-	for _, prefix := range searchPrefixes {
+// isSearch returns true if the path hits a search-limited endpoint.
+func (rl *RateLimiter) isSearch(path string) bool {
+	return hasAnyPrefix(path, rl.searchPrefixes)
+}
+
+func hasAnyPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
 		if strings.HasPrefix(path, prefix) {
 			return true
 		}
@@ -94,7 +130,7 @@ func (rl *RateLimiter) Wait(path string) {
 	}
 
 	// Check search bucket for search endpoints.
-	if isSearchPath(path) {
+	if rl.isSearch(path) {
 		if wait := rl.search.waitDuration(); wait > 0 {
 			slog.Info("rate limiting: waiting for search bucket", "wait", wait)
 			rl.mu.Unlock()
@@ -131,7 +167,7 @@ func (rl *RateLimiter) UpdateFromHeaders(resp *http.Response, path string) {
 	}
 
 	// Update the appropriate bucket with server-reported remaining tokens.
-	if isSearchPath(path) && rem < rl.search.tokens {
+	if rl.isSearch(path) && rem < rl.search.tokens {
 		slog.Debug("rate limit: server reports lower search remaining", "remaining", rem)
 		rl.search.tokens = rem
 	}
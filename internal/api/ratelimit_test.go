@@ -4,6 +4,8 @@ import (
 	"net/http"
 	"testing"
 	"time"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
 )
 
 func TestNewRateLimiter(t *testing.T) {
@@ -16,7 +18,20 @@ func TestNewRateLimiter(t *testing.T) {
 	}
 }
 
-func TestIsSearchPath(t *testing.T) {
+func TestNewRateLimiterFor(t *testing.T) {
+	rl := NewRateLimiterFor(&model.Capabilities{RateLimit: 500, SearchPrefixes: []string{"/records"}})
+	if rl.general.maxTokens != 500 {
+		t.Errorf("general max = %f, want 500", rl.general.maxTokens)
+	}
+	if rl.search.maxTokens != 30 {
+		t.Errorf("search max = %f, want the default 30", rl.search.maxTokens)
+	}
+	if !rl.isSearch("/records/1") || rl.isSearch("/licenses") {
+		t.Error("search prefixes not applied")
+	}
+}
+
+func TestRateLimiter_IsSearch(t *testing.T) {
 	tests := []struct {
 		path   string
 		search bool
@@ -25,12 +40,14 @@ func TestIsSearchPath(t *testing.T) {
 		{"/records/123", true},
 		{"/communities", true},
 		{"/licenses", true},
+		{"/vocabularies/languages", true},
 		{"/deposit/depositions", false},
 		{"/api/user/records", false},
 	}
+	rl := NewRateLimiter()
 	for _, tt := range tests {
-		if got := isSearchPath(tt.path); got != tt.search {
-			t.Errorf("isSearchPath(%q) = %v, want %v", tt.path, got, tt.search)
+		if got := rl.isSearch(tt.path); got != tt.search {
+			t.Errorf("isSearch(%q) = %v, want %v", tt.path, got, tt.search)
 		}
 	}
 }
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// The InvenioRDM draft API. Zenodo and self-hosted InvenioRDM instances
+// both serve it; some features, and instances without the legacy deposit
+// API, work only through it. Record IDs are strings, since InvenioRDM
+// instances other than Zenodo use IDs like "q5jr8-hny72".
+
+// rdmDraftPath returns the path of a record's draft.
+func rdmDraftPath(id string) string {
+	return "/records/" + url.PathEscape(id) + "/draft"
+}
+
+// CreateDraft creates a new draft record.
+func (c *Client) CreateDraft(draft *model.RDMRecord) (*model.RDMRecord, error) {
+	var result model.RDMRecord
+	if err := c.Post("/records", draft, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetDraft retrieves the draft of a record.
+func (c *Client) GetDraft(id string) (*model.RDMRecord, error) {
+	var result model.RDMRecord
+	if err := c.Get(rdmDraftPath(id), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// EditRecord creates a draft of a published record so it can be edited,
+// or returns the existing draft.
+func (c *Client) EditRecord(id string) (*model.RDMRecord, error) {
+	var result model.RDMRecord
+	if err := c.Post(rdmDraftPath(id), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateDraft replaces a draft's metadata, access and custom fields.
+func (c *Client) UpdateDraft(id string, draft *model.RDMRecord) (*model.RDMRecord, error) {
+	var result model.RDMRecord
+	if err := c.Put(rdmDraftPath(id), draft, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PublishDraft publishes a draft and returns the published record.
+func (c *Client) PublishDraft(id string) (*model.RDMRecord, error) {
+	var result model.RDMRecord
+	if err := c.Post(rdmDraftPath(id)+"/actions/publish", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DiscardDraft deletes a draft. For a published record this discards the
+// unpublished edits; for a new record it deletes the record.
+func (c *Client) DiscardDraft(id string) error {
+	return c.Delete(rdmDraftPath(id), nil)
+}
+
+// NewRecordVersion creates a draft of a new version of a published record
+// and returns the draft.
+func (c *Client) NewRecordVersion(id string) (*model.RDMRecord, error) {
+	var result model.RDMRecord
+	if err := c.Post("/records/"+url.PathEscape(id)+"/versions", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListDraftFiles returns the files of a draft.
+func (c *Client) ListDraftFiles(id string) (*model.RDMDraftFiles, error) {
+	var result model.RDMDraftFiles
+	if err := c.Get(rdmDraftPath(id)+"/files", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UploadDraftFile uploads a file to a draft: it registers the key, streams
+// the content and commits the file.
+func (c *Client) UploadDraftFile(id, key string, r io.Reader) (*model.RDMDraftFile, error) {
+	filesPath := rdmDraftPath(id) + "/files"
+	if err := c.Post(filesPath, []map[string]string{{"key": key}}, nil); err != nil {
+		return nil, fmt.Errorf("registering file: %w", err)
+	}
+	filePath := filesPath + "/" + url.PathEscape(key)
+	if err := c.putStream(c.baseURL+filePath+"/content", r, nil); err != nil {
+		return nil, fmt.Errorf("uploading content: %w", err)
+	}
+	var result model.RDMDraftFile
+	if err := c.Post(filePath+"/commit", nil, &result); err != nil {
+		return nil, fmt.Errorf("committing file: %w", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+func TestDraftLifecycle(t *testing.T) {
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "POST /records":
+			var draft model.RDMRecord
+			json.NewDecoder(r.Body).Decode(&draft)
+			if draft.Metadata.Title != "New Record" {
+				t.Errorf("title = %q", draft.Metadata.Title)
+			}
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(model.RDMRecord{ID: "q5jr8-hny72", Status: "draft", Metadata: draft.Metadata})
+		case "PUT /records/q5jr8-hny72/draft", "GET /records/q5jr8-hny72/draft", "POST /records/q5jr8-hny72/draft":
+			json.NewEncoder(w).Encode(model.RDMRecord{ID: "q5jr8-hny72", Status: "draft"})
+		case "POST /records/q5jr8-hny72/draft/actions/publish":
+			w.WriteHeader(http.StatusAccepted)
+			json.NewEncoder(w).Encode(model.RDMRecord{ID: "q5jr8-hny72", Status: "published", IsPublished: true})
+		case "POST /records/q5jr8-hny72/versions":
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(model.RDMRecord{ID: "a1b2c-d3e4f", Status: "new_version_draft"})
+		case "DELETE /records/a1b2c-d3e4f/draft":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "tok")
+	draft, err := client.CreateDraft(&model.RDMRecord{Metadata: model.RDMMetadata{Title: "New Record"}})
+	if err != nil || draft.ID != "q5jr8-hny72" {
+		t.Fatalf("CreateDraft = %+v, %v", draft, err)
+	}
+	if _, err := client.GetDraft(draft.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.UpdateDraft(draft.ID, draft); err != nil {
+		t.Fatal(err)
+	}
+	rec, err := client.PublishDraft(draft.ID)
+	if err != nil || !rec.IsPublished {
+		t.Fatalf("PublishDraft = %+v, %v", rec, err)
+	}
+	if _, err := client.EditRecord(rec.ID); err != nil {
+		t.Fatal(err)
+	}
+	next, err := client.NewRecordVersion(rec.ID)
+	if err != nil || next.ID != "a1b2c-d3e4f" {
+		t.Fatalf("NewRecordVersion = %+v, %v", next, err)
+	}
+	if err := client.DiscardDraft(next.ID); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 7 {
+		t.Errorf("calls = %v", calls)
+	}
+}
+
+func TestUploadDraftFile(t *testing.T) {
+	var steps []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		steps = append(steps, r.Method+" "+r.URL.EscapedPath())
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "POST /records/abc/draft/files":
+			var keys []map[string]string
+			json.NewDecoder(r.Body).Decode(&keys)
+			if len(keys) != 1 || keys[0]["key"] != "data/obs 1.csv" {
+				t.Errorf("keys = %v", keys)
+			}
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(model.RDMDraftFiles{Enabled: true, Entries: []model.RDMDraftFile{{Key: keys[0]["key"], Status: "pending"}}})
+		case "PUT /records/abc/draft/files/data%2Fobs%201.csv/content":
+			if ct := r.Header.Get("Content-Type"); ct != "application/octet-stream" {
+				t.Errorf("Content-Type = %q", ct)
+			}
+			if body, _ := io.ReadAll(r.Body); string(body) != "a,b\n" {
+				t.Errorf("content = %q", body)
+			}
+			json.NewEncoder(w).Encode(model.RDMDraftFile{Key: "data/obs 1.csv", Status: "pending"})
+		case "POST /records/abc/draft/files/data%2Fobs%201.csv/commit":
+			json.NewEncoder(w).Encode(model.RDMDraftFile{Key: "data/obs 1.csv", Status: "completed", Size: 4})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.EscapedPath())
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "tok")
+	f, err := client.UploadDraftFile("abc", "data/obs 1.csv", strings.NewReader("a,b\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Status != "completed" || len(steps) != 3 {
+		t.Errorf("file = %+v, steps = %v", f, steps)
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -41,6 +42,38 @@ func (c *Client) GetRecord(id int) (*model.Record, error) {
 	return &result, nil
 }
 
+// MediaTypeRDM selects the InvenioRDM JSON serialization of a record.
+const MediaTypeRDM = "application/vnd.inveniordm.v1+json"
+
+// GetRDMRecord retrieves a published record in the InvenioRDM schema, which
+// carries fields the legacy serialization flattens or omits.
+func (c *Client) GetRDMRecord(id int) (*model.RDMRecord, error) {
+	return c.GetRDMRecordByID(strconv.Itoa(id))
+}
+
+// GetRDMRecordByID is GetRDMRecord for a string record ID, as used by
+// InvenioRDM instances other than Zenodo.
+func (c *Client) GetRDMRecordByID(id string) (*model.RDMRecord, error) {
+	data, err := c.GetRaw("/records/"+url.PathEscape(id), MediaTypeRDM)
+	if err != nil {
+		return nil, err
+	}
+	var result model.RDMRecord
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	return &result, nil
+}
+
+// GetLatestRecord retrieves the latest version of the record with the given ID.
+func (c *Client) GetLatestRecord(id int) (*model.Record, error) {
+	var result model.Record
+	if err := c.Get(fmt.Sprintf("/records/%d/latest", id), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // ListVersions returns all versions of a record.
 func (c *Client) ListVersions(id int) (*model.RecordSearchResult, error) {
 	var result model.RecordSearchResult
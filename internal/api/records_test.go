@@ -61,6 +61,52 @@ func TestGetRecord(t *testing.T) {
 	}
 }
 
+func TestGetLatestRecord(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/records/100/latest" {
+			t.Errorf("path = %q, want /records/100/latest", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(model.Record{ID: 105})
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "tok")
+	record, err := client.GetLatestRecord(100)
+	if err != nil {
+		t.Fatalf("GetLatestRecord() error: %v", err)
+	}
+	if record.ID != 105 {
+		t.Errorf("ID = %d, want 105", record.ID)
+	}
+}
+
+func TestGetRDMRecord(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/records/12345" {
+			t.Errorf("path = %q, want /records/12345", r.URL.Path)
+		}
+		if got := r.Header.Get("Accept"); got != MediaTypeRDM {
+			t.Errorf("Accept = %q, want %q", got, MediaTypeRDM)
+		}
+		w.Write([]byte(`{"id": "12345", "pids": {"doi": {"identifier": "10.5281/zenodo.12345"}},
+			"metadata": {"title": "My Record", "creators": [{"person_or_org": {"type": "personal", "name": "Doe, Jane"},
+			"affiliations": [{"id": "01ggx4157", "name": "CERN"}]}]}}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "tok")
+	record, err := client.GetRDMRecord(12345)
+	if err != nil {
+		t.Fatalf("GetRDMRecord() error: %v", err)
+	}
+	if record.ID != "12345" || record.DOI() != "10.5281/zenodo.12345" || record.Metadata.Title != "My Record" {
+		t.Errorf("record = %+v", record)
+	}
+	if aff := record.Metadata.Creators[0].Affiliations[0]; aff.ID != "01ggx4157" {
+		t.Errorf("affiliation = %+v", aff)
+	}
+}
+
 func TestListUserRecords(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/deposit/depositions" {
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"html"
+	"net/url"
+	"strconv"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// The InvenioRDM requests API, through which communities review the
+// records submitted to them. Requests are fetched expanded, so they carry
+// the submitter's name.
+
+// Request actions.
+const (
+	ActionAccept  = "accept"
+	ActionDecline = "decline"
+	ActionCancel  = "cancel"
+)
+
+// requestPath returns the path of a request.
+func requestPath(id string) string {
+	return "/requests/" + url.PathEscape(id)
+}
+
+// commentPayload wraps plain text as a request comment, which the API
+// stores as HTML.
+func commentPayload(text string) map[string]interface{} {
+	return map[string]interface{}{
+		"payload": map[string]string{"content": html.EscapeString(text), "format": "html"},
+	}
+}
+
+// ListCommunityRequests searches the requests a community has received,
+// newest first. communityID is the community's UUID. If open is set, only
+// requests awaiting a decision are returned.
+func (c *Client) ListCommunityRequests(communityID, q string, open bool, page, size int) (*model.RequestSearchResult, error) {
+	query := url.Values{"expand": {"1"}, "sort": {"newest"}}
+	if q != "" {
+		query.Set("q", q)
+	}
+	if open {
+		query.Set("is_open", "true")
+	}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if size > 0 {
+		query.Set("size", strconv.Itoa(size))
+	} else {
+		query.Set("size", "100")
+	}
+
+	var result model.RequestSearchResult
+	if err := c.Get("/communities/"+url.PathEscape(communityID)+"/requests", query, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListRecordRequests returns the requests about a record that the
+// authenticated user created or received, such as its community inclusion
+// requests, newest first.
+func (c *Client) ListRecordRequests(recordID string) (*model.RequestSearchResult, error) {
+	query := url.Values{
+		"q":      {"topic.record:" + strconv.Quote(recordID)},
+		"expand": {"1"},
+		"sort":   {"newest"},
+		"size":   {"100"},
+	}
+	var result model.RequestSearchResult
+	if err := c.Get("/user/requests", query, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetRequest retrieves a request.
+func (c *Client) GetRequest(id string) (*model.Request, error) {
+	var result model.Request
+	if err := c.Get(requestPath(id), url.Values{"expand": {"1"}}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RequestAction performs an action (ActionAccept, ActionDecline or
+// ActionCancel) on a request, with an optional comment, and returns the
+// updated request.
+func (c *Client) RequestAction(id, action, comment string) (*model.Request, error) {
+	var body interface{}
+	if comment != "" {
+		body = commentPayload(comment)
+	}
+	var result model.Request
+	if err := c.Post(requestPath(id)+"/actions/"+url.PathEscape(action), body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CommentRequest adds a comment to a request's timeline.
+func (c *Client) CommentRequest(id, comment string) (*model.RequestEvent, error) {
+	var result model.RequestEvent
+	if err := c.Post(requestPath(id)+"/comments", commentPayload(comment), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetRequestTimeline returns a request's comments and status changes,
+// oldest first.
+func (c *Client) GetRequestTimeline(id string) (*model.RequestTimeline, error) {
+	var result model.RequestTimeline
+	query := url.Values{"expand": {"1"}, "size": {"100"}, "sort": {"oldest"}}
+	if err := c.Get(requestPath(id)+"/timeline", query, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
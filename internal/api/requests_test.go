@@ -0,0 +1,82 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+func TestListCommunityRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/communities/c-1/requests" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("is_open") != "true" || q.Get("expand") != "1" || q.Get("size") != "100" {
+			t.Errorf("query = %v", q)
+		}
+		w.Write([]byte(`{"hits": {"total": 1, "hits": [{"id": "r-1", "type": "community-submission",
+			"status": "submitted", "is_open": true, "created_by": {"user": "7"}, "topic": {"record": "abcd-1234"},
+			"expanded": {"created_by": {"username": "jdoe", "profile": {"full_name": "Jane Doe"}}}}]}}`))
+	}))
+	defer srv.Close()
+
+	result, err := NewClient(srv.URL, "tok").ListCommunityRequests("c-1", "", true, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Hits.Total != 1 {
+		t.Fatalf("total = %d", result.Hits.Total)
+	}
+	req := result.Hits.Hits[0]
+	if req.RecordID() != "abcd-1234" || req.Submitter() != "Jane Doe" {
+		t.Errorf("request = %+v", req)
+	}
+}
+
+func TestRequestAction(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/requests/r-1/actions/decline" {
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			Payload struct{ Content, Format string }
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Payload.Content != "Out of scope &lt;sorry&gt;" || body.Payload.Format != "html" {
+			t.Errorf("payload = %+v", body.Payload)
+		}
+		json.NewEncoder(w).Encode(model.Request{ID: "r-1", Status: model.RequestDeclined})
+	}))
+	defer srv.Close()
+
+	req, err := NewClient(srv.URL, "tok").RequestAction("r-1", ActionDecline, "Out of scope <sorry>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Status != model.RequestDeclined {
+		t.Errorf("status = %q", req.Status)
+	}
+}
+
+func TestCommentRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/requests/r-1/comments" {
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": "e-1", "type": "C", "payload": {"content": "Please add a license"}}`))
+	}))
+	defer srv.Close()
+
+	ev, err := NewClient(srv.URL, "tok").CommentRequest("r-1", "Please add a license")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev.ID != "e-1" || ev.Payload.Content != "Please add a license" {
+		t.Errorf("event = %+v", ev)
+	}
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// ListVocabulary lists a page of the terms of a vocabulary, such as
+// "licenses", "resourcetypes" or "languages".
+func (c *Client) ListVocabulary(vocabulary string, page, size int) (*model.VocabularySearchResult, error) {
+	query := url.Values{}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if size > 0 {
+		query.Set("size", strconv.Itoa(size))
+	}
+
+	var result model.VocabularySearchResult
+	if err := c.Get("/vocabularies/"+url.PathEscape(vocabulary), query, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// searchQuery returns the query parameters of a vocabulary search.
+func searchQuery(q string, page, size int) url.Values {
+	query := url.Values{}
+	if q != "" {
+		query.Set("q", q)
+	}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if size > 0 {
+		query.Set("size", strconv.Itoa(size))
+	}
+	return query
+}
+
+// SearchFunders searches the funders vocabulary.
+func (c *Client) SearchFunders(q string, page, size int) (*model.FunderSearchResult, error) {
+	var result model.FunderSearchResult
+	if err := c.Get("/funders", searchQuery(q, page, size), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SearchAwards searches the awards vocabulary, optionally only the awards
+// of one funder (by ROR ID).
+func (c *Client) SearchAwards(q, funderID string, page, size int) (*model.AwardSearchResult, error) {
+	if funderID != "" {
+		filter := "funder.id:" + strconv.Quote(funderID)
+		if q != "" {
+			q = "(" + q + ") AND " + filter
+		} else {
+			q = filter
+		}
+	}
+	var result model.AwardSearchResult
+	if err := c.Get("/awards", searchQuery(q, page, size), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
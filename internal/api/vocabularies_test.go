@@ -0,0 +1,75 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+func TestListVocabulary(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/vocabularies/relationtypes" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if r.URL.Query().Get("page") != "2" || r.URL.Query().Get("size") != "500" {
+			t.Errorf("query = %q", r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(model.VocabularySearchResult{
+			Hits: model.VocabularyHits{
+				Hits:  []model.VocabularyTerm{{ID: "iscitedby", Title: map[string]string{"en": "Is cited by"}}},
+				Total: 501,
+			},
+		})
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "")
+	result, err := client.ListVocabulary("relationtypes", 2, 500)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if result.Hits.Total != 501 || result.Hits.Hits[0].TitleString() != "Is cited by" {
+		t.Errorf("result = %+v", result.Hits)
+	}
+}
+
+func TestSearchFunders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/funders" || r.URL.Query().Get("q") != "european" {
+			t.Errorf("%s %q", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"hits": {"total": 1, "hits": [{"id": "00k4n6c32", "name": "European Commission", "country": "BE",
+			"identifiers": [{"identifier": "00k4n6c32", "scheme": "ror"}, {"identifier": "10.13039/501100000780", "scheme": "doi"}]}]}}`))
+	}))
+	defer srv.Close()
+
+	result, err := NewClient(srv.URL, "").SearchFunders("european", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := result.Hits.Hits[0]; f.Name != "European Commission" || f.DOI() != "10.13039/501100000780" {
+		t.Errorf("funder = %+v", f)
+	}
+}
+
+func TestSearchAwards(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/awards" || r.URL.Query().Get("q") != `(ocean) AND funder.id:"00k4n6c32"` {
+			t.Errorf("%s %q", r.URL.Path, r.URL.Query().Get("q"))
+		}
+		w.Write([]byte(`{"hits": {"total": 1, "hits": [{"id": "00k4n6c32::862626", "number": "862626", "acronym": "EuroSea",
+			"title": {"en": "Improving and integrating European ocean observing"}, "funder": {"id": "00k4n6c32", "name": "European Commission"}}]}}`))
+	}))
+	defer srv.Close()
+
+	result, err := NewClient(srv.URL, "").SearchAwards("ocean", "00k4n6c32", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a := result.Hits.Hits[0]; a.Acronym != "EuroSea" || a.Funder.Name != "European Commission" || a.TitleString() == "" {
+		t.Errorf("award = %+v", a)
+	}
+}
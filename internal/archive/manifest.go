@@ -0,0 +1,63 @@
+// Package archive mirrors record files to a local directory and verifies
+// mirrored copies against their manifest and the remote record.
+package archive
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// ManifestName is the manifest file written at the root of a mirrored directory.
+const ManifestName = "zenodo-manifest.json"
+
+// Manifest records what was mirrored, so a later verify can detect local
+// corruption and remote changes.
+type Manifest struct {
+	RecordID   int            `json:"record_id"`
+	ConceptID  string         `json:"conceptrecid,omitempty"`
+	DOI        string         `json:"doi,omitempty"`
+	Revision   int            `json:"revision"`
+	Updated    time.Time      `json:"updated"`
+	MirroredAt time.Time      `json:"mirrored_at"`
+	Source     string         `json:"source,omitempty"`
+	Metadata   model.Metadata `json:"metadata"`
+	Files      []ManifestFile `json:"files"`
+}
+
+// ManifestFile is a single mirrored file with its checksums.
+type ManifestFile struct {
+	Key    string `json:"key"`
+	Size   int64  `json:"size"`
+	MD5    string `json:"md5"`
+	SHA256 string `json:"sha256"`
+}
+
+// ReadManifest loads the manifest from a mirrored directory.
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestName))
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing manifest: %w", err)
+	}
+	return &m, nil
+}
+
+// WriteManifest writes the manifest to the root of dir.
+func WriteManifest(dir string, m *Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestName), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package archive
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+	"github.com/ran-codes/zenodo-cli/internal/safepath"
+)
+
+// Mirror downloads every file of rec into dir and writes a manifest.
+// Each download is checked against the MD5 reported by the API before it
+// replaces any existing copy.
+func Mirror(client *api.Client, rec *model.Record, dir string) (*Manifest, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating directory: %w", err)
+	}
+
+	m := &Manifest{
+		RecordID:   rec.ID,
+		ConceptID:  rec.ConceptID,
+		DOI:        rec.DOI,
+		Revision:   rec.Revision,
+		Updated:    rec.Updated,
+		MirroredAt: time.Now().UTC(),
+		Source:     client.BaseURL(),
+		Metadata:   rec.Metadata,
+	}
+
+	for _, f := range rec.Files {
+		mf, err := DownloadFile(client, f, dir)
+		if err != nil {
+			return nil, err
+		}
+		m.Files = append(m.Files, *mf)
+	}
+
+	if err := WriteManifest(dir, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DownloadFile downloads a single record file into dir and returns its
+// manifest entry.
+func DownloadFile(client *api.Client, f model.File, dir string) (*ManifestFile, error) {
+	dest, err := safepath.Join(dir, f.Key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return nil, fmt.Errorf("creating directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dest), ".download-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	md5h, shah := md5.New(), sha256.New()
+	w := io.MultiWriter(tmp, md5h, shah)
+	if err := client.Download(f.DownloadURL(), w); err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("downloading %s: %w", f.Key, err)
+	}
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("writing %s: %w", f.Key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("writing %s: %w", f.Key, err)
+	}
+
+	sum := hex.EncodeToString(md5h.Sum(nil))
+	if want := f.MD5(); want != "" && want != sum {
+		return nil, fmt.Errorf("checksum mismatch for %s: got md5:%s, want md5:%s", f.Key, sum, want)
+	}
+
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return nil, fmt.Errorf("saving %s: %w", f.Key, err)
+	}
+
+	return &ManifestFile{
+		Key:    f.Key,
+		Size:   size,
+		MD5:    sum,
+		SHA256: hex.EncodeToString(shah.Sum(nil)),
+	}, nil
+}
+
+// hashFile computes the MD5 and SHA-256 of a local file.
+func hashFile(path string) (md5sum, sha256sum string, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", "", err
+	}
+	defer f.Close()
+
+	md5h, shah := md5.New(), sha256.New()
+	if _, err := io.Copy(io.MultiWriter(md5h, shah), f); err != nil {
+		return "", "", err
+	}
+	return hex.EncodeToString(md5h.Sum(nil)), hex.EncodeToString(shah.Sum(nil)), nil
+}
@@ -0,0 +1,97 @@
+package archive
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+func md5hex(s string) string {
+	sum := md5.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// fileServer serves the given contents under /files/<key>.
+func fileServer(t *testing.T, contents map[string]string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := contents[strings.TrimPrefix(r.URL.Path, "/files/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func testRecord(srvURL string, contents map[string]string) *model.Record {
+	rec := &model.Record{ID: 42, DOI: "10.5281/zenodo.42", Revision: 3, Metadata: model.Metadata{Title: "Mirrored"}}
+	for _, key := range []string{"data.csv", "readme.txt"} {
+		body, ok := contents[key]
+		if !ok {
+			continue
+		}
+		rec.Files = append(rec.Files, model.File{
+			Key:      key,
+			Size:     int64(len(body)),
+			Checksum: "md5:" + md5hex(body),
+			Links:    model.FileLinks{Self: srvURL + "/files/" + key},
+		})
+	}
+	return rec
+}
+
+func TestMirror(t *testing.T) {
+	contents := map[string]string{"data.csv": "a,b\n1,2\n", "readme.txt": "hello"}
+	srv := fileServer(t, contents)
+	rec := testRecord(srv.URL, contents)
+	rec.Files[1].Size = 999 // the manifest records the bytes actually written
+	dir := t.TempDir()
+
+	m, err := Mirror(api.NewClient(srv.URL, "tok"), rec, dir)
+	if err != nil {
+		t.Fatalf("Mirror() error: %v", err)
+	}
+	if len(m.Files) != 2 {
+		t.Fatalf("files = %d, want 2", len(m.Files))
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "data.csv"))
+	if err != nil || string(data) != contents["data.csv"] {
+		t.Errorf("data.csv = %q, %v", data, err)
+	}
+
+	read, err := ReadManifest(dir)
+	if err != nil {
+		t.Fatalf("ReadManifest() error: %v", err)
+	}
+	if read.RecordID != 42 || read.Files[1].MD5 != md5hex("hello") || read.Files[1].SHA256 == "" || read.Files[1].Size != 5 {
+		t.Errorf("unexpected manifest: %+v", read)
+	}
+}
+
+func TestMirror_ChecksumMismatch(t *testing.T) {
+	contents := map[string]string{"data.csv": "a,b\n"}
+	srv := fileServer(t, contents)
+	rec := testRecord(srv.URL, contents)
+	rec.Files[0].Checksum = "md5:" + md5hex("something else")
+	dir := t.TempDir()
+
+	_, err := Mirror(api.NewClient(srv.URL, "tok"), rec, dir)
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected checksum mismatch, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "data.csv")); !os.IsNotExist(err) {
+		t.Error("corrupt download should not be kept")
+	}
+}
@@ -0,0 +1,148 @@
+package archive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+	"github.com/ran-codes/zenodo-cli/internal/safepath"
+)
+
+// Status is the outcome of verifying a single file.
+type Status string
+
+const (
+	StatusOK        Status = "ok"
+	StatusMissing   Status = "missing"   // in the manifest but not on disk
+	StatusCorrupted Status = "corrupted" // on disk but checksum differs from the manifest
+	StatusChanged   Status = "changed"   // remote checksum differs from the manifest
+	StatusNew       Status = "new"       // on the remote record but not mirrored
+	StatusRemoved   Status = "removed"   // mirrored but no longer on the remote record
+)
+
+// metadataEntry is the pseudo file name used for metadata findings.
+const metadataEntry = "(metadata)"
+
+// Finding is a single verification result.
+type Finding struct {
+	File   string `json:"file"`
+	Status Status `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Report is the result of verifying a mirrored directory.
+type Report struct {
+	RecordID int       `json:"record_id"`
+	Findings []Finding `json:"findings"`
+}
+
+// Problems returns the number of findings that are not ok.
+func (r *Report) Problems() int {
+	n := 0
+	for _, f := range r.Findings {
+		if f.Status != StatusOK {
+			n++
+		}
+	}
+	return n
+}
+
+// Verify recomputes the checksums of the files listed in dir's manifest.
+// If remote is non-nil, the manifest is also compared against the remote
+// record's metadata and file list.
+func Verify(dir string, remote *model.Record) (*Report, error) {
+	m, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{RecordID: m.RecordID}
+
+	for _, mf := range m.Files {
+		report.Findings = append(report.Findings, verifyLocal(dir, mf))
+	}
+
+	if remote != nil {
+		report.Findings = append(report.Findings, compareRemote(m, remote)...)
+	}
+
+	return report, nil
+}
+
+// verifyLocal checks a mirrored file on disk against its manifest entry.
+func verifyLocal(dir string, mf ManifestFile) Finding {
+	path, err := safepath.Join(dir, mf.Key)
+	if err != nil {
+		return Finding{File: mf.Key, Status: StatusCorrupted, Detail: err.Error()}
+	}
+
+	md5sum, sha256sum, err := hashFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Finding{File: mf.Key, Status: StatusMissing}
+	}
+	if err != nil {
+		return Finding{File: mf.Key, Status: StatusCorrupted, Detail: err.Error()}
+	}
+
+	if mf.SHA256 != "" && sha256sum != mf.SHA256 {
+		return Finding{File: mf.Key, Status: StatusCorrupted, Detail: fmt.Sprintf("sha256 %s, manifest has %s", sha256sum, mf.SHA256)}
+	}
+	if mf.MD5 != "" && md5sum != mf.MD5 {
+		return Finding{File: mf.Key, Status: StatusCorrupted, Detail: fmt.Sprintf("md5 %s, manifest has %s", md5sum, mf.MD5)}
+	}
+	return Finding{File: mf.Key, Status: StatusOK}
+}
+
+// compareRemote reports differences between the manifest and the current
+// remote record.
+func compareRemote(m *Manifest, remote *model.Record) []Finding {
+	var findings []Finding
+
+	if changed, detail := metadataChanged(m, remote); changed {
+		findings = append(findings, Finding{File: metadataEntry, Status: StatusChanged, Detail: detail})
+	}
+
+	mirrored := make(map[string]ManifestFile, len(m.Files))
+	for _, mf := range m.Files {
+		mirrored[mf.Key] = mf
+	}
+
+	seen := make(map[string]bool, len(remote.Files))
+	for _, rf := range remote.Files {
+		seen[rf.Key] = true
+		mf, ok := mirrored[rf.Key]
+		if !ok {
+			findings = append(findings, Finding{File: rf.Key, Status: StatusNew, Detail: fmt.Sprintf("%d bytes", rf.Size)})
+			continue
+		}
+		if sum := rf.MD5(); sum != "" && sum != mf.MD5 {
+			findings = append(findings, Finding{File: rf.Key, Status: StatusChanged, Detail: fmt.Sprintf("remote md5 %s, mirrored %s", sum, mf.MD5)})
+		}
+	}
+
+	for _, mf := range m.Files {
+		if !seen[mf.Key] {
+			findings = append(findings, Finding{File: mf.Key, Status: StatusRemoved})
+		}
+	}
+
+	return findings
+}
+
+// metadataChanged compares the mirrored metadata with the remote record.
+func metadataChanged(m *Manifest, remote *model.Record) (bool, string) {
+	if remote.ID != m.RecordID {
+		return true, fmt.Sprintf("record %d, mirrored %d", remote.ID, m.RecordID)
+	}
+	if remote.Revision != m.Revision {
+		return true, fmt.Sprintf("revision %d, mirrored %d", remote.Revision, m.Revision)
+	}
+	a, errA := json.Marshal(m.Metadata)
+	b, errB := json.Marshal(remote.Metadata)
+	if errA != nil || errB != nil || string(a) != string(b) {
+		return true, "metadata differs from mirrored copy"
+	}
+	return false, ""
+}
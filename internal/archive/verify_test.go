@@ -0,0 +1,100 @@
+package archive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// mirrored sets up a mirrored directory and returns it with the record.
+func mirrored(t *testing.T) (string, *model.Record) {
+	t.Helper()
+	contents := map[string]string{"data.csv": "a,b\n1,2\n", "readme.txt": "hello"}
+	srv := fileServer(t, contents)
+	rec := testRecord(srv.URL, contents)
+	dir := t.TempDir()
+	if _, err := Mirror(api.NewClient(srv.URL, "tok"), rec, dir); err != nil {
+		t.Fatalf("Mirror() error: %v", err)
+	}
+	return dir, rec
+}
+
+func statuses(r *Report) map[string]Status {
+	out := make(map[string]Status)
+	for _, f := range r.Findings {
+		if f.Status != StatusOK || out[f.File] == "" {
+			out[f.File] = f.Status
+		}
+	}
+	return out
+}
+
+func TestVerify_Clean(t *testing.T) {
+	dir, rec := mirrored(t)
+	report, err := Verify(dir, rec)
+	if err != nil {
+		t.Fatalf("Verify() error: %v", err)
+	}
+	if report.Problems() != 0 {
+		t.Errorf("expected no problems, got %+v", report.Findings)
+	}
+}
+
+func TestVerify_MissingAndCorrupted(t *testing.T) {
+	dir, _ := mirrored(t)
+	os.Remove(filepath.Join(dir, "readme.txt"))
+	os.WriteFile(filepath.Join(dir, "data.csv"), []byte("tampered"), 0644)
+
+	report, err := Verify(dir, nil)
+	if err != nil {
+		t.Fatalf("Verify() error: %v", err)
+	}
+	got := statuses(report)
+	if got["readme.txt"] != StatusMissing {
+		t.Errorf("readme.txt = %q, want missing", got["readme.txt"])
+	}
+	if got["data.csv"] != StatusCorrupted {
+		t.Errorf("data.csv = %q, want corrupted", got["data.csv"])
+	}
+	if report.Problems() != 2 {
+		t.Errorf("problems = %d, want 2", report.Problems())
+	}
+}
+
+func TestVerify_RemoteChanges(t *testing.T) {
+	dir, rec := mirrored(t)
+
+	remote := *rec
+	remote.Revision = 4
+	remote.Metadata.Title = "Renamed"
+	remote.Files = []model.File{
+		{Key: "data.csv", Checksum: "md5:" + md5hex("new content")},
+		{Key: "extra.zip", Size: 10},
+	}
+
+	report, err := Verify(dir, &remote)
+	if err != nil {
+		t.Fatalf("Verify() error: %v", err)
+	}
+	got := statuses(report)
+	want := map[string]Status{
+		metadataEntry: StatusChanged,
+		"data.csv":    StatusChanged,
+		"extra.zip":   StatusNew,
+		"readme.txt":  StatusRemoved,
+	}
+	for file, status := range want {
+		if got[file] != status {
+			t.Errorf("%s = %q, want %q", file, got[file], status)
+		}
+	}
+}
+
+func TestVerify_NoManifest(t *testing.T) {
+	if _, err := Verify(t.TempDir(), nil); err == nil {
+		t.Error("expected error for directory without manifest")
+	}
+}
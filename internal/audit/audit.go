@@ -0,0 +1,135 @@
+// Package audit lints a set of records and summarizes the result as a
+// quality report: per-record findings and scores, and counts per rule.
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ran-codes/zenodo-cli/internal/lint"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// Record is the audit result of one record.
+type Record struct {
+	ID       int            `json:"id"`
+	Title    string         `json:"title"`
+	URL      string         `json:"url,omitempty"`
+	DOI      string         `json:"doi,omitempty"`
+	Score    int            `json:"score"`
+	Findings []lint.Finding `json:"findings"`
+}
+
+// Count returns the number of the record's findings at level.
+func (r Record) Count(level lint.Level) int {
+	return lint.Count(r.Findings)[level]
+}
+
+// RuleSummary counts the findings of one rule across all records.
+type RuleSummary struct {
+	Rule        string     `json:"rule"`
+	Description string     `json:"description"`
+	Level       lint.Level `json:"level"`
+	// Records is the number of records with at least one finding.
+	Records  int `json:"records"`
+	Findings int `json:"findings"`
+}
+
+// Report is a complete audit. Records are ordered worst score first.
+type Report struct {
+	Scope        string        `json:"scope"`
+	Generated    time.Time     `json:"generated"`
+	AverageScore int           `json:"average_score"`
+	Records      []Record      `json:"records"`
+	Rules        []RuleSummary `json:"rules"`
+}
+
+// Count returns the number of findings at level across all records.
+func (r *Report) Count(level lint.Level) int {
+	n := 0
+	for _, rec := range r.Records {
+		n += rec.Count(level)
+	}
+	return n
+}
+
+// row is the part of a listing row (a record or deposition) the audit needs.
+type row struct {
+	ID       int            `json:"id"`
+	Title    string         `json:"title"`
+	DOI      string         `json:"doi"`
+	Metadata model.Metadata `json:"metadata"`
+	Links    model.Links    `json:"links"`
+}
+
+// Build lints each listing row with cfg. scope describes the records, e.g.
+// "community my-org".
+func Build(scope string, rows []map[string]interface{}, cfg lint.Config) (*Report, error) {
+	rep := &Report{Scope: scope, Generated: time.Now().UTC(), Records: []Record{}}
+	counts := make(map[string]*RuleSummary)
+	for _, r := range lint.Rules {
+		level := cfg.LevelFor(r)
+		if level == lint.LevelOff {
+			continue
+		}
+		rep.Rules = append(rep.Rules, RuleSummary{Rule: r.ID, Description: r.Description, Level: level})
+	}
+	for i := range rep.Rules {
+		counts[rep.Rules[i].Rule] = &rep.Rules[i]
+	}
+
+	total := 0
+	for _, m := range rows {
+		var r row
+		b, err := json.Marshal(m)
+		if err == nil {
+			err = json.Unmarshal(b, &r)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading record: %w", err)
+		}
+
+		findings := lint.Lint(r.Metadata, cfg)
+		rec := Record{
+			ID:       r.ID,
+			Title:    r.Metadata.Title,
+			URL:      r.Links.HTML,
+			DOI:      r.DOI,
+			Score:    lint.Score(findings, cfg),
+			Findings: findings,
+		}
+		if rec.Title == "" {
+			rec.Title = r.Title
+		}
+		if rec.DOI == "" {
+			rec.DOI = r.Metadata.DOI
+		}
+		rep.Records = append(rep.Records, rec)
+		total += rec.Score
+
+		seen := make(map[string]bool)
+		for _, f := range findings {
+			s := counts[f.Rule]
+			s.Findings++
+			if !seen[f.Rule] {
+				s.Records++
+				seen[f.Rule] = true
+			}
+		}
+	}
+	if len(rep.Records) > 0 {
+		rep.AverageScore = total / len(rep.Records)
+	} else {
+		rep.AverageScore = 100
+	}
+
+	sort.SliceStable(rep.Records, func(i, j int) bool {
+		if rep.Records[i].Score != rep.Records[j].Score {
+			return rep.Records[i].Score < rep.Records[j].Score
+		}
+		return rep.Records[i].ID < rep.Records[j].ID
+	})
+	return rep, nil
+}
@@ -0,0 +1,175 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/ran-codes/zenodo-cli/internal/lint"
+)
+
+func rows() []map[string]interface{} {
+	good := map[string]interface{}{
+		"id":  1,
+		"doi": "10.5281/zenodo.1",
+		"metadata": map[string]interface{}{
+			"title":        "Good record",
+			"description":  strings.Repeat("word ", 60),
+			"upload_type":  "dataset",
+			"access_right": "open",
+			"license":      map[string]interface{}{"id": "cc-by-4.0"},
+			"keywords":     []string{"ocean"},
+			"creators":     []map[string]interface{}{{"name": "Doe, Jane", "orcid": "0000-0002-1825-0097", "affiliation": "Example University"}},
+			"grants":       []map[string]interface{}{{"id": "10.13039/501100000780::283595"}},
+		},
+		"links":     map[string]interface{}{"html": "https://zenodo.org/records/1"},
+		"community": "my-org",
+	}
+	poor := map[string]interface{}{
+		"id": 2,
+		"metadata": map[string]interface{}{
+			"title":        "Poor | record",
+			"description":  "short",
+			"access_right": "open",
+			"creators":     []map[string]interface{}{{"name": "Roe, Rick"}, {"name": "Poe, Pat"}},
+		},
+		"community": "my-org",
+	}
+	return []map[string]interface{}{good, poor}
+}
+
+func TestBuild(t *testing.T) {
+	rep, err := Build("community my-org", rows(), lint.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rep.Records) != 2 {
+		t.Fatalf("records = %d", len(rep.Records))
+	}
+	worst, best := rep.Records[0], rep.Records[1]
+	if worst.ID != 2 || best.ID != 1 {
+		t.Fatalf("order = %d, %d; want worst first", worst.ID, best.ID)
+	}
+	if best.Score != 100 || len(best.Findings) != 0 {
+		t.Errorf("good record: score %d, findings %+v", best.Score, best.Findings)
+	}
+	if best.URL != "https://zenodo.org/records/1" || best.DOI != "10.5281/zenodo.1" {
+		t.Errorf("good record: url %q, doi %q", best.URL, best.DOI)
+	}
+	if worst.Score >= 100 || worst.Count(lint.LevelError) != 1 {
+		t.Errorf("poor record: score %d, findings %+v", worst.Score, worst.Findings)
+	}
+	if rep.AverageScore != (worst.Score+best.Score)/2 {
+		t.Errorf("average = %d", rep.AverageScore)
+	}
+
+	byRule := make(map[string]RuleSummary)
+	for _, s := range rep.Rules {
+		byRule[s.Rule] = s
+	}
+	if s := byRule["creator-orcid"]; s.Records != 1 || s.Findings != 2 {
+		t.Errorf("creator-orcid summary = %+v", s)
+	}
+	if s := byRule["identifier-syntax"]; s.Records != 0 || s.Findings != 0 {
+		t.Errorf("identifier-syntax summary = %+v", s)
+	}
+}
+
+func TestBuild_DisabledRules(t *testing.T) {
+	cfg := lint.Config{Rules: map[string]lint.Level{"creator-orcid": lint.LevelOff}}
+	rep, err := Build("test", rows(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range rep.Rules {
+		if s.Rule == "creator-orcid" {
+			t.Error("disabled rule listed in summary")
+		}
+	}
+	for _, f := range rep.Records[0].Findings {
+		if f.Rule == "creator-orcid" {
+			t.Errorf("finding from disabled rule: %+v", f)
+		}
+	}
+}
+
+func TestBuild_Empty(t *testing.T) {
+	rep, err := Build("test", nil, lint.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rep.AverageScore != 100 || rep.Records == nil {
+		t.Errorf("empty report = %+v", rep)
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	rep, _ := Build("community my-org", rows(), lint.Config{})
+	var buf bytes.Buffer
+	if err := Write(&buf, rep, "markdown"); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"# Metadata audit: community my-org",
+		"## Summary by rule",
+		"| `creator-orcid` | warning | 1 | 2 |",
+		`Poor \| record`,
+		"[Good record](https://zenodo.org/records/1)",
+		"- **error** `open-access-license`",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	rep, _ := Build("<community>", rows(), lint.Config{})
+	var buf bytes.Buffer
+	if err := Write(&buf, rep, "html"); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "Metadata audit: &lt;community&gt;") {
+		t.Error("scope not escaped")
+	}
+	if !strings.Contains(out, `<a href="https://zenodo.org/records/1">Good record</a>`) {
+		t.Error("record link missing")
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	rep, _ := Build("test", rows(), lint.Config{})
+	var buf bytes.Buffer
+	if err := Write(&buf, rep, "csv"); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := 1 + len(rep.Records[0].Findings) + 1
+	if len(records) != want {
+		t.Fatalf("rows = %d, want %d", len(records), want)
+	}
+	last := records[len(records)-1]
+	if last[0] != "1" || last[4] != "100" || last[5] != "" {
+		t.Errorf("clean record row = %v", last)
+	}
+}
+
+func TestWrite_UnknownFormat(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, &Report{}, "pdf"); err == nil {
+		t.Error("expected error")
+	}
+	if err := ValidFormat("pdf"); err == nil {
+		t.Error("ValidFormat accepted pdf")
+	}
+	for _, f := range []string{"markdown", "md", "html", "csv"} {
+		if err := ValidFormat(f); err != nil {
+			t.Errorf("ValidFormat(%q): %v", f, err)
+		}
+	}
+}
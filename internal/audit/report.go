@@ -0,0 +1,161 @@
+package audit
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ran-codes/zenodo-cli/internal/lint"
+)
+
+// Formats lists the report formats, for flag help and errors.
+const Formats = "markdown, html, csv"
+
+// writers maps each report format, and its aliases, to its writer.
+var writers = map[string]func(io.Writer, *Report) error{
+	"markdown": WriteMarkdown,
+	"md":       WriteMarkdown,
+	"html":     WriteHTML,
+	"csv":      WriteCSV,
+}
+
+// ValidFormat checks that format is a report format.
+func ValidFormat(format string) error {
+	if _, ok := writers[format]; !ok {
+		return fmt.Errorf("unsupported report format %q (supported: %s)", format, Formats)
+	}
+	return nil
+}
+
+// Write renders rep in format: markdown, html or csv.
+func Write(w io.Writer, rep *Report, format string) error {
+	if err := ValidFormat(format); err != nil {
+		return err
+	}
+	return writers[format](w, rep)
+}
+
+// mdEscape keeps text from breaking a Markdown table cell.
+func mdEscape(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// recordLink is the record's title, linked when it has a URL.
+func recordLink(r Record) string {
+	title := mdEscape(r.Title)
+	if title == "" {
+		title = fmt.Sprintf("Record %d", r.ID)
+	}
+	if r.URL == "" {
+		return title
+	}
+	return fmt.Sprintf("[%s](%s)", strings.NewReplacer("[", `\[`, "]", `\]`).Replace(title), r.URL)
+}
+
+// WriteMarkdown renders rep as a Markdown document.
+func WriteMarkdown(w io.Writer, rep *Report) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Metadata audit: %s\n\n", mdEscape(rep.Scope))
+	fmt.Fprintf(&b, "Generated %s. %d records, average score %d/100. %d errors, %d warnings, %d info.\n\n",
+		rep.Generated.Format("2006-01-02 15:04 MST"), len(rep.Records), rep.AverageScore,
+		rep.Count(lint.LevelError), rep.Count(lint.LevelWarning), rep.Count(lint.LevelInfo))
+
+	b.WriteString("## Summary by rule\n\n")
+	b.WriteString("| Rule | Level | Records | Findings | Checks |\n|------|-------|--------:|---------:|--------|\n")
+	for _, s := range rep.Rules {
+		fmt.Fprintf(&b, "| `%s` | %s | %d | %d | %s |\n", s.Rule, s.Level, s.Records, s.Findings, mdEscape(s.Description))
+	}
+
+	b.WriteString("\n## Records\n\n")
+	b.WriteString("| Score | ID | Title | Errors | Warnings | Info |\n|------:|---:|-------|-------:|---------:|-----:|\n")
+	for _, r := range rep.Records {
+		fmt.Fprintf(&b, "| %d | %d | %s | %d | %d | %d |\n", r.Score, r.ID, recordLink(r),
+			r.Count(lint.LevelError), r.Count(lint.LevelWarning), r.Count(lint.LevelInfo))
+	}
+
+	b.WriteString("\n## Findings\n")
+	for _, r := range rep.Records {
+		if len(r.Findings) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n### %s (%d), score %d\n\n", recordLink(r), r.ID, r.Score)
+		for _, f := range r.Findings {
+			fmt.Fprintf(&b, "- **%s** `%s` `%s`: %s\n", f.Level, f.Rule, f.Path, mdEscape(f.Message))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var htmlReport = template.Must(template.New("audit").Funcs(template.FuncMap{
+	"count": func(r Record, level string) int { return r.Count(lint.Level(level)) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Metadata audit: {{.Scope}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem; color: #222; }
+table { border-collapse: collapse; margin-bottom: 2rem; }
+th, td { border: 1px solid #ccc; padding: 0.3rem 0.6rem; text-align: left; vertical-align: top; }
+td.num { text-align: right; }
+.error { color: #b00020; } .warning { color: #a15c00; } .info { color: #555; }
+details { margin: 0.5rem 0; }
+</style>
+</head>
+<body>
+<h1>Metadata audit: {{.Scope}}</h1>
+<p>Generated {{.Generated.Format "2006-01-02 15:04 MST"}}. {{len .Records}} records, average score {{.AverageScore}}/100.
+{{.Count "error"}} errors, {{.Count "warning"}} warnings, {{.Count "info"}} info.</p>
+
+<h2>Summary by rule</h2>
+<table>
+<tr><th>Rule</th><th>Level</th><th>Records</th><th>Findings</th><th>Checks</th></tr>
+{{range .Rules}}<tr><td><code>{{.Rule}}</code></td><td class="{{.Level}}">{{.Level}}</td><td class="num">{{.Records}}</td><td class="num">{{.Findings}}</td><td>{{.Description}}</td></tr>
+{{end}}</table>
+
+<h2>Records</h2>
+<table>
+<tr><th>Score</th><th>ID</th><th>Title</th><th>Errors</th><th>Warnings</th><th>Info</th></tr>
+{{range .Records}}<tr><td class="num">{{.Score}}</td><td class="num">{{.ID}}</td><td>{{if .URL}}<a href="{{.URL}}">{{.Title}}</a>{{else}}{{.Title}}{{end}}</td><td class="num">{{count . "error"}}</td><td class="num">{{count . "warning"}}</td><td class="num">{{count . "info"}}</td></tr>
+{{end}}</table>
+
+<h2>Findings</h2>
+{{range .Records}}{{if .Findings}}<details>
+<summary>{{.Title}} ({{.ID}}), score {{.Score}}</summary>
+<ul>
+{{range .Findings}}<li><span class="{{.Level}}">{{.Level}}</span> <code>{{.Rule}}</code> <code>{{.Path}}</code>: {{.Message}}</li>
+{{end}}</ul>
+</details>
+{{end}}{{end}}</body>
+</html>
+`))
+
+// WriteHTML renders rep as a standalone HTML page.
+func WriteHTML(w io.Writer, rep *Report) error {
+	return htmlReport.Execute(w, rep)
+}
+
+// WriteCSV renders rep with one row per finding. Records without findings
+// get a single row with empty finding columns, so every score is listed.
+func WriteCSV(w io.Writer, rep *Report) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"record_id", "title", "url", "doi", "score", "level", "rule", "path", "message"})
+	for _, r := range rep.Records {
+		base := []string{strconv.Itoa(r.ID), r.Title, r.URL, r.DOI, strconv.Itoa(r.Score)}
+		if len(r.Findings) == 0 {
+			cw.Write(append(base, "", "", "", ""))
+			continue
+		}
+		for _, f := range r.Findings {
+			cw.Write(append(append([]string{}, base...), string(f.Level), f.Rule, f.Path, f.Message))
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
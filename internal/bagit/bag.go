@@ -0,0 +1,181 @@
+// Package bagit writes and validates BagIt (RFC 8493) bags.
+package bagit
+
+import (
+	"bufio"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Version is the BagIt version written to bagit.txt.
+const Version = "1.0"
+
+// PayloadDir is the directory holding payload files inside a bag.
+const PayloadDir = "data"
+
+// algorithms are the manifest algorithms written for every bag.
+var algorithms = []string{"md5", "sha256"}
+
+// PayloadFile is a payload file already written under the bag's data/
+// directory, with its checksums.
+type PayloadFile struct {
+	Path   string // relative to data/, slash-separated
+	Size   int64
+	MD5    string
+	SHA256 string
+}
+
+// Tag is a single bag-info.txt entry. Labels may repeat, so tags are kept
+// as an ordered list rather than a map.
+type Tag struct {
+	Label string
+	Value string
+}
+
+// Write writes the tag files (bagit.txt, bag-info.txt, payload manifests and
+// tag manifests) for payload files already present under dir/data.
+// Payload-Oxum is appended to info automatically.
+func Write(dir string, payload []PayloadFile, info []Tag) error {
+	var octets int64
+	for _, p := range payload {
+		octets += p.Size
+	}
+	info = append(info, Tag{Label: "Payload-Oxum", Value: fmt.Sprintf("%d.%d", octets, len(payload))})
+
+	declaration := fmt.Sprintf("BagIt-Version: %s\nTag-File-Character-Encoding: UTF-8\n", Version)
+	if err := writeFile(dir, "bagit.txt", declaration); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	for _, t := range info {
+		fmt.Fprintf(&b, "%s: %s\n", t.Label, strings.ReplaceAll(t.Value, "\n", "\n  "))
+	}
+	if err := writeFile(dir, "bag-info.txt", b.String()); err != nil {
+		return err
+	}
+
+	sorted := append([]PayloadFile(nil), payload...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Path < sorted[j].Path })
+
+	for _, algo := range algorithms {
+		var m strings.Builder
+		for _, p := range sorted {
+			sum := p.MD5
+			if algo == "sha256" {
+				sum = p.SHA256
+			}
+			fmt.Fprintf(&m, "%s  %s\n", sum, encodePath(PayloadDir+"/"+p.Path))
+		}
+		if err := writeFile(dir, "manifest-"+algo+".txt", m.String()); err != nil {
+			return err
+		}
+	}
+
+	// Tag manifests cover every tag file written above.
+	tagFiles := []string{"bagit.txt", "bag-info.txt"}
+	for _, algo := range algorithms {
+		tagFiles = append(tagFiles, "manifest-"+algo+".txt")
+	}
+	for _, algo := range algorithms {
+		var m strings.Builder
+		for _, name := range tagFiles {
+			sum, err := checksum(filepath.Join(dir, name), algo)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(&m, "%s  %s\n", sum, name)
+		}
+		if err := writeFile(dir, "tagmanifest-"+algo+".txt", m.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeFile(dir, name, content string) error {
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	return nil
+}
+
+// newHash returns a hash for a BagIt algorithm name.
+func newHash(algo string) (hash.Hash, error) {
+	switch algo {
+	case "md5":
+		return md5.New(), nil
+	case "sha1":
+		return sha1.New(), nil
+	case "sha256":
+		return sha256.New(), nil
+	case "sha512":
+		return sha512.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported checksum algorithm %q", algo)
+	}
+}
+
+// checksum computes the hex digest of a file.
+func checksum(path, algo string) (string, error) {
+	h, err := newHash(algo)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// encodePath percent-encodes the characters the spec requires in manifest paths.
+func encodePath(p string) string {
+	r := strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	return r.Replace(p)
+}
+
+// decodePath reverses encodePath.
+func decodePath(p string) string {
+	r := strings.NewReplacer("%0D", "\r", "%0d", "\r", "%0A", "\n", "%0a", "\n", "%25", "%")
+	return r.Replace(p)
+}
+
+// readManifest parses a manifest file into path → checksum.
+func readManifest(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if text == "" {
+			continue
+		}
+		sum, name, ok := strings.Cut(text, " ")
+		if !ok {
+			return nil, fmt.Errorf("%s line %d: malformed entry", filepath.Base(path), line)
+		}
+		entries[decodePath(strings.TrimLeft(name, " *"))] = strings.ToLower(sum)
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,194 @@
+package bagit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// makeBag writes a small valid bag and returns its directory.
+func makeBag(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{"data.csv": "a,b\n1,2\n", "docs/readme.txt": "hello"}
+
+	var payload []PayloadFile
+	for name, body := range files {
+		path := filepath.Join(dir, PayloadDir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+		md5sum, _ := checksum(path, "md5")
+		shasum, _ := checksum(path, "sha256")
+		payload = append(payload, PayloadFile{Path: name, Size: int64(len(body)), MD5: md5sum, SHA256: shasum})
+	}
+
+	info := []Tag{{Label: "External-Identifier", Value: "https://doi.org/10.5281/zenodo.1"}}
+	if err := Write(dir, payload, info); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	return dir
+}
+
+func TestWrite(t *testing.T) {
+	dir := makeBag(t)
+
+	for _, name := range []string{"bagit.txt", "bag-info.txt", "manifest-md5.txt", "manifest-sha256.txt", "tagmanifest-md5.txt", "tagmanifest-sha256.txt"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s not written: %v", name, err)
+		}
+	}
+
+	info, _ := os.ReadFile(filepath.Join(dir, "bag-info.txt"))
+	if !strings.Contains(string(info), "Payload-Oxum: 13.2") {
+		t.Errorf("bag-info.txt = %q", info)
+	}
+
+	manifest, _ := os.ReadFile(filepath.Join(dir, "manifest-sha256.txt"))
+	if !strings.Contains(string(manifest), "  data/docs/readme.txt\n") {
+		t.Errorf("manifest-sha256.txt = %q", manifest)
+	}
+}
+
+func TestValidate_Valid(t *testing.T) {
+	problems, err := Validate(makeBag(t))
+	if err != nil {
+		t.Fatalf("Validate() error: %v", err)
+	}
+	if len(problems) != 0 {
+		t.Errorf("expected valid bag, got %+v", problems)
+	}
+}
+
+func TestValidate_Tampered(t *testing.T) {
+	dir := makeBag(t)
+	os.WriteFile(filepath.Join(dir, "data", "data.csv"), []byte("changed"), 0644)
+
+	problems, err := Validate(dir)
+	if err != nil {
+		t.Fatalf("Validate() error: %v", err)
+	}
+	if !hasProblem(problems, "data/data.csv", "checksum mismatch") {
+		t.Errorf("expected checksum mismatch, got %+v", problems)
+	}
+	if !hasProblem(problems, "bag-info.txt", "Payload-Oxum") {
+		t.Errorf("expected Payload-Oxum mismatch, got %+v", problems)
+	}
+}
+
+func TestValidate_Incomplete(t *testing.T) {
+	dir := makeBag(t)
+	os.WriteFile(filepath.Join(dir, "data", "extra.txt"), []byte("x"), 0644)
+	os.Remove(filepath.Join(dir, "data", "docs", "readme.txt"))
+
+	problems, err := Validate(dir)
+	if err != nil {
+		t.Fatalf("Validate() error: %v", err)
+	}
+	if !hasProblem(problems, "data/extra.txt", "not listed") {
+		t.Errorf("expected unlisted payload file, got %+v", problems)
+	}
+	if !hasProblem(problems, "data/docs/readme.txt", "missing") {
+		t.Errorf("expected missing payload file, got %+v", problems)
+	}
+}
+
+func TestValidate_PathsOutsideBag(t *testing.T) {
+	dir := makeBag(t)
+	f, _ := os.OpenFile(filepath.Join(dir, "manifest-md5.txt"), os.O_APPEND|os.O_WRONLY, 0644)
+	f.WriteString("d41d8cd98f00b204e9800998ecf8427e  ../../etc/shadow\nd41d8cd98f00b204e9800998ecf8427e  bagit.txt\n")
+	f.Close()
+
+	problems, err := Validate(dir)
+	if err != nil {
+		t.Fatalf("Validate() error: %v", err)
+	}
+	if !hasProblem(problems, "../../etc/shadow", "outside the bag") {
+		t.Errorf("expected path outside the bag, got %+v", problems)
+	}
+	if !hasProblem(problems, "bagit.txt", "not under data/") {
+		t.Errorf("expected tag file in payload manifest, got %+v", problems)
+	}
+}
+
+func TestValidate_SHA512Only(t *testing.T) {
+	dir := makeBag(t)
+	var manifest string
+	for _, p := range []string{"data/data.csv", "data/docs/readme.txt"} {
+		sum, _ := checksum(filepath.Join(dir, filepath.FromSlash(p)), "sha512")
+		manifest += sum + "  " + p + "\n"
+	}
+	for _, name := range []string{"manifest-md5.txt", "manifest-sha256.txt", "tagmanifest-md5.txt", "tagmanifest-sha256.txt"} {
+		os.Remove(filepath.Join(dir, name))
+	}
+	os.WriteFile(filepath.Join(dir, "manifest-sha512.txt"), []byte(manifest), 0644)
+
+	problems, err := Validate(dir)
+	if err != nil {
+		t.Fatalf("Validate() error: %v", err)
+	}
+	if len(problems) != 0 {
+		t.Errorf("expected valid bag, got %+v", problems)
+	}
+
+	os.WriteFile(filepath.Join(dir, "manifest-blake3.txt"), []byte(manifest), 0644)
+	if problems, _ := Validate(dir); !hasProblem(problems, "manifest-blake3.txt", "unsupported") {
+		t.Errorf("expected unsupported algorithm, got %+v", problems)
+	}
+}
+
+func TestValidate_NotABag(t *testing.T) {
+	problems, err := Validate(t.TempDir())
+	if err != nil {
+		t.Fatalf("Validate() error: %v", err)
+	}
+	if !hasProblem(problems, "bagit.txt", "declaration") {
+		t.Errorf("expected missing declaration, got %+v", problems)
+	}
+}
+
+func TestEncodePath(t *testing.T) {
+	p := "data/100%\nfile"
+	if got := decodePath(encodePath(p)); got != p {
+		t.Errorf("round trip = %q", got)
+	}
+	if strings.Contains(encodePath(p), "\n") {
+		t.Error("newline should be encoded")
+	}
+}
+
+func TestInfoFromRecord(t *testing.T) {
+	rec := &model.Record{
+		DOI: "10.5281/zenodo.42",
+		Metadata: model.Metadata{
+			Title:    "My Dataset",
+			Creators: []model.Creator{{Name: "Doe, Jane", Affiliation: "Example University"}},
+		},
+	}
+	info := InfoFromRecord(rec, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+
+	want := map[string]string{
+		"Source-Organization": "Example University",
+		"External-Identifier": "https://doi.org/10.5281/zenodo.42",
+		"Bagging-Date":        "2024-05-01",
+	}
+	for label, value := range want {
+		if got := tagValue(info, label); got != value {
+			t.Errorf("%s = %q, want %q", label, got, value)
+		}
+	}
+}
+
+func hasProblem(problems []Problem, path, substr string) bool {
+	for _, p := range problems {
+		if p.Path == path && strings.Contains(p.Message, substr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package bagit
+
+import (
+	"time"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// InfoFromRecord builds bag-info.txt entries from a record's metadata.
+func InfoFromRecord(rec *model.Record, now time.Time) []Tag {
+	var info []Tag
+	if org := sourceOrganization(rec.Metadata); org != "" {
+		info = append(info, Tag{Label: "Source-Organization", Value: org})
+	}
+	doi := rec.DOI
+	if doi == "" {
+		doi = rec.Metadata.DOI
+	}
+	if doi != "" {
+		info = append(info, Tag{Label: "External-Identifier", Value: "https://doi.org/" + doi})
+	}
+	if rec.Metadata.Title != "" {
+		info = append(info, Tag{Label: "External-Description", Value: rec.Metadata.Title})
+	}
+	if rec.ConceptDOI != "" {
+		info = append(info, Tag{Label: "Bag-Group-Identifier", Value: "https://doi.org/" + rec.ConceptDOI})
+	}
+	info = append(info, Tag{Label: "Bagging-Date", Value: now.Format("2006-01-02")})
+	info = append(info, Tag{Label: "Bag-Software-Agent", Value: "zenodo-cli"})
+	return info
+}
+
+// sourceOrganization prefers the imprint publisher, then the first creator
+// affiliation.
+func sourceOrganization(m model.Metadata) string {
+	if m.ImprintPublisher != "" {
+		return m.ImprintPublisher
+	}
+	for _, c := range m.Creators {
+		if c.Affiliation != "" {
+			return c.Affiliation
+		}
+	}
+	return ""
+}
@@ -0,0 +1,203 @@
+package bagit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Problem is a single validation failure.
+type Problem struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// Validate checks that dir is a complete and valid bag: the declaration is
+// present, every payload file is listed in each payload manifest, every
+// manifest entry exists with a matching checksum, tag manifests match, and
+// Payload-Oxum (if present) agrees with the payload.
+func Validate(dir string) ([]Problem, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	var problems []Problem
+	add := func(path, format string, args ...interface{}) {
+		problems = append(problems, Problem{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	decl, err := readTags(filepath.Join(dir, "bagit.txt"))
+	if err != nil {
+		add("bagit.txt", "missing or unreadable bag declaration")
+	} else if v := tagValue(decl, "BagIt-Version"); v == "" {
+		add("bagit.txt", "BagIt-Version is missing")
+	}
+
+	manifests, err := filepath.Glob(filepath.Join(dir, "manifest-*.txt"))
+	if err != nil {
+		return nil, err
+	}
+	if len(manifests) == 0 {
+		add(".", "no payload manifest found")
+	}
+
+	payload, err := payloadFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range manifests {
+		name := filepath.Base(path)
+		algo := strings.TrimSuffix(strings.TrimPrefix(name, "manifest-"), ".txt")
+		entries, err := readManifest(path)
+		if err != nil {
+			add(name, "%v", err)
+			continue
+		}
+		for _, p := range payload {
+			if _, ok := entries[p]; !ok {
+				add(p, "not listed in %s", name)
+			}
+		}
+		problems = append(problems, checkEntries(dir, name, algo, entries, true)...)
+	}
+
+	tagManifests, err := filepath.Glob(filepath.Join(dir, "tagmanifest-*.txt"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range tagManifests {
+		name := filepath.Base(path)
+		algo := strings.TrimSuffix(strings.TrimPrefix(name, "tagmanifest-"), ".txt")
+		entries, err := readManifest(path)
+		if err != nil {
+			add(name, "%v", err)
+			continue
+		}
+		problems = append(problems, checkEntries(dir, name, algo, entries, false)...)
+	}
+
+	if tags, err := readTags(filepath.Join(dir, "bag-info.txt")); err == nil {
+		if oxum := tagValue(tags, "Payload-Oxum"); oxum != "" {
+			var octets int64
+			for _, p := range payload {
+				if fi, err := os.Stat(filepath.Join(dir, filepath.FromSlash(p))); err == nil {
+					octets += fi.Size()
+				}
+			}
+			if got := fmt.Sprintf("%d.%d", octets, len(payload)); got != oxum {
+				add("bag-info.txt", "Payload-Oxum is %s but payload is %s", oxum, got)
+			}
+		}
+	}
+
+	return problems, nil
+}
+
+// checkEntries verifies that each manifest entry exists and matches its
+// checksum. Entries outside the bag, or outside data/ in a payload manifest,
+// are reported without being read (RFC 8493 §7.2).
+func checkEntries(dir, manifest, algo string, entries map[string]string, payload bool) []Problem {
+	var problems []Problem
+	paths := make([]string, 0, len(entries))
+	for p := range entries {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	if _, err := newHash(algo); err != nil {
+		return []Problem{{Path: manifest, Message: err.Error()}}
+	}
+
+	for _, p := range paths {
+		if !filepath.IsLocal(filepath.FromSlash(p)) {
+			problems = append(problems, Problem{Path: p, Message: fmt.Sprintf("listed in %s but outside the bag", manifest)})
+			continue
+		}
+		if payload && !strings.HasPrefix(p, PayloadDir+"/") {
+			problems = append(problems, Problem{Path: p, Message: fmt.Sprintf("listed in %s but not under %s/", manifest, PayloadDir)})
+			continue
+		}
+		sum, err := checksum(filepath.Join(dir, filepath.FromSlash(p)), algo)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			problems = append(problems, Problem{Path: p, Message: fmt.Sprintf("listed in %s but missing", manifest)})
+		case err != nil:
+			problems = append(problems, Problem{Path: p, Message: err.Error()})
+		case sum != entries[p]:
+			problems = append(problems, Problem{Path: p, Message: fmt.Sprintf("%s checksum mismatch", algo)})
+		}
+	}
+	return problems
+}
+
+// payloadFiles lists every file under data/ as slash-separated bag paths.
+func payloadFiles(dir string) ([]string, error) {
+	root := filepath.Join(dir, PayloadDir)
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path == root {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	return files, err
+}
+
+// readTags parses a tag file (bagit.txt, bag-info.txt), joining continuation lines.
+func readTags(path string) ([]Tag, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var tags []Tag
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(tags) > 0 {
+			tags[len(tags)-1].Value += "\n" + strings.TrimSpace(line)
+			continue
+		}
+		label, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("%s: malformed line %q", filepath.Base(path), line)
+		}
+		tags = append(tags, Tag{Label: strings.TrimSpace(label), Value: strings.TrimSpace(value)})
+	}
+	return tags, scanner.Err()
+}
+
+// tagValue returns the first value for label, or "".
+func tagValue(tags []Tag, label string) string {
+	for _, t := range tags {
+		if strings.EqualFold(t.Label, label) {
+			return t.Value
+		}
+	}
+	return ""
+}
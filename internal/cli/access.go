@@ -1,22 +1,25 @@
 package cli
 
-// v0.1: Access links commands disabled — read-only release.
-// They will be re-enabled in a future version.
-
-/*
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/curation"
+	"github.com/ran-codes/zenodo-cli/internal/model"
 	"github.com/ran-codes/zenodo-cli/internal/output"
 	"github.com/spf13/cobra"
 )
 
 var accessCmd = &cobra.Command{
 	Use:   "access",
-	Short: "Manage access links",
+	Short: "Share restricted records and manage access",
+	Long: `Share restricted or embargoed records with share links, or with specific
+users and groups, and answer requests for access to them.`,
 }
 
 var accessLinksCmd = &cobra.Command{
@@ -34,23 +37,343 @@ Examples:
   zenodo access links list 12345 --output json`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		id, err := strconv.Atoi(args[0])
+		client := newClient()
+		links, err := client.ListAccessLinks(args[0])
+		if err != nil {
+			return err
+		}
+		fields := appCtx.Fields
+		if fields == "" {
+			fields = "id,permission,expires_at,description,created_at"
+		}
+		return output.Format(os.Stdout, links, appCtx.Output, fields)
+	},
+}
+
+var accessLinksCreateCmd = &cobra.Command{
+	Use:   "create <record-id>",
+	Short: "Create a share link for a record",
+	Long: `Create a share link, e.g. for reviewers of an embargoed dataset. Anyone
+with the link gets its permission:
+
+  view     view the record and its files, even if restricted
+  preview  also preview the record's unpublished drafts
+  edit     also edit the record's drafts
+
+The link's URL is printed to stdout; it is only shown once.
+
+Examples:
+  zenodo access links create 12345 --expires 30d --description "Journal reviewers"
+  zenodo access links create 12345 --permission preview --expires 2026-12-31`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		permission, _ := cmd.Flags().GetString("permission")
+		if !slices.Contains([]string{model.PermissionView, model.PermissionPreview, model.PermissionEdit}, permission) {
+			return fmt.Errorf("invalid --permission %q: must be view, preview or edit", permission)
+		}
+		expires, _ := cmd.Flags().GetString("expires")
+		expiresAt, err := parseExpiry(expires, time.Now())
+		if err != nil {
+			return err
+		}
+		description, _ := cmd.Flags().GetString("description")
+
+		client := newClient()
+		link, err := client.CreateAccessLink(args[0], &model.AccessLink{
+			Permission:  permission,
+			ExpiresAt:   expiresAt,
+			Description: description,
+		})
+		if err != nil {
+			return err
+		}
+		until := "never expires"
+		if expiresAt != "" {
+			until = "expires " + expiresAt
+		}
+		fmt.Fprintf(os.Stderr, "Created %s link %s (%s)\n", link.Permission, link.ID, until)
+		fmt.Println(client.ShareURL(args[0], link.Token))
+		return nil
+	},
+}
+
+var accessLinksDeleteCmd = &cobra.Command{
+	Use:   "delete <record-id> <link-id>...",
+	Short: "Delete share links",
+	Long: `Delete share links. Anyone using them loses access at once.
+
+Examples:
+  zenodo access links delete 12345 0b3f5c2e-8d41-4c8e-a2f4-6e1d9b7a0c55`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := newClient()
+		for _, linkID := range args[1:] {
+			if err := client.DeleteAccessLink(args[0], linkID); err != nil {
+				return fmt.Errorf("deleting link %s: %w", linkID, err)
+			}
+			fmt.Fprintf(os.Stderr, "Link %s deleted.\n", linkID)
+		}
+		return nil
+	},
+}
+
+var accessGrantsCmd = &cobra.Command{
+	Use:   "grants",
+	Short: "Share records with users and groups",
+}
+
+// grantRow is a flattened access grant for table and CSV output.
+type grantRow struct {
+	ID         int    `json:"id"`
+	Type       string `json:"type"`
+	Subject    string `json:"subject"`
+	Permission string `json:"permission"`
+	Origin     string `json:"origin,omitempty"`
+}
+
+func grantRows(grants []model.AccessGrant) []grantRow {
+	rows := make([]grantRow, len(grants))
+	for i, g := range grants {
+		typ := g.Subject.Type
+		if typ == model.GrantRole {
+			typ = "group"
+		}
+		rows[i] = grantRow{ID: g.ID, Type: typ, Subject: g.Subject.ID, Permission: g.Permission, Origin: g.Origin}
+	}
+	return rows
+}
+
+var accessGrantsListCmd = &cobra.Command{
+	Use:   "list <record-id>",
+	Short: "List the users and groups a record is shared with",
+	Long: `List a record's access grants. The grant ID is what access grants remove
+takes.
+
+Examples:
+  zenodo access grants list 12345`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		grants, err := newClient().ListAccessGrants(args[0])
+		if err != nil {
+			return err
+		}
+		fields := appCtx.Fields
+		if fields == "" {
+			fields = "id,type,subject,permission,origin"
+		}
+		return output.Format(os.Stdout, grantRows(grants), appCtx.Output, fields)
+	},
+}
+
+var accessGrantsAddCmd = &cobra.Command{
+	Use:   "add <record-id>",
+	Short: "Share a record with users or groups",
+	Long: `Give users (by user ID) or groups access to a record. Besides view, preview
+and edit, a grant may give manage permission, which also allows managing
+access and publishing.
+
+Examples:
+  zenodo access grants add 12345 --user 4711 --user 4712
+  zenodo access grants add 12345 --group reviewers --permission preview
+  zenodo access grants add 12345 --user 4711 --notify --message "Data for your review"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		permission, _ := cmd.Flags().GetString("permission")
+		if !slices.Contains([]string{model.PermissionView, model.PermissionPreview, model.PermissionEdit, model.PermissionManage}, permission) {
+			return fmt.Errorf("invalid --permission %q: must be view, preview, edit or manage", permission)
+		}
+		users, _ := cmd.Flags().GetStringSlice("user")
+		groups, _ := cmd.Flags().GetStringSlice("group")
+		if len(users) == 0 && len(groups) == 0 {
+			return fmt.Errorf("give at least one --user or --group")
+		}
+		notify, _ := cmd.Flags().GetBool("notify")
+		message, _ := cmd.Flags().GetString("message")
+		if message != "" && !notify {
+			return fmt.Errorf("--message needs --notify")
+		}
+
+		var grants []model.AccessGrant
+		for _, subjects := range []struct {
+			typ string
+			ids []string
+		}{{model.GrantUser, users}, {model.GrantRole, groups}} {
+			for _, id := range subjects.ids {
+				var g model.AccessGrant
+				g.Subject.Type, g.Subject.ID, g.Permission = subjects.typ, id, permission
+				grants = append(grants, g)
+			}
+		}
+
+		created, err := newClient().CreateAccessGrants(args[0], grants, notify, message)
 		if err != nil {
-			return fmt.Errorf("invalid record ID: %s", args[0])
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Record %s shared with %d users and groups (%s).\n", args[0], len(grants), permission)
+		fields := appCtx.Fields
+		if fields == "" {
+			fields = "id,type,subject,permission,origin"
+		}
+		return output.Format(os.Stdout, grantRows(created), appCtx.Output, fields)
+	},
+}
+
+var accessGrantsRemoveCmd = &cobra.Command{
+	Use:   "remove <record-id> <grant-id>...",
+	Short: "Revoke access grants",
+	Long: `Revoke access grants by the IDs access grants list shows. Grants are
+revoked highest ID first, as removing one renumbers those after it.
+
+Examples:
+  zenodo access grants remove 12345 0 2`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var ids []int
+		for _, arg := range args[1:] {
+			id, err := strconv.Atoi(arg)
+			if err != nil {
+				return fmt.Errorf("invalid grant ID: %s", arg)
+			}
+			ids = append(ids, id)
 		}
+		// A repeated ID would revoke the grant renumbered into its place.
+		slices.Sort(ids)
+		ids = slices.Compact(ids)
+		slices.Reverse(ids)
+
+		client := newClient()
+		for _, id := range ids {
+			if err := client.DeleteAccessGrant(args[0], id); err != nil {
+				return fmt.Errorf("revoking grant %d: %w", id, err)
+			}
+			fmt.Fprintf(os.Stderr, "Grant %d revoked.\n", id)
+		}
+		return nil
+	},
+}
+
+var accessRequestsCmd = &cobra.Command{
+	Use:   "requests",
+	Short: "Answer requests for access to your restricted records",
+}
+
+var accessRequestsListCmd = &cobra.Command{
+	Use:   "list [record-id]",
+	Short: "List open access requests",
+	Long: `List the open requests for access to your restricted records, or to one
+record. Users and guests can request access when the record allows it.
 
-		client := api.NewClient(appCtx.BaseURL, appCtx.Token)
-		links, err := client.ListAccessLinks(id)
+Examples:
+  zenodo access requests list
+  zenodo access requests list 12345 --all`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireEndpoint(model.EndpointRequests); err != nil {
+			return err
+		}
+		var recordID string
+		if len(args) > 0 {
+			recordID = args[0]
+		}
+		all, _ := cmd.Flags().GetBool("all")
+		result, err := newClient().ListAccessRequests(recordID, !all)
 		if err != nil {
 			return err
 		}
-		return output.Format(os.Stdout, links, appCtx.Output, appCtx.Fields)
+		matches := make([]curation.Match, len(result.Hits.Hits))
+		for i, req := range result.Hits.Hits {
+			matches[i] = curation.Match{Request: req}
+		}
+		fields := appCtx.Fields
+		if fields == "" {
+			fields = "id,type,status,record,submitter,created"
+		}
+		return output.Format(os.Stdout, requestRows(matches), appCtx.Output, fields)
+	},
+}
+
+var accessRequestsAcceptCmd = &cobra.Command{
+	Use:   "accept <id>...",
+	Short: "Grant access requests",
+	Long: `Accept access requests. A user gets access to the record; a guest is
+emailed a share link.
+
+Examples:
+  zenodo access requests accept 0c2b8e6e-6f1c-4b5e-9d1a-3f7c2a9e8b10`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRequestAction(cmd, args, api.ActionAccept)
 	},
 }
 
+var accessRequestsDeclineCmd = &cobra.Command{
+	Use:   "decline <id>...",
+	Short: "Decline access requests",
+	Long: `Decline access requests, optionally saying why.
+
+Examples:
+  zenodo access requests decline 0c2b8e6e-6f1c-4b5e-9d1a-3f7c2a9e8b10 --message "Data is under embargo"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRequestAction(cmd, args, api.ActionDecline)
+	},
+}
+
+// parseExpiry turns an expiry given as a date (YYYY-MM-DD) or a number of
+// days from now ("30d") into a date. An empty expiry means none.
+func parseExpiry(s string, now time.Time) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	if days, err := parseDays(s); err == nil {
+		return now.AddDate(0, 0, days).Format(time.DateOnly), nil
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return "", fmt.Errorf("invalid expiry %q: use a date (2026-12-31) or a number of days (30d)", s)
+	}
+	if !t.After(now) {
+		return "", fmt.Errorf("expiry %s is not in the future", s)
+	}
+	return s, nil
+}
+
+// parseDays parses a positive number of days, such as "30d".
+func parseDays(s string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+	if err != nil || n <= 0 || !strings.HasSuffix(s, "d") {
+		return 0, fmt.Errorf("invalid number of days %q: use e.g. 30d", s)
+	}
+	return n, nil
+}
+
 func init() {
+	accessLinksCreateCmd.Flags().String("permission", model.PermissionView, "What the link allows: view, preview or edit")
+	accessLinksCreateCmd.Flags().String("expires", "", "Expiry date (YYYY-MM-DD) or days from now (e.g. 30d); default never")
+	accessLinksCreateCmd.Flags().String("description", "", "What the link is for, e.g. who it was sent to")
 	accessLinksCmd.AddCommand(accessLinksListCmd)
+	accessLinksCmd.AddCommand(accessLinksCreateCmd)
+	accessLinksCmd.AddCommand(accessLinksDeleteCmd)
+
+	accessGrantsAddCmd.Flags().StringSlice("user", nil, "User ID to share with (repeatable)")
+	accessGrantsAddCmd.Flags().StringSlice("group", nil, "Group to share with (repeatable)")
+	accessGrantsAddCmd.Flags().String("permission", model.PermissionView, "What the grant allows: view, preview, edit or manage")
+	accessGrantsAddCmd.Flags().Bool("notify", false, "Email the users about the grant")
+	accessGrantsAddCmd.Flags().String("message", "", "Message to include in the notification")
+	accessGrantsCmd.AddCommand(accessGrantsListCmd)
+	accessGrantsCmd.AddCommand(accessGrantsAddCmd)
+	accessGrantsCmd.AddCommand(accessGrantsRemoveCmd)
+
+	accessRequestsListCmd.Flags().Bool("all", false, "Include closed requests")
+	for _, c := range []*cobra.Command{accessRequestsAcceptCmd, accessRequestsDeclineCmd} {
+		c.Flags().String("message", "", "Comment to add with the decision")
+	}
+	accessRequestsCmd.AddCommand(accessRequestsListCmd)
+	accessRequestsCmd.AddCommand(accessRequestsAcceptCmd)
+	accessRequestsCmd.AddCommand(accessRequestsDeclineCmd)
+
 	accessCmd.AddCommand(accessLinksCmd)
+	accessCmd.AddCommand(accessGrantsCmd)
+	accessCmd.AddCommand(accessRequestsCmd)
 	rootCmd.AddCommand(accessCmd)
 }
-*/
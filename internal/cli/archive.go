@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/ran-codes/zenodo-cli/internal/archive"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+	"github.com/ran-codes/zenodo-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var mirrorCmd = &cobra.Command{
+	Use:   "mirror <id> <dir>",
+	Short: "Download a record's files into a local mirror",
+	Long: `Download every file of a published record into a directory and write a
+manifest (` + archive.ManifestName + `) with checksums and metadata.
+
+Re-running mirror on the same directory refreshes it. Use "zenodo verify"
+to check the mirror later.
+
+Examples:
+  zenodo mirror 12345 ./backup/12345
+  zenodo mirror 12345 ./backup/12345 --latest`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid record ID: %s", args[0])
+		}
+		dir := args[1]
+
+		client := newClient()
+		latest, _ := cmd.Flags().GetBool("latest")
+
+		var record *model.Record
+		if latest {
+			record, err = client.GetLatestRecord(id)
+		} else {
+			record, err = client.GetRecord(id)
+		}
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "Mirroring record %d (%d files) to %s\n", record.ID, len(record.Files), dir)
+		manifest, err := archive.Mirror(client, record, dir)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Mirrored %d files\n", len(manifest.Files))
+		return nil
+	},
+}
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify <dir>",
+	Short: "Verify a local mirror against its manifest and Zenodo",
+	Long: `Re-read a mirrored directory's manifest, recompute file checksums, and
+compare them with the current remote record.
+
+Each file is reported as ok, missing, corrupted (local checksum differs),
+changed (remote checksum differs), new (added remotely since the mirror),
+or removed (no longer on the remote record). Metadata changes are reported
+as "(metadata)".
+
+Exits with code 6 if any problem is found, so it can be scheduled in CI.
+
+Examples:
+  zenodo verify ./backup/12345
+  zenodo verify ./backup/12345 --latest
+  zenodo verify ./backup/12345 --offline`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := args[0]
+		offline, _ := cmd.Flags().GetBool("offline")
+		latest, _ := cmd.Flags().GetBool("latest")
+
+		manifest, err := archive.ReadManifest(dir)
+		if err != nil {
+			return err
+		}
+
+		var remote *model.Record
+		if !offline {
+			client := newClient()
+			if latest {
+				remote, err = client.GetLatestRecord(manifest.RecordID)
+			} else {
+				remote, err = client.GetRecord(manifest.RecordID)
+			}
+			if err != nil {
+				return fmt.Errorf("fetching remote record: %w", err)
+			}
+		}
+
+		report, err := archive.Verify(dir, remote)
+		if err != nil {
+			return err
+		}
+
+		if err := output.Format(os.Stdout, report.Findings, appCtx.Output, appCtx.Fields); err != nil {
+			return err
+		}
+
+		if n := report.Problems(); n > 0 {
+			return &CheckFailedError{Msg: fmt.Sprintf("verification failed: %d problem(s) in %s", n, dir)}
+		}
+		fmt.Fprintf(os.Stderr, "Verified %d files in %s\n", len(manifest.Files), dir)
+		return nil
+	},
+}
+
+func init() {
+	mirrorCmd.Flags().Bool("latest", false, "Mirror the latest version of the record")
+
+	verifyCmd.Flags().Bool("offline", false, "Only check local checksums; skip the remote comparison")
+	verifyCmd.Flags().Bool("latest", false, "Compare against the latest version of the record")
+
+	rootCmd.AddCommand(mirrorCmd)
+	rootCmd.AddCommand(verifyCmd)
+}
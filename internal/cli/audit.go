@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/audit"
+	"github.com/ran-codes/zenodo-cli/internal/lint"
+	"github.com/ran-codes/zenodo-cli/internal/listing"
+	"github.com/spf13/cobra"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Lint every record in a community or account and write a quality report",
+	Long: `Run the lint rules over every record in a listing mode and write a report
+with each record's findings and score, and the number of records failing
+each rule.
+
+Records are selected like 'records list':
+  --community <slug>   records in a community
+  --authored           records with your ORCID (or --orcid) as creator or contributor
+  --uploaded           records and drafts uploaded by your account
+
+Each record is scored from 0 to 100: the share of enabled rules it passes,
+weighted by level (error 3, warning 2, info 1). Records are listed worst
+first. Rule levels come from the profile's "lint" section and --rules, as
+for 'zenodo lint'.
+
+Report formats: markdown (default), html, csv. CSV has one row per finding,
+and one row with empty finding columns for each clean record.
+
+Examples:
+  zenodo audit --community my-org --dest audit.md
+  zenodo audit --community my-org --format html --dest audit.html
+  zenodo audit --authored --format csv > audit.csv`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		community, _ := cmd.Flags().GetString("community")
+		authored, _ := cmd.Flags().GetBool("authored")
+		uploaded, _ := cmd.Flags().GetBool("uploaded")
+		orcid, _ := cmd.Flags().GetString("orcid")
+		status, _ := cmd.Flags().GetString("status")
+		format, _ := cmd.Flags().GetString("format")
+		format = strings.ToLower(format)
+		dest, _ := cmd.Flags().GetString("dest")
+
+		modes := 0
+		for _, set := range []bool{community != "", authored, uploaded} {
+			if set {
+				modes++
+			}
+		}
+		if modes != 1 {
+			return fmt.Errorf("choose one of --community <slug>, --authored or --uploaded")
+		}
+		if err := audit.ValidFormat(format); err != nil {
+			return err
+		}
+		cfg, err := lintConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := newClient()
+		var (
+			scope string
+			fetch func(api.RecordListParams) (*listing.Result, error)
+		)
+		switch {
+		case community != "":
+			scope = "community " + community
+			fetch = func(p api.RecordListParams) (*listing.Result, error) {
+				return listing.Community(client, community, p)
+			}
+		case authored:
+			if orcid == "" {
+				orcid = configuredORCID()
+			}
+			if orcid == "" {
+				return fmt.Errorf("ORCID not configured. Run: zenodo config set orcid <your-orcid>, or pass --orcid")
+			}
+			scope = "records authored by " + orcid
+			fetch = func(p api.RecordListParams) (*listing.Result, error) {
+				return listing.Authored(client, orcid, p)
+			}
+		default:
+			scope = "records uploaded by profile " + appCtx.Profile
+			fetch = func(p api.RecordListParams) (*listing.Result, error) {
+				return listing.Uploaded(client, p)
+			}
+		}
+
+		res, err := listing.Collect(api.RecordListParams{Status: status}, fetch, func(fetched, total int) {
+			fmt.Fprintf(os.Stderr, "Fetched %d of %d records\n", fetched, total)
+		})
+		if err != nil {
+			if len(res.Rows) == 0 {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Warning: %v (auditing the %d records fetched)\n", err, len(res.Rows))
+		}
+
+		rep, err := audit.Build(scope, res.Rows, cfg)
+		if err != nil {
+			return err
+		}
+
+		var w io.Writer = os.Stdout
+		if dest != "" {
+			f, err := os.Create(dest)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+		if err := audit.Write(w, rep, format); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "Audited %d records: average score %d, %d error(s), %d warning(s), %d info\n",
+			len(rep.Records), rep.AverageScore,
+			rep.Count(lint.LevelError), rep.Count(lint.LevelWarning), rep.Count(lint.LevelInfo))
+		if dest != "" {
+			fmt.Fprintf(os.Stderr, "Wrote %s\n", dest)
+		}
+		return nil
+	},
+}
+
+func init() {
+	auditCmd.Flags().String("community", "", "Audit the records in this community")
+	auditCmd.Flags().Bool("authored", false, "Audit records where you are a creator or contributor (by ORCID)")
+	auditCmd.Flags().Bool("uploaded", false, "Audit records uploaded by your account")
+	auditCmd.Flags().String("orcid", "", "ORCID for --authored (default: the configured orcid)")
+	auditCmd.Flags().String("status", "", "Filter --uploaded by status: draft, published")
+	auditCmd.Flags().String("format", "markdown", "Report format: "+audit.Formats)
+	auditCmd.Flags().String("dest", "", "Write the report to this file instead of stdout")
+	auditCmd.Flags().String("rules", "", "YAML file of rule levels and thresholds, overriding the profile's")
+	rootCmd.AddCommand(auditCmd)
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ran-codes/zenodo-cli/internal/bagit"
+	"github.com/ran-codes/zenodo-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var bagCmd = &cobra.Command{
+	Use:   "bag",
+	Short: "Work with BagIt packages",
+}
+
+var bagValidateCmd = &cobra.Command{
+	Use:   "validate <dir>",
+	Short: "Validate a BagIt bag",
+	Long: `Check that a directory is a complete and valid BagIt bag: every payload
+file is listed in each manifest, every checksum matches, tag manifests
+match, and Payload-Oxum agrees with the payload.
+
+Exits with code 6 if the bag is invalid.
+
+Examples:
+  zenodo bag validate ./bags/12345`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := args[0]
+		problems, err := bagit.Validate(dir)
+		if err != nil {
+			return err
+		}
+		if len(problems) > 0 {
+			if err := output.Format(os.Stdout, problems, appCtx.Output, appCtx.Fields); err != nil {
+				return err
+			}
+			return &CheckFailedError{Msg: fmt.Sprintf("bag is invalid: %d problem(s) in %s", len(problems), dir)}
+		}
+		fmt.Fprintf(os.Stderr, "Bag at %s is valid\n", dir)
+		return nil
+	},
+}
+
+func init() {
+	bagCmd.AddCommand(bagValidateCmd)
+	rootCmd.AddCommand(bagCmd)
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/crosswalk"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var recordsCatalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "Render a community's records as one catalogue file",
+	Long: `Fetch every record in a community (up to 10k) and render them as a single
+catalogue, for loading into a data catalogue or CKAN harvest.
+
+Formats:
+  schemaorg     schema.org DataCatalog (JSON-LD)
+  dcat          DCAT catalogue in Turtle
+  dcat-jsonld   DCAT catalogue in JSON-LD
+
+Examples:
+  zenodo records catalog --community my-org --format dcat > catalog.ttl
+  zenodo records catalog --community my-org --format schemaorg --dest catalog.jsonld`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		community, _ := cmd.Flags().GetString("community")
+		if community == "" {
+			return fmt.Errorf("--community is required")
+		}
+		format, _ := cmd.Flags().GetString("format")
+		dest, _ := cmd.Flags().GetString("dest")
+		// Check the format before fetching records or truncating dest.
+		write, ok := catalogWriters[format]
+		if !ok {
+			return fmt.Errorf("unsupported catalogue format: %q (supported: schemaorg, dcat, dcat-jsonld)", format)
+		}
+
+		client := newClient()
+		comm, err := client.GetCommunity(community)
+		if err != nil {
+			return fmt.Errorf("fetching community: %w", err)
+		}
+
+		params := api.RecordListParams{Community: community}
+		records, total, err := api.PaginateAll(func(page int) (*model.RecordSearchResult, error) {
+			params.Page = page
+			return client.SearchRecords("", params)
+		})
+		if err != nil {
+			if records == nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		fmt.Fprintf(os.Stderr, "Cataloguing %d of %d records in %s\n", len(records), total, community)
+
+		title := comm.Metadata.Title
+		if title == "" {
+			title = community
+		}
+
+		var w io.Writer = os.Stdout
+		if dest != "" {
+			f, err := os.Create(dest)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+
+		return write(w, title, comm.Links.SelfHTML, records)
+	},
+}
+
+// catalogWriters renders a catalogue of records in each supported format.
+var catalogWriters = map[string]func(w io.Writer, title, url string, records []model.Record) error{
+	"schemaorg": func(w io.Writer, title, url string, records []model.Record) error {
+		return writeJSON(w, crosswalk.ToSchemaOrgCatalog(title, url, records))
+	},
+	"dcat": func(w io.Writer, title, url string, records []model.Record) error {
+		return crosswalk.WriteDCATCatalog(w, title, url, records, crosswalk.DCATTurtle)
+	},
+	"dcat-jsonld": func(w io.Writer, title, url string, records []model.Record) error {
+		return crosswalk.WriteDCATCatalog(w, title, url, records, crosswalk.DCATJSONLD)
+	},
+}
+
+// writeJSON writes v as indented JSON.
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
+
+func init() {
+	recordsCatalogCmd.Flags().String("community", "", "Community slug")
+	recordsCatalogCmd.Flags().String("format", "dcat", "Catalogue format: schemaorg, dcat, dcat-jsonld")
+	recordsCatalogCmd.Flags().String("dest", "", "Write to this file instead of stdout")
+	recordsCmd.AddCommand(recordsCatalogCmd)
+}
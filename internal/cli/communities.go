@@ -1,7 +1,12 @@
 package cli
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"slices"
 
 	"github.com/ran-codes/zenodo-cli/internal/api"
 	"github.com/ran-codes/zenodo-cli/internal/model"
@@ -11,7 +16,7 @@ import (
 
 var communitiesCmd = &cobra.Command{
 	Use:   "communities",
-	Short: "Search and list communities",
+	Short: "Search, inspect and manage communities",
 }
 
 var communitiesListCmd = &cobra.Command{
@@ -23,7 +28,10 @@ Examples:
   zenodo communities list
   zenodo communities list --query "open science"`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client := api.NewClient(appCtx.BaseURL, appCtx.Token)
+		if err := requireEndpoint(model.EndpointCommunities); err != nil {
+			return err
+		}
+		client := newClient()
 		query, _ := cmd.Flags().GetString("query")
 
 		var result *model.CommunitySearchResult
@@ -44,8 +52,301 @@ Examples:
 	},
 }
 
+var communitiesGetCmd = &cobra.Command{
+	Use:   "get <slug>",
+	Short: "Get a community by slug or ID",
+	Long: `Show a community's full metadata, access policies and links.
+
+Examples:
+  zenodo communities get my-org
+  zenodo communities get my-org --output json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireEndpoint(model.EndpointCommunities); err != nil {
+			return err
+		}
+		community, err := newClient().GetCommunity(args[0])
+		if err != nil {
+			return err
+		}
+		return output.Format(os.Stdout, community, appCtx.Output, appCtx.Fields)
+	},
+}
+
+// memberRow is a flattened community member for table and CSV output.
+type memberRow struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Role        string `json:"role"`
+	Visible     bool   `json:"visible"`
+	Description string `json:"description,omitempty"`
+}
+
+var communitiesMembersCmd = &cobra.Command{
+	Use:   "members <slug>",
+	Short: "List a community's members and their roles",
+	Long: `List the members of a community with their roles (owner, manager, curator
+or reader). Members only see the full list; anyone else sees the members
+who made their membership public.
+
+Examples:
+  zenodo communities members my-org
+  zenodo communities members my-org --role curator`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireEndpoint(model.EndpointCommunities); err != nil {
+			return err
+		}
+		role, _ := cmd.Flags().GetString("role")
+		roles := []string{model.RoleOwner, model.RoleManager, model.RoleCurator, model.RoleReader}
+		if role != "" && !slices.Contains(roles, role) {
+			return fmt.Errorf("invalid --role %q: must be one of owner, manager, curator, reader", role)
+		}
+
+		client := newClient()
+		community, err := client.GetCommunity(args[0])
+		if err != nil {
+			return err
+		}
+		members, err := listMembers(client, community.ID, false)
+		var apiErr *model.APIError
+		if errors.As(err, &apiErr) && (apiErr.Status == http.StatusUnauthorized || apiErr.Status == http.StatusForbidden) {
+			fmt.Fprintln(os.Stderr, "Not a member of this community; showing public members only.")
+			members, err = listMembers(client, community.ID, true)
+		}
+		if err != nil {
+			return err
+		}
+
+		rows := []memberRow{}
+		for _, m := range members {
+			if role != "" && m.Role != role {
+				continue
+			}
+			rows = append(rows, memberRow{
+				Name:        m.Member.Name,
+				Type:        m.Member.Type,
+				Role:        m.Role,
+				Visible:     m.Visible,
+				Description: m.Member.Description,
+			})
+		}
+		fields := appCtx.Fields
+		if fields == "" {
+			fields = "name,type,role,visible,description"
+		}
+		return output.Format(os.Stdout, rows, appCtx.Output, fields)
+	},
+}
+
+// listMembers fetches every page of a community's members.
+func listMembers(client *api.Client, communityID string, public bool) ([]model.CommunityMember, error) {
+	const size = 100
+	var members []model.CommunityMember
+	for page := 1; ; page++ {
+		res, err := client.ListCommunityMembers(communityID, public, page, size)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, res.Hits.Hits...)
+		if len(res.Hits.Hits) < size || len(members) >= res.Hits.Total {
+			return members, nil
+		}
+	}
+}
+
+var communitiesRecordsCmd = &cobra.Command{
+	Use:   "records <slug> [query]",
+	Short: "Search a community's records",
+	Long: `Search the published records in a community, with the same Elasticsearch
+query syntax as records search. Totals of views and downloads over the
+listed records are printed to stderr.
+
+Examples:
+  zenodo communities records my-org
+  zenodo communities records my-org "climate AND publication_date:[2024 TO *]"
+  zenodo communities records my-org --sort mostviewed --all`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireEndpoint(model.EndpointCommunities); err != nil {
+			return err
+		}
+		client := newClient()
+		var query string
+		if len(args) > 1 {
+			query = args[1]
+		}
+		sort, _ := cmd.Flags().GetString("sort")
+		all, _ := cmd.Flags().GetBool("all")
+		params := api.RecordListParams{Community: args[0], Sort: sort}
+
+		fields := appCtx.Fields
+		if fields == "" {
+			fields = "id,title,links.doi,stats.version_views,stats.version_downloads,created"
+		}
+
+		var records []model.Record
+		var total int
+		if all {
+			var err error
+			records, total, err = api.PaginateAll(func(page int) (*model.RecordSearchResult, error) {
+				params.Page = page
+				return client.SearchRecords(query, params)
+			})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		} else {
+			result, err := client.SearchRecords(query, params)
+			if err != nil {
+				return err
+			}
+			records, total = result.Hits.Hits, result.Hits.Total
+		}
+
+		var views, downloads int
+		for _, r := range records {
+			views += r.Stats.Views
+			downloads += r.Stats.Downloads
+		}
+		fmt.Fprintf(os.Stderr, "Showing %d of %d records (%d views, %d downloads)\n", len(records), total, views, downloads)
+		return output.Format(os.Stdout, records, appCtx.Output, fields)
+	},
+}
+
+var communitiesUpdateCmd = &cobra.Command{
+	Use:   "update <slug>",
+	Short: "Update a community's metadata",
+	Long: `Update community metadata using GET-merge-PUT. Fetches the community, merges
+your changes, shows a diff, and asks for confirmation before PUT. You need
+to be an owner or manager of the community.
+
+Changes can come from:
+  --title, --description, --curation-policy, --website  Inline field flags
+  --file metadata.json  JSON file with partial community metadata
+
+Examples:
+  zenodo communities update my-org --title "My Organization"
+  zenodo communities update my-org --curation-policy "$(cat policy.html)"
+  zenodo communities update my-org --website https://example.org --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireEndpoint(model.EndpointCommunities); err != nil {
+			return err
+		}
+		client := newClient()
+
+		// 1. GET the current community.
+		community, err := client.GetCommunity(args[0])
+		if err != nil {
+			return fmt.Errorf("fetching community: %w", err)
+		}
+
+		// 2. Build merged metadata.
+		merged := community.Metadata
+		if err := applyCommunityChanges(cmd, &merged); err != nil {
+			return err
+		}
+
+		// 3. Validate.
+		if merged.Title == "" {
+			fmt.Fprintln(os.Stderr, "Validation errors:")
+			fmt.Fprintln(os.Stderr, "  - title is required")
+			return fmt.Errorf("metadata validation failed")
+		}
+		if merged.Website != "" {
+			if u, err := url.Parse(merged.Website); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				fmt.Fprintln(os.Stderr, "Validation errors:")
+				fmt.Fprintf(os.Stderr, "  - website %q is not an http(s) URL\n", merged.Website)
+				return fmt.Errorf("metadata validation failed")
+			}
+		}
+
+		// 4. Show diff.
+		changed, err := output.DiffMetadata(os.Stderr, community.Metadata, merged)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			return nil
+		}
+
+		// 5. Dry run — stop here.
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if dryRun {
+			fmt.Fprintln(os.Stderr, "Dry run — no changes applied.")
+			return nil
+		}
+
+		// 6. Confirm.
+		yes, _ := cmd.Flags().GetBool("yes")
+		if !yes {
+			if !confirm("Apply these changes?") {
+				fmt.Fprintln(os.Stderr, "Cancelled.")
+				os.Exit(5)
+			}
+		}
+
+		// 7. PUT the community with the merged metadata.
+		updated := *community
+		updated.Metadata = merged
+		result, err := client.UpdateCommunity(community.ID, &updated)
+		if err != nil {
+			return fmt.Errorf("updating community: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Community %s updated.\n", result.Slug)
+		return nil
+	},
+}
+
+// applyCommunityChanges applies the update flags to community metadata.
+func applyCommunityChanges(cmd *cobra.Command, m *model.CommunityMetadata) error {
+	if title, _ := cmd.Flags().GetString("title"); title != "" {
+		m.Title = title
+	}
+	if desc, _ := cmd.Flags().GetString("description"); desc != "" {
+		m.Description = desc
+	}
+	if policy, _ := cmd.Flags().GetString("curation-policy"); policy != "" {
+		m.CurationPolicy = policy
+	}
+	if website, _ := cmd.Flags().GetString("website"); website != "" {
+		m.Website = website
+	}
+
+	filePath, _ := cmd.Flags().GetString("file")
+	if filePath != "" {
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return fmt.Errorf("reading metadata file: %w", err)
+		}
+		if err := m.MergeJSON(data); err != nil {
+			return fmt.Errorf("parsing metadata file: %w", err)
+		}
+	}
+	return nil
+}
+
 func init() {
 	communitiesListCmd.Flags().String("query", "", "Search all public communities")
 	communitiesCmd.AddCommand(communitiesListCmd)
+
+	communitiesCmd.AddCommand(communitiesGetCmd)
+
+	communitiesMembersCmd.Flags().String("role", "", "Only list members with this role: owner, manager, curator, reader")
+	communitiesCmd.AddCommand(communitiesMembersCmd)
+
+	communitiesRecordsCmd.Flags().String("sort", "", "Sort order, e.g. newest, oldest, mostviewed, mostdownloaded, bestmatch")
+	communitiesRecordsCmd.Flags().Bool("all", false, "Fetch all pages (up to 10k results)")
+	communitiesCmd.AddCommand(communitiesRecordsCmd)
+
+	communitiesUpdateCmd.Flags().String("title", "", "New community title")
+	communitiesUpdateCmd.Flags().String("description", "", "New short description")
+	communitiesUpdateCmd.Flags().String("curation-policy", "", "New curation policy (HTML)")
+	communitiesUpdateCmd.Flags().String("website", "", "New website URL")
+	communitiesUpdateCmd.Flags().String("file", "", "JSON file with partial community metadata")
+	communitiesUpdateCmd.Flags().Bool("dry-run", false, "Show diff without applying changes")
+	communitiesUpdateCmd.Flags().Bool("yes", false, "Skip confirmation prompt")
+	communitiesCmd.AddCommand(communitiesUpdateCmd)
 	rootCmd.AddCommand(communitiesCmd)
 }
@@ -3,8 +3,14 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
+	"github.com/ran-codes/zenodo-cli/internal/api"
 	"github.com/ran-codes/zenodo-cli/internal/config"
+	"github.com/ran-codes/zenodo-cli/internal/deposit"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+	"github.com/ran-codes/zenodo-cli/internal/vocab"
 	"github.com/spf13/cobra"
 )
 
@@ -24,7 +30,8 @@ active profile. If the keyring is unavailable, it falls back to the config file.
 Examples:
   zenodo config set token <your-api-token>
   zenodo config set default_profile sandbox
-  zenodo config set profiles.production.base_url https://zenodo.org/api`,
+  zenodo config set profiles.production.base_url https://zenodo.org/api
+  zenodo config set profiles.myinst.mode rdm`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		key, value := args[0], args[1]
@@ -238,6 +245,9 @@ Examples:
 				marker = "* "
 			}
 			baseURL := cfg.ProfileBaseURL(name)
+			if mode := cfg.GetProfileValue(name, "mode"); mode != "" {
+				baseURL += ", mode: " + mode
+			}
 			fmt.Printf("%s%s (%s)\n", marker, name, baseURL)
 		}
 		return nil
@@ -283,6 +293,100 @@ Examples:
 	},
 }
 
+var configAddInstanceCmd = &cobra.Command{
+	Use:   "add-instance <name> <url>",
+	Short: "Add a profile for an InvenioRDM instance and probe what it supports",
+	Long: `Add a profile for a Zenodo or other InvenioRDM instance, or re-probe an
+existing one. The instance is probed (anonymously) for the endpoints and
+vocabularies it serves and for its rate limits, and the result is stored in
+the profile so that commands adapt to it:
+
+  - requests are rate limited to the instance's limits, not Zenodo's
+  - deposit commands use the InvenioRDM draft API if the instance has no
+    legacy deposit API, unless the profile sets a mode
+  - validation only fetches the vocabularies the instance serves
+  - harvest, licenses search and communities list fail early if the
+    instance lacks their endpoint
+
+The URL may be the site or its API root; /api is tried if the site does
+not answer. --sandbox always means Zenodo's sandbox and ignores the probe.
+
+Examples:
+  zenodo config add-instance myinst https://data.example.org
+  zenodo config add-instance myinst https://data.example.org/api --mode rdm
+  zenodo config add-instance production https://zenodo.org/api`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, rawURL := args[0], strings.TrimSuffix(args[1], "/")
+		modeFlag, _ := cmd.Flags().GetString("mode")
+		if modeFlag != "" {
+			if _, err := deposit.ParseMode(modeFlag); err != nil {
+				return err
+			}
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+
+		kinds := make([]string, len(vocab.Kinds))
+		for i, k := range vocab.Kinds {
+			kinds[i] = string(k)
+		}
+		baseURL := rawURL
+		caps, err := api.NewClient(baseURL, "").Probe(kinds)
+		if err != nil && !strings.HasSuffix(rawURL, "/api") {
+			baseURL = rawURL + "/api"
+			if withAPI, apiErr := api.NewClient(baseURL, "").Probe(kinds); apiErr == nil {
+				caps, err = withAPI, nil
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("probing %s: %w", rawURL, err)
+		}
+
+		cfg.SetProfileValue(name, "base_url", baseURL)
+		if modeFlag != "" {
+			cfg.SetProfileValue(name, "mode", strings.ToLower(modeFlag))
+		}
+		if err := cfg.SetProfileCapabilities(name, caps); err != nil {
+			return err
+		}
+		if err := cfg.Save(); err != nil {
+			return err
+		}
+
+		mode := cfg.GetProfileValue(name, "mode")
+		switch {
+		case mode != "":
+		case caps.Has(model.EndpointDeposit):
+			mode = "deposit"
+		default:
+			mode = "rdm (no deposit API)"
+		}
+		limits := "unknown, using Zenodo's"
+		if caps.RateLimit > 0 || caps.SearchRateLimit > 0 {
+			limits = fmt.Sprintf("%s/min, search %s/min", formatLimit(caps.RateLimit), formatLimit(caps.SearchRateLimit))
+		}
+		fmt.Fprintf(os.Stderr, "Profile %q: %s\n", name, baseURL)
+		fmt.Fprintf(os.Stderr, "  endpoints:    %s\n", strings.Join(caps.Endpoints, ", "))
+		fmt.Fprintf(os.Stderr, "  vocabularies: %s\n", formatValue(strings.Join(caps.Vocabularies, ", ")))
+		fmt.Fprintf(os.Stderr, "  rate limits:  %s\n", limits)
+		fmt.Fprintf(os.Stderr, "  write API:    %s\n", mode)
+		fmt.Fprintf(os.Stderr, "Use it with --profile %s, or: zenodo config use %s\n", name, name)
+		return nil
+	},
+}
+
+// formatLimit formats a probed requests-per-minute limit.
+func formatLimit(n int) string {
+	if n == 0 {
+		return "?"
+	}
+	return strconv.Itoa(n)
+}
+
 func init() {
 	configCmd.AddCommand(configSetCmd)
 	configCmd.AddCommand(configGetCmd)
@@ -290,5 +394,7 @@ func init() {
 	configCmd.AddCommand(configListCmd)
 	configCmd.AddCommand(configProfilesCmd)
 	configCmd.AddCommand(configUseCmd)
+	configAddInstanceCmd.Flags().String("mode", "", "Write API for the profile: deposit or rdm (default: chosen from the probe)")
+	configCmd.AddCommand(configAddInstanceCmd)
 	rootCmd.AddCommand(configCmd)
 }
@@ -1,6 +1,12 @@
 package cli
 
-import "github.com/ran-codes/zenodo-cli/internal/config"
+import (
+	"fmt"
+
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/config"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
 
 // AppContext holds resolved runtime state shared across all subcommands.
 type AppContext struct {
@@ -9,10 +15,34 @@ type AppContext struct {
 	Profile string
 	Token   string
 	BaseURL string
-	Output  string
-	Fields  string
-	Verbose bool
+	Mode    string
+	// Capabilities are the profile's probed instance capabilities, or nil
+	// if it was never probed (or --sandbox points elsewhere).
+	Capabilities *model.Capabilities
+	Output       string
+	Fields       string
+	Verbose      bool
 }
 
 // appCtx is the global resolved context, populated by PersistentPreRunE.
 var appCtx AppContext
+
+// newClient returns an API client for the resolved profile, adapted to its
+// instance's capabilities.
+func newClient() *api.Client {
+	client := api.NewClient(appCtx.BaseURL, appCtx.Token)
+	if appCtx.Capabilities != nil {
+		client.UseCapabilities(appCtx.Capabilities)
+	}
+	return client
+}
+
+// requireEndpoint fails if the profile's instance was probed and does not
+// serve endpoint, rather than letting the request 404.
+func requireEndpoint(endpoint string) error {
+	if appCtx.Capabilities.Has(endpoint) {
+		return nil
+	}
+	return fmt.Errorf("the instance at %s does not serve the %s endpoint (probed %s; re-probe with `zenodo config add-instance %s %s`)",
+		appCtx.BaseURL, endpoint, appCtx.Capabilities.Probed, appCtx.Profile, appCtx.BaseURL)
+}
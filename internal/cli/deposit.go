@@ -1,22 +1,19 @@
 package cli
 
-// v0.1: Deposit write commands disabled — read-only release.
-// All deposit subcommands (edit, update, discard, publish) are write operations.
-// They will be re-enabled in a future version.
-
-/*
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
-	"strconv"
+	"path/filepath"
 	"strings"
 
 	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/crosswalk"
+	"github.com/ran-codes/zenodo-cli/internal/deposit"
 	"github.com/ran-codes/zenodo-cli/internal/model"
 	"github.com/ran-codes/zenodo-cli/internal/output"
+	"github.com/ran-codes/zenodo-cli/internal/safepath"
 	"github.com/ran-codes/zenodo-cli/internal/validate"
 	"github.com/spf13/cobra"
 )
@@ -24,6 +21,161 @@ import (
 var depositCmd = &cobra.Command{
 	Use:   "deposit",
 	Short: "Edit and manage depositions",
+	Long: `Create, edit and publish depositions.
+
+By default these commands use Zenodo's deposit API. For a profile with
+"mode: rdm" they use the InvenioRDM draft API (/records/{id}/draft)
+instead, which self-hosted InvenioRDM instances require:
+
+  zenodo config set profiles.myinst.mode rdm
+
+Metadata is given in the same (deposit) schema in either mode.`,
+}
+
+// depositMode returns the write mode of the profile.
+func depositMode() (deposit.Mode, error) {
+	mode, err := deposit.ResolveMode(appCtx.Mode, appCtx.Capabilities)
+	if err != nil {
+		return "", fmt.Errorf("profile %q: %w", appCtx.Profile, err)
+	}
+	return mode, nil
+}
+
+// depositBackend returns the write backend for the profile's mode.
+func depositBackend(client *api.Client) (deposit.Backend, error) {
+	mode, err := depositMode()
+	if err != nil {
+		return nil, err
+	}
+	endpoint := model.EndpointDeposit
+	if mode == deposit.ModeRDM {
+		endpoint = model.EndpointDrafts
+	}
+	if err := requireEndpoint(endpoint); err != nil {
+		return nil, err
+	}
+	return deposit.New(client, mode), nil
+}
+
+var depositCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new deposition from an RO-Crate",
+	Long: `Create a new draft deposition from an RO-Crate metadata file. Creators
+(with ORCID and affiliation), license, keywords, related identifiers and
+the crate's data files are mapped to Zenodo metadata; the files are
+uploaded from the crate directory.
+
+The draft is left unpublished unless --publish is given.
+
+Examples:
+  zenodo deposit create --from ./crate/ro-crate-metadata.json
+  zenodo deposit create --from ./crate/ro-crate-metadata.json --dry-run
+  zenodo deposit create --from ./crate/ro-crate-metadata.json --publish --yes`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, _ := cmd.Flags().GetString("from")
+		if from == "" {
+			return fmt.Errorf("--from is required")
+		}
+
+		data, err := os.ReadFile(from)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", from, err)
+		}
+		metadata, files, err := crosswalk.FromROCrate(data)
+		if err != nil {
+			return err
+		}
+
+		client := newClient()
+		backend, err := depositBackend(client)
+		if err != nil {
+			return err
+		}
+
+		// Check everything locally before creating anything remotely.
+		useVocabularies(client)
+		validate.FillSchemes(&metadata)
+		if errs := validate.Metadata(metadata); len(errs) > 0 {
+			fmt.Fprintln(os.Stderr, "Validation errors:")
+			for _, e := range errs {
+				fmt.Fprintf(os.Stderr, "  - %s\n", e)
+			}
+			return fmt.Errorf("metadata validation failed")
+		}
+		crateDir := filepath.Dir(from)
+		for _, f := range files {
+			path, err := safepath.Join(crateDir, f)
+			if err == nil {
+				_, err = os.Stat(path)
+			}
+			if err != nil {
+				return fmt.Errorf("crate file %s: %w", f, err)
+			}
+		}
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if dryRun {
+			fmt.Fprintf(os.Stderr, "Dry run — would create %q with %d files.\n", metadata.Title, len(files))
+			return output.Format(os.Stdout, metadata, appCtx.Output, appCtx.Fields)
+		}
+
+		dep, err := backend.Create(metadata)
+		if err != nil {
+			return fmt.Errorf("creating deposition: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Created deposition %s\n", dep.ID)
+
+		for _, f := range files {
+			if err := uploadCrateFile(backend, dep, crateDir, f); err != nil {
+				return fmt.Errorf("uploading %s to deposition %s: %w", f, dep.ID, err)
+			}
+			fmt.Fprintf(os.Stderr, "Uploaded %s\n", f)
+		}
+
+		publish, _ := cmd.Flags().GetBool("publish")
+		if !publish {
+			fmt.Fprintf(os.Stderr, "Draft ready. Publish with: zenodo deposit publish %s\n", dep.ID)
+			return nil
+		}
+
+		yes, _ := cmd.Flags().GetBool("yes")
+		if !yes {
+			if !confirm("Publish this deposition?") {
+				fmt.Fprintln(os.Stderr, "Cancelled.")
+				os.Exit(5)
+			}
+		}
+		result, err := backend.Publish(dep.ID)
+		if err != nil {
+			return err
+		}
+		reportPublished(result)
+		return nil
+	},
+}
+
+// reportPublished prints the outcome of publishing a deposition.
+func reportPublished(d *deposit.Draft) {
+	fmt.Fprintf(os.Stderr, "Deposition %s published (state: %s)\n", d.ID, d.State)
+	if d.DOI != "" {
+		fmt.Fprintf(os.Stderr, "DOI: %s\n", d.DOI)
+	}
+}
+
+// uploadCrateFile uploads a crate-relative file. Zenodo buckets are flat,
+// so nested paths are uploaded under their slash-separated path.
+func uploadCrateFile(backend deposit.Backend, dep *deposit.Draft, crateDir, name string) error {
+	path, err := safepath.Join(crateDir, name)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return backend.Upload(dep, name, f)
 }
 
 var depositEditCmd = &cobra.Command{
@@ -35,17 +187,15 @@ Examples:
   zenodo deposit edit 12345`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		id, err := strconv.Atoi(args[0])
+		backend, err := depositBackend(newClient())
 		if err != nil {
-			return fmt.Errorf("invalid deposition ID: %s", args[0])
+			return err
 		}
-
-		client := api.NewClient(appCtx.BaseURL, appCtx.Token)
-		dep, err := client.EditDeposition(id)
+		dep, err := backend.Edit(args[0])
 		if err != nil {
 			return err
 		}
-		fmt.Fprintf(os.Stderr, "Deposition %d unlocked for editing (state: %s)\n", dep.ID, dep.State)
+		fmt.Fprintf(os.Stderr, "Deposition %s unlocked for editing (state: %s)\n", dep.ID, dep.State)
 		return nil
 	},
 }
@@ -68,15 +218,14 @@ Examples:
   zenodo deposit update 12345 --title "New Title" --dry-run`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		id, err := strconv.Atoi(args[0])
+		client := newClient()
+		backend, err := depositBackend(client)
 		if err != nil {
-			return fmt.Errorf("invalid deposition ID: %s", args[0])
+			return err
 		}
 
-		client := api.NewClient(appCtx.BaseURL, appCtx.Token)
-
 		// 1. GET current metadata.
-		dep, err := client.GetDeposition(id)
+		dep, err := backend.Get(args[0])
 		if err != nil {
 			return fmt.Errorf("fetching deposition: %w", err)
 		}
@@ -87,7 +236,9 @@ Examples:
 			return err
 		}
 
-		// 3. Validate.
+		// 3. Fill in detectable related-identifier schemes, then validate.
+		useVocabularies(client)
+		validate.FillSchemes(&merged)
 		if errs := validate.Metadata(merged); len(errs) > 0 {
 			fmt.Fprintln(os.Stderr, "Validation errors:")
 			for _, e := range errs {
@@ -122,11 +273,11 @@ Examples:
 		}
 
 		// 7. PUT merged metadata.
-		result, err := client.UpdateDeposition(id, merged)
+		result, err := backend.Update(dep, merged)
 		if err != nil {
 			return fmt.Errorf("updating deposition: %w", err)
 		}
-		fmt.Fprintf(os.Stderr, "Deposition %d metadata updated.\n", result.ID)
+		fmt.Fprintf(os.Stderr, "Deposition %s metadata updated.\n", result.ID)
 		return nil
 	},
 }
@@ -140,17 +291,14 @@ Examples:
   zenodo deposit discard 12345`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		id, err := strconv.Atoi(args[0])
+		backend, err := depositBackend(newClient())
 		if err != nil {
-			return fmt.Errorf("invalid deposition ID: %s", args[0])
+			return err
 		}
-
-		client := api.NewClient(appCtx.BaseURL, appCtx.Token)
-		dep, err := client.DiscardDeposition(id)
-		if err != nil {
+		if err := backend.Discard(args[0]); err != nil {
 			return err
 		}
-		fmt.Fprintf(os.Stderr, "Changes discarded on deposition %d (state: %s)\n", dep.ID, dep.State)
+		fmt.Fprintf(os.Stderr, "Changes discarded on deposition %s\n", args[0])
 		return nil
 	},
 }
@@ -166,20 +314,18 @@ Examples:
   zenodo deposit publish 12345 --yes`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		id, err := strconv.Atoi(args[0])
+		backend, err := depositBackend(newClient())
 		if err != nil {
-			return fmt.Errorf("invalid deposition ID: %s", args[0])
+			return err
 		}
 
-		client := api.NewClient(appCtx.BaseURL, appCtx.Token)
-
 		// Get current state to show info.
-		dep, err := client.GetDeposition(id)
+		dep, err := backend.Get(args[0])
 		if err != nil {
 			return err
 		}
 
-		fmt.Fprintf(os.Stderr, "Publishing deposition %d: %s\n", dep.ID, dep.Metadata.Title)
+		fmt.Fprintf(os.Stderr, "Publishing deposition %s: %s\n", dep.ID, dep.Metadata.Title)
 
 		// Confirm.
 		yes, _ := cmd.Flags().GetBool("yes")
@@ -190,19 +336,45 @@ Examples:
 			}
 		}
 
-		result, err := client.PublishDeposition(id)
+		result, err := backend.Publish(dep.ID)
 		if err != nil {
 			return err
 		}
-		fmt.Fprintf(os.Stderr, "Deposition %d published (state: %s)\n", result.ID, result.State)
-		if result.DOI != "" {
-			fmt.Fprintf(os.Stderr, "DOI: %s\n", result.DOI)
+		reportPublished(result)
+		return nil
+	},
+}
+
+var depositNewVersionCmd = &cobra.Command{
+	Use:   "new-version <id>",
+	Short: "Create a draft of a new version of a published record",
+	Long: `Create a draft of a new version of a published record, with a copy of its
+metadata. Edit it with "deposit update" and publish it with "deposit publish".
+
+Examples:
+  zenodo deposit new-version 12345`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		backend, err := depositBackend(newClient())
+		if err != nil {
+			return err
 		}
+		draft, err := backend.NewVersion(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Created new version draft %s of %s. Publish with: zenodo deposit publish %s\n", draft.ID, args[0], draft.ID)
 		return nil
 	},
 }
 
 func init() {
+	// deposit create flags
+	depositCreateCmd.Flags().String("from", "", "RO-Crate metadata file (ro-crate-metadata.json)")
+	depositCreateCmd.Flags().Bool("dry-run", false, "Show the mapped metadata without creating anything")
+	depositCreateCmd.Flags().Bool("publish", false, "Publish the deposition after uploading files")
+	depositCreateCmd.Flags().Bool("yes", false, "Skip confirmation prompt")
+
 	// deposit update flags
 	depositUpdateCmd.Flags().String("title", "", "Set title")
 	depositUpdateCmd.Flags().String("description", "", "Set description")
@@ -214,10 +386,12 @@ func init() {
 	// deposit publish flags
 	depositPublishCmd.Flags().Bool("yes", false, "Skip confirmation prompt")
 
+	depositCmd.AddCommand(depositCreateCmd)
 	depositCmd.AddCommand(depositEditCmd)
 	depositCmd.AddCommand(depositUpdateCmd)
 	depositCmd.AddCommand(depositDiscardCmd)
 	depositCmd.AddCommand(depositPublishCmd)
+	depositCmd.AddCommand(depositNewVersionCmd)
 	rootCmd.AddCommand(depositCmd)
 }
 
@@ -238,7 +412,7 @@ func applyChanges(cmd *cobra.Command, m *model.Metadata) error {
 		if err != nil {
 			return fmt.Errorf("reading metadata file: %w", err)
 		}
-		if err := mergeJSON(m, data); err != nil {
+		if err := m.MergeJSON(data); err != nil {
 			return fmt.Errorf("parsing metadata file: %w", err)
 		}
 	}
@@ -250,7 +424,7 @@ func applyChanges(cmd *cobra.Command, m *model.Metadata) error {
 		if err != nil {
 			return fmt.Errorf("reading stdin: %w", err)
 		}
-		if err := mergeJSON(m, data); err != nil {
+		if err := m.MergeJSON(data); err != nil {
 			return fmt.Errorf("parsing stdin metadata: %w", err)
 		}
 	}
@@ -258,37 +432,6 @@ func applyChanges(cmd *cobra.Command, m *model.Metadata) error {
 	return nil
 }
 
-// mergeJSON unmarshals JSON into the metadata, overwriting only specified fields.
-func mergeJSON(m *model.Metadata, data []byte) error {
-	// Marshal current state, merge with incoming, unmarshal back.
-	current, err := json.Marshal(m)
-	if err != nil {
-		return err
-	}
-
-	var base map[string]interface{}
-	if err := json.Unmarshal(current, &base); err != nil {
-		return err
-	}
-
-	var overlay map[string]interface{}
-	if err := json.Unmarshal(data, &overlay); err != nil {
-		return err
-	}
-
-	// Overlay wins for any key it specifies.
-	for k, v := range overlay {
-		base[k] = v
-	}
-
-	merged, err := json.Marshal(base)
-	if err != nil {
-		return err
-	}
-
-	return json.Unmarshal(merged, m)
-}
-
 // confirm prompts the user for y/n confirmation.
 func confirm(prompt string) bool {
 	fmt.Fprintf(os.Stderr, "%s [y/N] ", prompt)
@@ -297,4 +440,3 @@ func confirm(prompt string) bool {
 	answer = strings.TrimSpace(strings.ToLower(answer))
 	return answer == "y" || answer == "yes"
 }
-*/
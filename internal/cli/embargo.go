@@ -0,0 +1,249 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/deposit"
+	"github.com/ran-codes/zenodo-cli/internal/embargo"
+	"github.com/ran-codes/zenodo-cli/internal/listing"
+	"github.com/ran-codes/zenodo-cli/internal/output"
+	"github.com/ran-codes/zenodo-cli/internal/validate"
+	"github.com/spf13/cobra"
+)
+
+var embargoCmd = &cobra.Command{
+	Use:   "embargo",
+	Short: "Monitor and extend embargoes",
+}
+
+var embargoListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List embargoed and restricted records",
+	Long: `List your embargoed and restricted records with their embargo dates and
+the days remaining, soonest first.
+
+Records (default: those uploaded by your account):
+  --uploaded           records and drafts uploaded by your account
+  --authored           records with your ORCID (or --orcid) as creator or contributor
+  --community <slug>   records in a community
+
+--within keeps the embargoes that lift in the next so many days, or have
+already passed. With --fail the command exits with code 6 if there are
+any, so a scheduled CI job can alert before data becomes public.
+
+Examples:
+  zenodo embargo list
+  zenodo embargo list --within 30d
+  zenodo embargo list --community my-org --within 90d --fail --output csv`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		community, _ := cmd.Flags().GetString("community")
+		authored, _ := cmd.Flags().GetBool("authored")
+		uploaded, _ := cmd.Flags().GetBool("uploaded")
+		orcid, _ := cmd.Flags().GetString("orcid")
+		within, _ := cmd.Flags().GetString("within")
+		fail, _ := cmd.Flags().GetBool("fail")
+
+		modes := 0
+		for _, set := range []bool{community != "", authored, uploaded} {
+			if set {
+				modes++
+			}
+		}
+		if modes > 1 {
+			return fmt.Errorf("choose one of --community <slug>, --authored or --uploaded")
+		}
+		days := -1
+		if within != "" {
+			var err error
+			if days, err = parseDays(within); err != nil {
+				return fmt.Errorf("invalid --within: %w", err)
+			}
+		} else if fail {
+			return fmt.Errorf("--fail needs --within")
+		}
+
+		client := newClient()
+		var fetch func(api.RecordListParams) (*listing.Result, error)
+		switch {
+		case community != "":
+			fetch = func(p api.RecordListParams) (*listing.Result, error) {
+				return listing.Community(client, community, p)
+			}
+		case authored:
+			if orcid == "" {
+				orcid = configuredORCID()
+			}
+			if orcid == "" {
+				return fmt.Errorf("ORCID not configured. Run: zenodo config set orcid <your-orcid>, or pass --orcid")
+			}
+			fetch = func(p api.RecordListParams) (*listing.Result, error) {
+				return listing.Authored(client, orcid, p)
+			}
+		default:
+			fetch = func(p api.RecordListParams) (*listing.Result, error) {
+				return listing.Uploaded(client, p)
+			}
+		}
+
+		res, err := listing.Collect(api.RecordListParams{}, fetch, nil)
+		if err != nil {
+			if len(res.Rows) == 0 {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Warning: %v (checking the %d records fetched)\n", err, len(res.Rows))
+		}
+		entries, err := embargo.Find(res.Rows, time.Now(), func(id int, err error) {
+			fmt.Fprintf(os.Stderr, "Warning: record %d: %v\n", id, err)
+		})
+		if err != nil {
+			return err
+		}
+		if days >= 0 {
+			entries = embargo.Within(entries, days)
+			fmt.Fprintf(os.Stderr, "%d of %d records have an embargo lifting within %d days\n", len(entries), len(res.Rows), days)
+		} else {
+			fmt.Fprintf(os.Stderr, "%d of %d records are embargoed or restricted\n", len(entries), len(res.Rows))
+		}
+
+		fields := appCtx.Fields
+		if fields == "" {
+			fields = "id,title,access_right,embargo_date,days_left"
+		}
+		if err := output.Format(os.Stdout, entries, appCtx.Output, fields); err != nil {
+			return err
+		}
+		if fail && len(entries) > 0 {
+			return &CheckFailedError{Msg: fmt.Sprintf("%d embargoes lift within %d days", len(entries), days)}
+		}
+		return nil
+	},
+}
+
+var embargoExtendCmd = &cobra.Command{
+	Use:   "extend <id>",
+	Short: "Move a record's embargo date",
+	Long: `Set a new embargo date on an embargoed record, through the same
+GET-merge-PUT flow as deposit update: the change is shown as a diff and
+confirmed before it is saved. A published record is unlocked for editing,
+updated and published again.
+
+--until takes a date (YYYY-MM-DD) or a number of days from today (e.g. 180d).
+
+Examples:
+  zenodo embargo extend 12345 --until 2027-06-30
+  zenodo embargo extend 12345 --until 180d --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		until, _ := cmd.Flags().GetString("until")
+		if until == "" {
+			return fmt.Errorf("--until is required")
+		}
+		date, err := parseExpiry(until, time.Now())
+		if err != nil {
+			return fmt.Errorf("invalid --until: %w", err)
+		}
+
+		client := newClient()
+		backend, err := depositBackend(client)
+		if err != nil {
+			return err
+		}
+
+		// 1. GET current metadata. A published RDM record may have no draft
+		// yet; its published version is read instead, and a draft is only
+		// opened in step 6, once the change is confirmed.
+		dep, err := backend.Get(args[0])
+		if mode, _ := depositMode(); err != nil && mode == deposit.ModeRDM {
+			dep, err = backend.Published(args[0])
+		}
+		if err != nil {
+			return fmt.Errorf("fetching deposition: %w", err)
+		}
+		if dep.Metadata.AccessRight != embargo.Embargoed {
+			return fmt.Errorf("record %s is not embargoed (access right: %s)", args[0], dep.Metadata.AccessRight)
+		}
+
+		// 2. Merge and validate.
+		merged := dep.Metadata
+		merged.EmbargoDate = date
+		if errs := validate.Metadata(merged); len(errs) > 0 {
+			fmt.Fprintln(os.Stderr, "Validation errors:")
+			for _, e := range errs {
+				fmt.Fprintf(os.Stderr, "  - %s\n", e)
+			}
+			return fmt.Errorf("metadata validation failed")
+		}
+
+		// 3. Show diff.
+		changed, err := output.DiffMetadata(os.Stderr, dep.Metadata, merged)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			return nil
+		}
+		if dep.Metadata.EmbargoDate != "" && date < dep.Metadata.EmbargoDate {
+			fmt.Fprintf(os.Stderr, "Warning: %s is earlier than the current embargo date; the data becomes public sooner.\n", date)
+		}
+
+		// 4. Dry run — stop here.
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if dryRun {
+			fmt.Fprintln(os.Stderr, "Dry run — no changes applied.")
+			return nil
+		}
+
+		// 5. Confirm.
+		yes, _ := cmd.Flags().GetBool("yes")
+		if !yes {
+			prompt := "Apply these changes?"
+			if dep.Published {
+				prompt = "Apply these changes and publish the record again?"
+			}
+			if !confirm(prompt) {
+				fmt.Fprintln(os.Stderr, "Cancelled.")
+				os.Exit(5)
+			}
+		}
+
+		// 6. Unlock a published record, PUT, and publish again.
+		if dep.Published && dep.State != "inprogress" {
+			if dep, err = backend.Edit(dep.ID); err != nil {
+				return fmt.Errorf("unlocking record: %w", err)
+			}
+		}
+		result, err := backend.Update(dep, merged)
+		if err != nil {
+			return fmt.Errorf("updating deposition: %w", err)
+		}
+		if !dep.Published {
+			fmt.Fprintf(os.Stderr, "Draft %s embargoed until %s; it takes effect when the draft is published.\n", result.ID, date)
+			return nil
+		}
+		if result, err = backend.Publish(result.ID); err != nil {
+			return fmt.Errorf("publishing record: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Record %s embargoed until %s.\n", result.ID, date)
+		return nil
+	},
+}
+
+func init() {
+	embargoListCmd.Flags().String("community", "", "Check the records in this community")
+	embargoListCmd.Flags().Bool("authored", false, "Check records where you are a creator or contributor (by ORCID)")
+	embargoListCmd.Flags().Bool("uploaded", false, "Check records uploaded by your account (the default)")
+	embargoListCmd.Flags().String("orcid", "", "ORCID for --authored (default: the configured orcid)")
+	embargoListCmd.Flags().String("within", "", "Only embargoes lifting within this many days (e.g. 30d)")
+	embargoListCmd.Flags().Bool("fail", false, "Exit with code 6 if any embargo lifts within --within (for CI)")
+	embargoCmd.AddCommand(embargoListCmd)
+
+	embargoExtendCmd.Flags().String("until", "", "New embargo date (YYYY-MM-DD) or days from today (e.g. 180d)")
+	embargoExtendCmd.Flags().Bool("dry-run", false, "Show diff without applying changes")
+	embargoExtendCmd.Flags().Bool("yes", false, "Skip confirmation prompt")
+	embargoCmd.AddCommand(embargoExtendCmd)
+	rootCmd.AddCommand(embargoCmd)
+}
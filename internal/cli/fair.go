@@ -0,0 +1,234 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/fair"
+	"github.com/ran-codes/zenodo-cli/internal/listing"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+	"github.com/ran-codes/zenodo-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var recordsFairCmd = &cobra.Command{
+	Use:   "fair [id...]",
+	Short: "Score records against FAIR indicators",
+	Long: `Assess published records against a documented subset of FAIR indicators,
+computed from the record's metadata and file list:
+
+  F1    persistent-identifier   the record has a well-formed DOI
+  F2    rich-metadata           title, description (50+ words), keywords, date, resource type
+  F2    creator-orcid           creators have valid ORCIDs
+  A1    access-right            machine-readable access right, with embargo date or conditions
+  I1    open-formats            files use open, standard formats
+  I2    affiliation-ror         creator affiliations carry ROR IDs
+  I3    related-identifiers     related works are linked by identifiers with a scheme
+  R1.1  open-license            an open license (not NC or ND)
+  R1.2  funding                 grants are recorded
+
+Indicators give partial credit (e.g. 2 of 3 creators with ORCIDs scores 67).
+The record's score is the mean of its indicators; each principle is scored
+the same way over its own indicators.
+
+With one ID, prints the breakdown per indicator with suggestions. With
+several IDs, or --community / --authored, prints one row per record; JSON
+output always holds the full assessments.
+
+Examples:
+  zenodo records fair 12345
+  zenodo records fair 12345 -o json > fair-evidence.json
+  zenodo records fair 12345 23456 34567
+  zenodo records fair --community my-org -o csv > fair.csv`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		community, _ := cmd.Flags().GetString("community")
+		authored, _ := cmd.Flags().GetBool("authored")
+		if len(args) == 0 && community == "" && !authored {
+			return fmt.Errorf("give record IDs, --community <slug> or --authored")
+		}
+		if len(args) > 0 && (community != "" || authored) {
+			return fmt.Errorf("record IDs cannot be combined with --community or --authored")
+		}
+		if community != "" && authored {
+			return fmt.Errorf("--community and --authored cannot be combined")
+		}
+
+		client := newClient()
+		var records []model.Record
+		if len(args) > 0 {
+			for _, arg := range args {
+				id, err := strconv.Atoi(arg)
+				if err != nil {
+					return fmt.Errorf("invalid record ID: %s", arg)
+				}
+				rec, err := client.GetRecord(id)
+				if err != nil {
+					return fmt.Errorf("record %d: %w", id, err)
+				}
+				records = append(records, *rec)
+			}
+		} else {
+			var err error
+			records, err = fairBatch(client, community, authored)
+			if err != nil {
+				return err
+			}
+		}
+
+		assessments := make([]*fair.Assessment, len(records))
+		for i := range records {
+			assessments[i] = fair.Assess(&records[i])
+		}
+
+		if len(args) == 1 {
+			return printAssessment(assessments[0])
+		}
+		return printAssessments(assessments)
+	},
+}
+
+// fairBatch fetches every published record in a community, or authored by
+// the configured ORCID.
+func fairBatch(client *api.Client, community string, authored bool) ([]model.Record, error) {
+	fetch := func(p api.RecordListParams) (*listing.Result, error) {
+		return listing.Community(client, community, p)
+	}
+	if authored {
+		orcid := configuredORCID()
+		if orcid == "" {
+			return nil, fmt.Errorf("ORCID not configured. Run: zenodo config set orcid <your-orcid>")
+		}
+		fetch = func(p api.RecordListParams) (*listing.Result, error) {
+			return listing.Authored(client, orcid, p)
+		}
+	}
+	res, err := listing.Collect(api.RecordListParams{}, fetch, func(fetched, total int) {
+		fmt.Fprintf(os.Stderr, "Fetched %d of %d records\n", fetched, total)
+	})
+	if err != nil {
+		if len(res.Rows) == 0 {
+			return nil, err
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %v (assessing the %d records fetched)\n", err, len(res.Rows))
+	}
+
+	data, err := json.Marshal(res.Rows)
+	if err != nil {
+		return nil, err
+	}
+	var records []model.Record
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("reading records: %w", err)
+	}
+	return records, nil
+}
+
+// fairIndicatorRow is an indicator result flattened for table and CSV output.
+type fairIndicatorRow struct {
+	Principle  string `json:"principle"`
+	Ref        string `json:"ref"`
+	Indicator  string `json:"indicator"`
+	Score      int    `json:"score"`
+	Detail     string `json:"detail"`
+	Suggestion string `json:"suggestion"`
+}
+
+// printAssessment prints one record's breakdown.
+func printAssessment(a *fair.Assessment) error {
+	if appCtx.Output == "json" {
+		return output.Format(os.Stdout, a, "json", appCtx.Fields)
+	}
+	fields := appCtx.Fields
+	if fields == "" {
+		fields = "ref,indicator,score,detail,suggestion"
+	}
+	rows := make([]fairIndicatorRow, len(a.Indicators))
+	for i, r := range a.Indicators {
+		rows[i] = fairIndicatorRow{
+			Principle:  r.Principle.Name(),
+			Ref:        r.Ref,
+			Indicator:  r.Indicator,
+			Score:      r.Score,
+			Detail:     r.Detail,
+			Suggestion: r.Suggestion,
+		}
+	}
+	if err := output.Format(os.Stdout, rows, appCtx.Output, fields); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Record %d: FAIR score %d/100 (%s)\n", a.ID, a.Score, principleSummary(a))
+	return nil
+}
+
+// fairRecordRow is one record's assessment flattened for table and CSV output.
+type fairRecordRow struct {
+	ID            int    `json:"id"`
+	Title         string `json:"title"`
+	DOI           string `json:"doi"`
+	Score         int    `json:"score"`
+	Findable      int    `json:"findable"`
+	Accessible    int    `json:"accessible"`
+	Interoperable int    `json:"interoperable"`
+	Reusable      int    `json:"reusable"`
+	Failed        string `json:"failed"`
+	Suggestions   string `json:"suggestions"`
+}
+
+// printAssessments prints one row per record.
+func printAssessments(assessments []*fair.Assessment) error {
+	if len(assessments) == 0 {
+		fmt.Fprintln(os.Stderr, "No records found")
+		return nil
+	}
+	if appCtx.Output == "json" {
+		return output.Format(os.Stdout, assessments, "json", appCtx.Fields)
+	}
+	fields := appCtx.Fields
+	if fields == "" {
+		fields = "id,title,score,findable,accessible,interoperable,reusable,failed"
+		if appCtx.Output == "csv" {
+			fields = "id,title,doi,score,findable,accessible,interoperable,reusable,failed,suggestions"
+		}
+	}
+	rows := make([]fairRecordRow, len(assessments))
+	total := 0
+	for i, a := range assessments {
+		rows[i] = fairRecordRow{
+			ID:            a.ID,
+			Title:         a.Title,
+			DOI:           a.DOI,
+			Score:         a.Score,
+			Findable:      a.PrincipleScore(fair.Findable),
+			Accessible:    a.PrincipleScore(fair.Accessible),
+			Interoperable: a.PrincipleScore(fair.Interoperable),
+			Reusable:      a.PrincipleScore(fair.Reusable),
+			Failed:        strings.Join(a.Failed(), ", "),
+			Suggestions:   strings.Join(a.Suggestions, "; "),
+		}
+		total += a.Score
+	}
+	if err := output.Format(os.Stdout, rows, appCtx.Output, fields); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%d records, average FAIR score %d/100\n", len(assessments), total/len(assessments))
+	return nil
+}
+
+// principleSummary formats the per-principle scores, e.g. "F 83, A 100, ...".
+func principleSummary(a *fair.Assessment) string {
+	parts := make([]string, len(a.Principles))
+	for i, p := range a.Principles {
+		parts[i] = fmt.Sprintf("%s %d", p.Principle, p.Score)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func init() {
+	recordsFairCmd.Flags().String("community", "", "Assess every record in this community")
+	recordsFairCmd.Flags().Bool("authored", false, "Assess every record with your ORCID as creator or contributor")
+	recordsCmd.AddCommand(recordsFairCmd)
+}
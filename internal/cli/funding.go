@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+	"github.com/ran-codes/zenodo-cli/internal/output"
+	"github.com/ran-codes/zenodo-cli/internal/validate"
+	"github.com/spf13/cobra"
+)
+
+var fundersCmd = &cobra.Command{
+	Use:   "funders",
+	Short: "Search the funders vocabulary",
+}
+
+var fundersSearchCmd = &cobra.Command{
+	Use:   "search [query]",
+	Short: "Search funders",
+	Long: `Search the funders vocabulary by name, ROR ID or Crossref Funder
+Registry DOI. The ID (a ROR ID) or the DOI goes before :: in a grant ID.
+
+Examples:
+  zenodo funders search "national institutes of health"
+  zenodo funders search "European Commission" --output json
+  zenodo funders search --size 100 --page 2`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireEndpoint(model.EndpointFunders); err != nil {
+			return err
+		}
+		client := newClient()
+		page, _ := cmd.Flags().GetInt("page")
+		size, _ := cmd.Flags().GetInt("size")
+
+		q := ""
+		if len(args) > 0 {
+			q = args[0]
+		}
+		result, err := client.SearchFunders(q, page, size)
+		if err != nil {
+			return err
+		}
+		rows := make([]funderRow, len(result.Hits.Hits))
+		for i, f := range result.Hits.Hits {
+			rows[i] = funderRow{ID: f.ID, Name: f.Name, Country: f.Country, DOI: f.DOI()}
+		}
+
+		fields := appCtx.Fields
+		if fields == "" {
+			fields = "id,name,country,doi"
+		}
+		fmt.Fprintf(os.Stderr, "Showing %d of %d funders\n", len(rows), result.Hits.Total)
+		return output.Format(os.Stdout, rows, appCtx.Output, fields)
+	},
+}
+
+// funderRow is one funder for `funders search`.
+type funderRow struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Country string `json:"country,omitempty"`
+	DOI     string `json:"doi,omitempty"`
+}
+
+var awardsCmd = &cobra.Command{
+	Use:   "awards",
+	Short: "Search the awards vocabulary",
+}
+
+var awardsSearchCmd = &cobra.Command{
+	Use:   "search [query]",
+	Short: "Search awards (grants)",
+	Long: `Search the awards vocabulary by number, acronym or title, optionally
+for one funder. --funder takes a ROR ID, a Crossref Funder Registry DOI
+or a name; a name is resolved to the best-matching funder.
+
+The id column is the grant ID to use in metadata (grants[].id).
+
+Examples:
+  zenodo awards search FAIRsFAIR
+  zenodo awards search 101004310 --funder 00k4n6c32
+  zenodo awards search "R01GM" --funder "national institutes of health"
+  zenodo awards search --funder 10.13039/501100000780 --output csv`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireEndpoint(model.EndpointFunders); err != nil {
+			return err
+		}
+		client := newClient()
+		funder, _ := cmd.Flags().GetString("funder")
+		page, _ := cmd.Flags().GetInt("page")
+		size, _ := cmd.Flags().GetInt("size")
+
+		q := ""
+		if len(args) > 0 {
+			q = args[0]
+		}
+		if q == "" && funder == "" {
+			return fmt.Errorf("give a query, --funder, or both")
+		}
+
+		funderID := ""
+		if funder != "" {
+			f, err := resolveFunder(client, funder)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Funder: %s (%s)\n", f.Name, f.ID)
+			funderID = f.ID
+		}
+
+		result, err := client.SearchAwards(q, funderID, page, size)
+		if err != nil {
+			return err
+		}
+		rows := make([]awardRow, len(result.Hits.Hits))
+		for i, a := range result.Hits.Hits {
+			rows[i] = awardRow{
+				ID:      a.ID,
+				Number:  a.Number,
+				Acronym: a.Acronym,
+				Title:   a.TitleString(),
+				Program: a.Program,
+				Funder:  a.Funder.Name,
+			}
+		}
+
+		fields := appCtx.Fields
+		if fields == "" {
+			fields = "id,acronym,title,program,funder"
+		}
+		fmt.Fprintf(os.Stderr, "Showing %d of %d awards\n", len(rows), result.Hits.Total)
+		return output.Format(os.Stdout, rows, appCtx.Output, fields)
+	},
+}
+
+// awardRow is one award for `awards search`.
+type awardRow struct {
+	ID      string `json:"id"`
+	Number  string `json:"number"`
+	Acronym string `json:"acronym,omitempty"`
+	Title   string `json:"title"`
+	Program string `json:"program,omitempty"`
+	Funder  string `json:"funder,omitempty"`
+}
+
+// resolveFunder finds a funder by ROR ID, Crossref Funder Registry DOI or
+// name. A name resolves to the best match.
+func resolveFunder(client *api.Client, s string) (*model.Funder, error) {
+	q := s
+	switch {
+	case strings.HasPrefix(s, "10."):
+		q = "identifiers.identifier:" + strconv.Quote(s)
+	case validate.ROR(s) == nil:
+		q = "id:" + strconv.Quote(s)
+	}
+	result, err := client.SearchFunders(q, 1, 1)
+	if err != nil {
+		return nil, fmt.Errorf("looking up funder: %w", err)
+	}
+	if len(result.Hits.Hits) == 0 {
+		return nil, fmt.Errorf("no funder matches %q; see `zenodo funders search`", s)
+	}
+	return &result.Hits.Hits[0], nil
+}
+
+func init() {
+	fundersSearchCmd.Flags().Int("page", 1, "Page number")
+	fundersSearchCmd.Flags().Int("size", 20, "Results per page")
+	fundersCmd.AddCommand(fundersSearchCmd)
+	rootCmd.AddCommand(fundersCmd)
+
+	awardsSearchCmd.Flags().String("funder", "", "Only awards of this funder (ROR ID, Crossref Funder DOI or name)")
+	awardsSearchCmd.Flags().Int("page", 1, "Page number")
+	awardsSearchCmd.Flags().Int("size", 20, "Results per page")
+	awardsCmd.AddCommand(awardsSearchCmd)
+	rootCmd.AddCommand(awardsCmd)
+}
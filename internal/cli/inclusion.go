@@ -0,0 +1,320 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/curation"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+	"github.com/ran-codes/zenodo-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// Where a record stands with a community, besides the statuses of its
+// inclusion request (accepted, declined, cancelled, expired).
+const (
+	inclusionIncluded = "included"
+	inclusionPending  = "pending"
+	inclusionRemoved  = "removed"
+	inclusionFailed   = "failed"
+)
+
+var recordsCommunitiesCmd = &cobra.Command{
+	Use:   "communities",
+	Short: "Submit published records to communities",
+	Long: `List the communities a published record is in, ask communities to include
+it, or remove it from them.
+
+Adding a record opens an inclusion request with each community. Curators
+review it, unless you may include records in the community directly, in
+which case it is accepted straight away.`,
+}
+
+// inclusionRow is a record's standing with one community.
+type inclusionRow struct {
+	Record    string `json:"record"`
+	Community string `json:"community"`
+	Title     string `json:"title,omitempty"`
+	Status    string `json:"status"`
+	Request   string `json:"request,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+var recordsCommunitiesListCmd = &cobra.Command{
+	Use:   "list <record-id>",
+	Short: "List a record's communities and pending requests",
+	Long: `List the communities a record is in, and the state of the inclusion
+requests you made or received for it: pending, declined, cancelled or
+expired.
+
+Examples:
+  zenodo records communities list 12345`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireEndpoint(model.EndpointCommunities); err != nil {
+			return err
+		}
+		client := newClient()
+		recordID := args[0]
+
+		result, err := client.ListRecordCommunities(recordID)
+		if err != nil {
+			return err
+		}
+		rows := []inclusionRow{}
+		seen := map[string]bool{}
+		for _, c := range result.Hits.Hits {
+			seen[c.ID] = true
+			rows = append(rows, inclusionRow{Record: recordID, Community: c.Slug, Title: c.Metadata.Title, Status: inclusionIncluded})
+		}
+
+		if appCtx.Capabilities.Has(model.EndpointRequests) {
+			reqs, err := client.ListRecordRequests(recordID)
+			if err != nil {
+				return err
+			}
+			communities := communityCache{client: client}
+			for _, req := range reqs.Hits.Hits {
+				id := req.Receiver["community"]
+				if id == "" || seen[id] {
+					continue
+				}
+				// Requests are newest first, so only the latest per
+				// community is listed.
+				seen[id] = true
+				row := inclusionRow{Record: recordID, Community: id, Status: inclusionState(req.Status), Request: req.ID}
+				if c, err := communities.get(id); err == nil {
+					row.Community, row.Title = c.Slug, c.Metadata.Title
+				}
+				rows = append(rows, row)
+			}
+		}
+
+		fields := appCtx.Fields
+		if fields == "" {
+			fields = "community,title,status,request"
+		}
+		return output.Format(os.Stdout, rows, appCtx.Output, fields)
+	},
+}
+
+var recordsCommunitiesAddCmd = &cobra.Command{
+	Use:   "add <record-id> <slug>...",
+	Short: "Ask communities to include a record",
+	Long: `Submit a published record to one or more communities, and report for each
+whether it was accepted, is pending review, or was declined.
+
+With --stdin, every argument is a community slug and record IDs are read
+from stdin, one per line (# starts a comment).
+
+Examples:
+  zenodo records communities add 12345 eu-horizon nih-data
+  zenodo records list --output csv --fields id | tail -n +2 | zenodo records communities add --stdin eu-horizon`,
+	Args: recordsCommunitiesArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRecordsCommunities(cmd, args, false)
+	},
+}
+
+var recordsCommunitiesRemoveCmd = &cobra.Command{
+	Use:   "remove <record-id> <slug>...",
+	Short: "Remove a record from communities",
+	Long: `Remove a published record from one or more communities. You must own the
+record or curate the community.
+
+With --stdin, every argument is a community slug and record IDs are read
+from stdin, one per line (# starts a comment). Stdin then cannot answer
+the confirmation prompt, so --stdin requires --yes.
+
+Examples:
+  zenodo records communities remove 12345 old-project
+  cat ids.txt | zenodo records communities remove --stdin old-project --yes`,
+	Args: recordsCommunitiesArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRecordsCommunities(cmd, args, true)
+	},
+}
+
+// recordsCommunitiesArgs requires a record ID and a slug, or only slugs
+// with --stdin.
+func recordsCommunitiesArgs(cmd *cobra.Command, args []string) error {
+	if useStdin, _ := cmd.Flags().GetBool("stdin"); useStdin {
+		return cobra.MinimumNArgs(1)(cmd, args)
+	}
+	return cobra.MinimumNArgs(2)(cmd, args)
+}
+
+// runRecordsCommunities adds records to, or removes them from, communities
+// and reports the outcome for each pair.
+func runRecordsCommunities(cmd *cobra.Command, args []string, remove bool) error {
+	if err := requireEndpoint(model.EndpointCommunities); err != nil {
+		return err
+	}
+	useStdin, _ := cmd.Flags().GetBool("stdin")
+	yes, _ := cmd.Flags().GetBool("yes")
+	// The record IDs use up stdin, leaving nothing to answer confirm().
+	if remove && useStdin && !yes {
+		return fmt.Errorf("--stdin needs --yes, as stdin cannot also answer the confirmation prompt")
+	}
+	client := newClient()
+
+	recordIDs, slugs := args[:1], args[1:]
+	if useStdin {
+		var err error
+		if recordIDs, err = curation.ReadIDs(os.Stdin); err != nil {
+			return fmt.Errorf("reading record IDs: %w", err)
+		}
+		if len(recordIDs) == 0 {
+			return fmt.Errorf("no record IDs on stdin")
+		}
+		slugs = args
+	}
+
+	if remove && !yes {
+		if !confirm(fmt.Sprintf("Remove %d records from %s?", len(recordIDs), strings.Join(slugs, ", "))) {
+			fmt.Fprintln(os.Stderr, "Cancelled.")
+			os.Exit(5)
+		}
+	}
+
+	communities := communityCache{client: client}
+	var rows []inclusionRow
+	counts := map[string]int{}
+	for _, recordID := range recordIDs {
+		for _, slug := range slugs {
+			row := inclusionRow{Record: recordID, Community: slug}
+			if c, err := communities.get(slug); err != nil {
+				row.Status, row.Error = inclusionFailed, fmt.Sprintf("community %s: %v", slug, err)
+			} else {
+				row.Title = c.Metadata.Title
+				if remove {
+					removeFromCommunity(client, &row, c.ID)
+				} else {
+					addToCommunity(client, &row, c.ID)
+				}
+			}
+			counts[row.Status]++
+			if row.Error != "" {
+				fmt.Fprintf(os.Stderr, "Record %s, %s: %s\n", recordID, slug, row.Error)
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	var summary []string
+	for _, status := range []string{model.RequestAccepted, inclusionPending, model.RequestDeclined, inclusionRemoved, inclusionFailed} {
+		if counts[status] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[status], status))
+		}
+	}
+	fmt.Fprintln(os.Stderr, strings.Join(summary, ", "))
+
+	fields := appCtx.Fields
+	if fields == "" {
+		fields = "record,community,status,request,error"
+	}
+	if err := output.Format(os.Stdout, rows, appCtx.Output, fields); err != nil {
+		return err
+	}
+	if failed := counts[inclusionFailed]; failed > 0 {
+		return fmt.Errorf("%d of %d failed", failed, len(rows))
+	}
+	return nil
+}
+
+// addToCommunity submits a record to a community and records the state of
+// the resulting request in row.
+func addToCommunity(client *api.Client, row *inclusionRow, communityID string) {
+	result, err := client.AddRecordCommunities(row.Record, []string{communityID})
+	if err != nil {
+		row.Status, row.Error = inclusionFailed, apiErrorMessage(err)
+		return
+	}
+	if len(result.Errors) > 0 {
+		row.Status, row.Error = inclusionFailed, result.Errors[0].Message
+		return
+	}
+	if len(result.Processed) == 0 {
+		row.Status, row.Error = inclusionFailed, "no request was created"
+		return
+	}
+	p := result.Processed[0]
+	row.Request, row.Status = p.RequestID, inclusionPending
+	if p.Request != nil {
+		row.Status = inclusionState(p.Request.Status)
+	}
+}
+
+// removeFromCommunity removes a record from a community and records the
+// outcome in row.
+func removeFromCommunity(client *api.Client, row *inclusionRow, communityID string) {
+	result, err := client.RemoveRecordCommunities(row.Record, []string{communityID})
+	if err != nil {
+		row.Status, row.Error = inclusionFailed, apiErrorMessage(err)
+		return
+	}
+	if len(result.Errors) > 0 {
+		row.Status, row.Error = inclusionFailed, result.Errors[0].Message
+		return
+	}
+	row.Status = inclusionRemoved
+}
+
+// inclusionState describes a record's standing with a community from the
+// status of its inclusion request.
+func inclusionState(status string) string {
+	if status == model.RequestSubmitted {
+		return inclusionPending
+	}
+	return status
+}
+
+// apiErrorMessage returns the most specific message in an API error: the
+// first per-item error if there is one, as the communities API reports
+// why a record could not be added there.
+func apiErrorMessage(err error) string {
+	var apiErr *model.APIError
+	if errors.As(err, &apiErr) && len(apiErr.Errors) > 0 && apiErr.Errors[0].Message != "" {
+		return apiErr.Errors[0].Message
+	}
+	return err.Error()
+}
+
+// communityCache looks up communities by slug or ID once each.
+type communityCache struct {
+	client *api.Client
+	byKey  map[string]*model.Community
+	errs   map[string]error
+}
+
+func (cc *communityCache) get(idOrSlug string) (*model.Community, error) {
+	if c, ok := cc.byKey[idOrSlug]; ok {
+		return c, nil
+	}
+	if err, ok := cc.errs[idOrSlug]; ok {
+		return nil, err
+	}
+	if cc.byKey == nil {
+		cc.byKey, cc.errs = map[string]*model.Community{}, map[string]error{}
+	}
+	c, err := cc.client.GetCommunity(idOrSlug)
+	if err != nil {
+		cc.errs[idOrSlug] = err
+		return nil, err
+	}
+	cc.byKey[idOrSlug] = c
+	return c, nil
+}
+
+func init() {
+	recordsCommunitiesCmd.AddCommand(recordsCommunitiesListCmd)
+	for _, c := range []*cobra.Command{recordsCommunitiesAddCmd, recordsCommunitiesRemoveCmd} {
+		c.Flags().Bool("stdin", false, "Read record IDs from stdin; all arguments are community slugs")
+		recordsCommunitiesCmd.AddCommand(c)
+	}
+	recordsCommunitiesRemoveCmd.Flags().Bool("yes", false, "Skip confirmation prompt")
+	recordsCmd.AddCommand(recordsCommunitiesCmd)
+}
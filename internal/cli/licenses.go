@@ -1,9 +1,10 @@
 package cli
 
 import (
+	"fmt"
 	"os"
 
-	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/model"
 	"github.com/ran-codes/zenodo-cli/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -24,7 +25,10 @@ Examples:
   zenodo licenses search "MIT" --output json`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client := api.NewClient(appCtx.BaseURL, appCtx.Token)
+		if err := requireEndpoint(model.EndpointLicenses); err != nil {
+			return fmt.Errorf("%w; try `zenodo vocab show licenses [query]`", err)
+		}
+		client := newClient()
 
 		q := ""
 		if len(args) > 0 {
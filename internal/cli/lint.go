@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/lint"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+	"github.com/ran-codes/zenodo-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint <id|file>...",
+	Short: "Check record metadata against a metadata policy",
+	Long: `Check the metadata of records, drafts or local metadata files against a
+set of rules. Each rule reports at level error, warning or info.
+
+A numeric argument is a record or deposition ID; anything else is read as a
+JSON file holding metadata, a deposition or a record.
+
+Rules:
+  creator-orcid               creators without an ORCID (warning)
+  creator-affiliation         creators without an affiliation (warning)
+  description-length          descriptions under min_description_words words (warning, default 50)
+  keywords                    no keywords (warning)
+  open-access-license         open access without a license (error)
+  grants                      no grants (info)
+  software-version            software without a version string (warning)
+  related-identifier-scheme   related identifiers without a scheme (warning)
+  identifier-syntax           malformed ORCIDs, DOIs, RORs, ISBNs, dates or language codes (error)
+
+Levels and thresholds are set per profile under "lint" in the config file,
+and can be overridden with --rules <file> using the same YAML:
+
+  profiles:
+    production:
+      lint:
+        min_description_words: 80
+        rules:
+          creator-orcid: error
+          grants: off
+
+Output is a findings table, JSON or CSV (--output), or SARIF 2.1.0 with
+--output sarif for code-scanning tools. Exits with code 6 if any finding
+is at or above --fail-on (default: error).
+
+Examples:
+  zenodo lint 12345
+  zenodo lint metadata.json --fail-on warning
+  zenodo lint metadata.json --rules policy.yaml -o sarif > lint.sarif`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		failOnFlag, _ := cmd.Flags().GetString("fail-on")
+		failOn, err := lint.ParseLevel(failOnFlag)
+		if err != nil || failOn == lint.LevelOff {
+			return fmt.Errorf("--fail-on must be error, warning or info")
+		}
+		cfg, err := lintConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := newClient()
+		var (
+			reports []lint.Report
+			all     []lint.Finding
+			rows    []lintRow
+		)
+		for _, arg := range args {
+			m, uri, err := loadLintTarget(client, arg)
+			if err != nil {
+				return fmt.Errorf("%s: %w", arg, err)
+			}
+			findings := lint.Lint(*m, cfg)
+			reports = append(reports, lint.Report{Target: arg, URI: uri, Findings: findings})
+			all = append(all, findings...)
+			for _, f := range findings {
+				rows = append(rows, lintRow{Target: arg, Level: f.Level, Rule: f.Rule, Path: f.Path, Message: f.Message})
+			}
+		}
+
+		switch appCtx.Output {
+		case "sarif":
+			if err := lint.WriteSARIF(os.Stdout, reports, cfg); err != nil {
+				return err
+			}
+		case "json":
+			if err := output.Format(os.Stdout, reports, "json", appCtx.Fields); err != nil {
+				return err
+			}
+		default:
+			fields := appCtx.Fields
+			if fields == "" {
+				fields = "target,level,rule,path,message"
+			}
+			if len(rows) > 0 {
+				if err := output.Format(os.Stdout, rows, appCtx.Output, fields); err != nil {
+					return err
+				}
+			}
+		}
+
+		counts := lint.Count(all)
+		fmt.Fprintf(os.Stderr, "%d error(s), %d warning(s), %d info in %d target(s)\n",
+			counts[lint.LevelError], counts[lint.LevelWarning], counts[lint.LevelInfo], len(args))
+		if lint.Failed(all, failOn) {
+			return &CheckFailedError{Msg: fmt.Sprintf("lint failed: findings at level %s or above", failOn)}
+		}
+		return nil
+	},
+}
+
+// lintRow is a finding flattened for table and CSV output.
+type lintRow struct {
+	Target  string     `json:"target"`
+	Level   lint.Level `json:"level"`
+	Rule    string     `json:"rule"`
+	Path    string     `json:"path"`
+	Message string     `json:"message"`
+}
+
+// lintConfig combines the profile's "lint" section with the --rules file.
+func lintConfig(cmd *cobra.Command) (lint.Config, error) {
+	cfg, err := lint.ConfigFromValue(appCtx.Config.Get(fmt.Sprintf("profiles.%s.lint", appCtx.Profile)))
+	if err != nil {
+		return lint.Config{}, fmt.Errorf("profile %s: %w", appCtx.Profile, err)
+	}
+	if path, _ := cmd.Flags().GetString("rules"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return lint.Config{}, fmt.Errorf("reading rules file: %w", err)
+		}
+		fileCfg, err := lint.ParseConfig(data)
+		if err != nil {
+			return lint.Config{}, fmt.Errorf("%s: %w", path, err)
+		}
+		cfg = cfg.Merge(fileCfg)
+	}
+	return cfg, nil
+}
+
+// loadLintTarget returns the metadata of a record ID or JSON file, and a URI
+// locating it. IDs are fetched as depositions when a token is available, so
+// drafts can be linted, falling back to the published record.
+func loadLintTarget(client *api.Client, arg string) (*model.Metadata, string, error) {
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		data, err := os.ReadFile(arg)
+		if err != nil {
+			return nil, "", err
+		}
+		m, err := model.ParseMetadataJSON(data)
+		if err != nil {
+			return nil, "", fmt.Errorf("parsing metadata: %w", err)
+		}
+		return m, arg, nil
+	}
+
+	if appCtx.Token != "" {
+		if dep, err := client.GetDeposition(id); err == nil {
+			uri := dep.Links.HTML
+			if uri == "" {
+				uri = arg
+			}
+			return &dep.Metadata, uri, nil
+		}
+	}
+	rec, err := client.GetRecord(id)
+	if err != nil {
+		return nil, "", err
+	}
+	uri := rec.Links.HTML
+	if uri == "" {
+		uri = arg
+	}
+	return &rec.Metadata, uri, nil
+}
+
+func init() {
+	lintCmd.Flags().String("rules", "", "YAML file of rule levels and thresholds, overriding the profile's")
+	lintCmd.Flags().String("fail-on", "error", "Exit with code 6 if a finding is at this level or above: error, warning or info")
+	rootCmd.AddCommand(lintCmd)
+}
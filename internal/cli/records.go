@@ -1,13 +1,13 @@
 package cli
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
-	"strings"
 
 	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/crosswalk"
+	"github.com/ran-codes/zenodo-cli/internal/listing"
 	"github.com/ran-codes/zenodo-cli/internal/model"
 	"github.com/ran-codes/zenodo-cli/internal/output"
 	"github.com/spf13/cobra"
@@ -33,7 +33,7 @@ Examples:
   zenodo records list --community
   zenodo records list --community=my-org`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client := api.NewClient(appCtx.BaseURL, appCtx.Token)
+		client := newClient()
 		status, _ := cmd.Flags().GetString("status")
 		community, _ := cmd.Flags().GetString("community")
 		communityUsed := cmd.Flags().Changed("community")
@@ -44,7 +44,7 @@ Examples:
 
 		// --authored: search by ORCID (created or contributed)
 		if authored {
-			return listAuthored(client, cmd, status, community, fields)
+			return listAuthored(client, status, community, fields)
 		}
 
 		// --uploaded: explicitly list self-uploaded records
@@ -57,20 +57,12 @@ Examples:
 			if fields == "" {
 				fields = "community,title,links.doi,stats.version_views,stats.version_downloads,created"
 			}
-			params := api.RecordListParams{
-				Status:    status,
-				Community: community,
-			}
-			result, err := client.SearchRecords("", params)
-			if err != nil {
-				return err
-			}
-			rows, err := injectCommunity(result.Hits.Hits, community)
+			result, err := listing.Community(client, community, api.RecordListParams{Status: status})
 			if err != nil {
 				return err
 			}
-			fmt.Fprintf(os.Stderr, "Showing %d of %d records in %s\n", len(result.Hits.Hits), result.Hits.Total, community)
-			return output.Format(os.Stdout, rows, appCtx.Output, fields)
+			fmt.Fprintf(os.Stderr, "Showing %d of %d records in %s\n", len(result.Rows), result.Total, community)
+			return output.Format(os.Stdout, result.Rows, appCtx.Output, fields)
 		}
 
 		// --community (no value): aggregate across user's communities
@@ -78,71 +70,56 @@ Examples:
 			if fields == "" {
 				fields = "community,title,links.doi,stats.version_views,stats.version_downloads,created"
 			}
-			communities, err := client.ListUserCommunities("", 0, 0)
+			result, n, err := listing.AllCommunities(client, api.RecordListParams{Status: status}, func(slug string, err error) {
+				fmt.Fprintf(os.Stderr, "Warning: could not fetch records for %s: %v\n", slug, err)
+			}, nil)
 			if err != nil {
 				return err
 			}
-			if len(communities.Hits.Hits) == 0 {
+			if n == 0 {
 				fmt.Fprintln(os.Stderr, "No communities found")
 				return nil
 			}
-			var allRows []map[string]interface{}
-			for _, c := range communities.Hits.Hits {
-				params := api.RecordListParams{
-					Status:    status,
-					Community: c.Slug,
-				}
-				result, err := client.SearchRecords("", params)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Warning: could not fetch records for %s: %v\n", c.Slug, err)
-					continue
-				}
-				rows, err := injectCommunity(result.Hits.Hits, c.Slug)
-				if err != nil {
-					return err
-				}
-				allRows = append(allRows, rows...)
-			}
-			fmt.Fprintf(os.Stderr, "Total: %d records across %d communities\n", len(allRows), len(communities.Hits.Hits))
-			return output.Format(os.Stdout, allRows, appCtx.Output, fields)
+			fmt.Fprintf(os.Stderr, "Total: %d records across %d communities\n", len(result.Rows), n)
+			return output.Format(os.Stdout, result.Rows, appCtx.Output, fields)
 		}
 
 		// Default: if ORCID is configured, search by ORCID; otherwise list uploads
-		orcid := fmt.Sprintf("%v", appCtx.Config.Get("orcid"))
-		if orcid != "" && orcid != "<nil>" {
-			return listAuthored(client, cmd, "", "", fields)
+		if configuredORCID() != "" {
+			return listAuthored(client, "", "", fields)
 		}
 		return listUploaded(client, status, fields)
 	},
 }
 
+// configuredORCID returns the ORCID from config, or "" if unset.
+func configuredORCID() string {
+	orcid := fmt.Sprintf("%v", appCtx.Config.Get("orcid"))
+	if orcid == "<nil>" {
+		return ""
+	}
+	return orcid
+}
+
 // listAuthored searches for records where the user's ORCID appears as creator or contributor.
-func listAuthored(client *api.Client, cmd *cobra.Command, status, community, fields string) error {
+func listAuthored(client *api.Client, status, community, fields string) error {
 	if status == "draft" {
 		return fmt.Errorf("--authored cannot be used with --status draft (drafts are not available via the search API)")
 	}
-	orcid := fmt.Sprintf("%v", appCtx.Config.Get("orcid"))
-	if orcid == "" || orcid == "<nil>" {
+	orcid := configuredORCID()
+	if orcid == "" {
 		return fmt.Errorf("ORCID not configured. Run: zenodo config set orcid <your-orcid>")
 	}
 	if fields == "" {
 		fields = "title,community,links.doi,stats.version_views,stats.version_downloads,created"
 	}
-	query := orcidQuery(orcid)
-	params := api.RecordListParams{
-		Community: community,
-	}
-	result, err := client.SearchRecords(query, params)
-	if err != nil {
-		return err
-	}
-	rows, err := normalizeCommunities(result.Hits.Hits)
+	result, err := listing.Authored(client, orcid, api.RecordListParams{Community: community})
 	if err != nil {
 		return err
 	}
 	fmt.Fprintf(os.Stderr, "Records where you are a creator or contributor (ORCID %s)\n", orcid)
-	fmt.Fprintf(os.Stderr, "Showing %d of %d records\n", len(result.Hits.Hits), result.Hits.Total)
-	return output.Format(os.Stdout, rows, appCtx.Output, fields)
+	fmt.Fprintf(os.Stderr, "Showing %d of %d records\n", len(result.Rows), result.Total)
+	return output.Format(os.Stdout, result.Rows, appCtx.Output, fields)
 }
 
 // listUploaded lists records the authenticated user uploaded via depositions.
@@ -150,96 +127,17 @@ func listUploaded(client *api.Client, status, fields string) error {
 	if fields == "" {
 		fields = "title,community,links.doi,created"
 	}
-	params := api.RecordListParams{
-		Status: status,
-	}
-	depositions, err := client.ListUserRecords(params)
+	result, err := listing.Uploaded(client, api.RecordListParams{Status: status})
 	if err != nil {
 		return err
 	}
-	rows, err := normalizeCommunities(depositions)
-	if err != nil {
-		return err
-	}
-	orcid := fmt.Sprintf("%v", appCtx.Config.Get("orcid"))
-	if orcid == "" || orcid == "<nil>" {
+	if configuredORCID() == "" {
 		fmt.Fprintf(os.Stderr, "Records uploaded by your account (to see all records you authored or contributed to: zenodo config set orcid <your-orcid>)\n")
 	} else {
 		fmt.Fprintf(os.Stderr, "Records uploaded by your account\n")
 	}
-	fmt.Fprintf(os.Stderr, "Showing %d records\n", len(depositions))
-	return output.Format(os.Stdout, rows, appCtx.Output, fields)
-}
-
-// orcidQuery returns an Elasticsearch query that matches records where the given
-// ORCID appears as either a creator or contributor.
-func orcidQuery(orcid string) string {
-	return fmt.Sprintf("creators.orcid:%s OR contributors.orcid:%s", orcid, orcid)
-}
-
-// normalizeCommunities converts depositions to maps and extracts metadata.communities
-// into a top-level "community" field as a comma-separated string of identifiers.
-func normalizeCommunities(data interface{}) ([]map[string]interface{}, error) {
-	b, err := json.Marshal(data)
-	if err != nil {
-		return nil, err
-	}
-	var rows []map[string]interface{}
-	if err := json.Unmarshal(b, &rows); err != nil {
-		return nil, err
-	}
-	for _, row := range rows {
-		row["community"] = extractCommunities(row)
-	}
-	return rows, nil
-}
-
-// extractCommunities pulls community slugs from metadata.communities,
-// handling both "identifier" (depositions) and "id" (records) keys.
-func extractCommunities(row map[string]interface{}) string {
-	mc, ok := row["metadata"]
-	if !ok {
-		return ""
-	}
-	meta, ok := mc.(map[string]interface{})
-	if !ok {
-		return ""
-	}
-	communities, ok := meta["communities"]
-	if !ok {
-		return ""
-	}
-	arr, ok := communities.([]interface{})
-	if !ok {
-		return ""
-	}
-	var slugs []string
-	for _, item := range arr {
-		if m, ok := item.(map[string]interface{}); ok {
-			if id, ok := m["identifier"]; ok && fmt.Sprintf("%v", id) != "" {
-				slugs = append(slugs, fmt.Sprintf("%v", id))
-			} else if id, ok := m["id"]; ok && fmt.Sprintf("%v", id) != "" {
-				slugs = append(slugs, fmt.Sprintf("%v", id))
-			}
-		}
-	}
-	return strings.Join(slugs, ", ")
-}
-
-// injectCommunity converts records to maps and adds a top-level "community" field.
-func injectCommunity(data interface{}, slug string) ([]map[string]interface{}, error) {
-	b, err := json.Marshal(data)
-	if err != nil {
-		return nil, err
-	}
-	var rows []map[string]interface{}
-	if err := json.Unmarshal(b, &rows); err != nil {
-		return nil, err
-	}
-	for _, row := range rows {
-		row["community"] = slug
-	}
-	return rows, nil
+	fmt.Fprintf(os.Stderr, "Showing %d records\n", len(result.Rows))
+	return output.Format(os.Stdout, result.Rows, appCtx.Output, fields)
 }
 
 var recordsSearchCmd = &cobra.Command{
@@ -253,7 +151,7 @@ Examples:
   zenodo records search "publication_date:[2024-01-01 TO 2024-12-31]" --all`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client := api.NewClient(appCtx.BaseURL, appCtx.Token)
+		client := newClient()
 		query := args[0]
 		community, _ := cmd.Flags().GetString("community")
 		all, _ := cmd.Flags().GetBool("all")
@@ -296,7 +194,10 @@ var recordsGetCmd = &cobra.Command{
 Examples:
   zenodo records get 12345
   zenodo records get 12345 --output json
-  zenodo records get 12345 --format bibtex`,
+  zenodo records get 12345 --format bibtex
+  zenodo records get 12345 --format schemaorg
+  zenodo records get 12345 --format dcat
+  zenodo records get 12345 --format rdm`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		id, err := strconv.Atoi(args[0])
@@ -304,7 +205,7 @@ Examples:
 			return fmt.Errorf("invalid record ID: %s", args[0])
 		}
 
-		client := api.NewClient(appCtx.BaseURL, appCtx.Token)
+		client := newClient()
 		format, _ := cmd.Flags().GetString("format")
 
 		// Handle non-JSON formats via Accept header.
@@ -323,12 +224,28 @@ Examples:
 			}
 			fmt.Println(string(data))
 			return nil
+		case "rdm":
+			record, err := client.GetRDMRecord(id)
+			if err != nil {
+				return err
+			}
+			return writeJSON(os.Stdout, record)
 		}
 
 		record, err := client.GetRecord(id)
 		if err != nil {
 			return err
 		}
+
+		// Crosswalk formats are rendered locally from the record.
+		switch format {
+		case "schemaorg":
+			return writeJSON(os.Stdout, crosswalk.ToSchemaOrg(record))
+		case "dcat":
+			return crosswalk.WriteDCAT(os.Stdout, record, crosswalk.DCATTurtle)
+		case "dcat-jsonld":
+			return crosswalk.WriteDCAT(os.Stdout, record, crosswalk.DCATJSONLD)
+		}
 		return output.Format(os.Stdout, record, appCtx.Output, appCtx.Fields)
 	},
 }
@@ -347,7 +264,7 @@ Examples:
 			return fmt.Errorf("invalid record ID: %s", args[0])
 		}
 
-		client := api.NewClient(appCtx.BaseURL, appCtx.Token)
+		client := newClient()
 		result, err := client.ListVersions(id)
 		if err != nil {
 			return err
@@ -374,7 +291,7 @@ func init() {
 	recordsSearchCmd.Flags().Bool("all", false, "Fetch all pages (up to 10k results)")
 
 	// records get flags
-	recordsGetCmd.Flags().String("format", "", "Response format: json, bibtex, datacite (default: uses --output)")
+	recordsGetCmd.Flags().String("format", "", "Response format: json, bibtex, datacite, schemaorg, dcat, dcat-jsonld, rdm (default: uses --output)")
 
 	recordsCmd.AddCommand(recordsListCmd)
 	recordsCmd.AddCommand(recordsSearchCmd)
@@ -0,0 +1,353 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/curation"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+	"github.com/ran-codes/zenodo-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var communitiesRequestsCmd = &cobra.Command{
+	Use:   "requests",
+	Short: "Review the records submitted to a community",
+	Long: `Review the requests a community has received: drafts submitted to it
+and published records proposed for inclusion. You must be a curator or
+owner of the community.
+
+Accept or decline requests one at a time by ID, or in bulk with
+--community and filters, e.g. every open submission whose record has a
+creator with a known ORCID iD.`,
+}
+
+var requestsListCmd = &cobra.Command{
+	Use:   "list <slug>",
+	Short: "List a community's open requests",
+	Long: `List the open requests a community has received, newest first, or all
+of them with --all. The filters are the ones bulk accept and decline use,
+so a listing previews what they would act on.
+
+Examples:
+  zenodo communities requests list my-org
+  zenodo communities requests list my-org --type submission
+  zenodo communities requests list my-org --orcid-file lab-orcids.txt --output csv`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := newClient()
+		all, _ := cmd.Flags().GetBool("all")
+		matches, total, err := selectRequests(cmd, client, args[0], !all)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Showing %d of %d requests\n", len(matches), total)
+		fields := appCtx.Fields
+		if fields == "" {
+			fields = "id,type,status,record,title,submitter,created"
+		}
+		return output.Format(os.Stdout, requestRows(matches), appCtx.Output, fields)
+	},
+}
+
+var requestsShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show a request and its comments",
+	Long: `Show a request and its timeline of comments and decisions.
+
+Examples:
+  zenodo communities requests show 0c2b8e6e-6f1c-4b5e-9d1a-3f7c2a9e8b10
+  zenodo communities requests show 0c2b8e6e-6f1c-4b5e-9d1a-3f7c2a9e8b10 --output json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireEndpoint(model.EndpointRequests); err != nil {
+			return err
+		}
+		client := newClient()
+		req, err := client.GetRequest(args[0])
+		if err != nil {
+			return err
+		}
+		timeline, err := client.GetRequestTimeline(req.ID)
+		if err != nil {
+			return err
+		}
+		if appCtx.Output == "json" {
+			return writeJSON(os.Stdout, map[string]interface{}{"request": req, "timeline": timeline.Hits.Hits})
+		}
+
+		fields := appCtx.Fields
+		if fields == "" {
+			fields = "id,type,status,record,title,submitter,created,url"
+		}
+		if err := output.Format(os.Stdout, requestRows([]curation.Match{{Request: *req}})[0], appCtx.Output, fields); err != nil {
+			return err
+		}
+		for _, ev := range timeline.Hits.Hits {
+			when := ev.Created.Format("2006-01-02 15:04")
+			switch ev.Type {
+			case model.RequestEventComment:
+				fmt.Printf("%s  %s: %s\n", when, ev.Author(), ev.Text())
+			case model.RequestEventLog:
+				fmt.Printf("%s  %s %s\n", when, ev.Author(), ev.Payload.Event)
+			}
+		}
+		return nil
+	},
+}
+
+var requestsAcceptCmd = &cobra.Command{
+	Use:   "accept [id...]",
+	Short: "Accept requests",
+	Long: `Accept requests by ID, or in bulk: every open request of --community
+that matches the filters. A bulk action lists the matching requests and
+asks for confirmation (skip with --yes). Accepting a submission publishes
+the record in the community.
+
+Examples:
+  zenodo communities requests accept 0c2b8e6e-6f1c-4b5e-9d1a-3f7c2a9e8b10
+  zenodo communities requests accept --community my-org --orcid-file lab-orcids.txt
+  zenodo communities requests accept --community my-org --orcid 0000-0002-1825-0097 --yes --message "Welcome!"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRequestAction(cmd, args, api.ActionAccept)
+	},
+}
+
+var requestsDeclineCmd = &cobra.Command{
+	Use:   "decline [id...]",
+	Short: "Decline requests",
+	Long: `Decline requests by ID, or in bulk with --community and filters, as
+for accept. Use --message to tell the submitter why.
+
+Examples:
+  zenodo communities requests decline 0c2b8e6e-6f1c-4b5e-9d1a-3f7c2a9e8b10 --message "Out of scope"
+  zenodo communities requests decline --community my-org --type inclusion --dry-run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRequestAction(cmd, args, api.ActionDecline)
+	},
+}
+
+var requestsCommentCmd = &cobra.Command{
+	Use:   "comment <id> <message>",
+	Short: "Comment on a request",
+	Long: `Add a comment to a request, e.g. to ask the submitter for changes.
+
+Examples:
+  zenodo communities requests comment 0c2b8e6e-6f1c-4b5e-9d1a-3f7c2a9e8b10 "Please add a license"`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireEndpoint(model.EndpointRequests); err != nil {
+			return err
+		}
+		if _, err := newClient().CommentRequest(args[0], args[1]); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Comment added to request %s\n", args[0])
+		return nil
+	},
+}
+
+// requestRow is one request in a listing or action report.
+type requestRow struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Status    string `json:"status"`
+	Record    string `json:"record"`
+	Title     string `json:"title"`
+	Submitter string `json:"submitter"`
+	ORCIDs    string `json:"orcids,omitempty"`
+	Created   string `json:"created"`
+	URL       string `json:"url"`
+	Error     string `json:"error,omitempty"`
+}
+
+func requestRows(matches []curation.Match) []requestRow {
+	rows := make([]requestRow, len(matches))
+	for i, m := range matches {
+		req := m.Request
+		title := m.Title
+		if title == "" {
+			title = req.Title
+		}
+		rows[i] = requestRow{
+			ID:        req.ID,
+			Type:      strings.TrimPrefix(req.Type, "community-"),
+			Status:    req.Status,
+			Record:    req.RecordID(),
+			Title:     title,
+			Submitter: req.Submitter(),
+			ORCIDs:    strings.Join(m.ORCIDs, " "),
+			URL:       req.Links.SelfHTML,
+		}
+		if !req.Created.IsZero() {
+			rows[i].Created = req.Created.Format(time.DateOnly)
+		}
+	}
+	return rows
+}
+
+// requestFilter builds the curation filter from the --type, --orcid and
+// --orcid-file flags.
+func requestFilter(cmd *cobra.Command) (curation.Filter, error) {
+	var f curation.Filter
+	switch t, _ := cmd.Flags().GetString("type"); t {
+	case "":
+	case "submission", model.RequestCommunitySubmission:
+		f.Type = model.RequestCommunitySubmission
+	case "inclusion", model.RequestCommunityInclusion:
+		f.Type = model.RequestCommunityInclusion
+	default:
+		return f, fmt.Errorf("unknown request type %q (supported: submission, inclusion)", t)
+	}
+
+	orcids, _ := cmd.Flags().GetStringSlice("orcid")
+	ids, err := curation.ParseORCIDs(orcids)
+	if err != nil {
+		return f, err
+	}
+	if path, _ := cmd.Flags().GetString("orcid-file"); path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			return f, err
+		}
+		defer file.Close()
+		fromFile, err := curation.ReadORCIDs(file)
+		if err != nil {
+			return f, fmt.Errorf("%s: %w", path, err)
+		}
+		if len(fromFile) == 0 {
+			return f, fmt.Errorf("%s: no ORCID iDs", path)
+		}
+		ids = append(ids, fromFile...)
+	}
+	f.ORCIDs = ids
+	return f, nil
+}
+
+// selectRequests lists a community's requests and returns those matching
+// the filter flags, and the number listed.
+func selectRequests(cmd *cobra.Command, client *api.Client, slug string, open bool) ([]curation.Match, int, error) {
+	if err := requireEndpoint(model.EndpointRequests); err != nil {
+		return nil, 0, err
+	}
+	filter, err := requestFilter(cmd)
+	if err != nil {
+		return nil, 0, err
+	}
+	community, err := client.GetCommunity(slug)
+	if err != nil {
+		return nil, 0, fmt.Errorf("community %s: %w", slug, err)
+	}
+	reqs, err := curation.List(client, community.ID, "", open)
+	if err != nil {
+		return nil, 0, err
+	}
+	matches, err := curation.Select(client, reqs, filter)
+	return matches, len(reqs), err
+}
+
+// runRequestAction accepts or declines the requests given by ID, or those
+// selected with --community, and reports the outcome of each.
+func runRequestAction(cmd *cobra.Command, ids []string, action string) error {
+	client := newClient()
+	slug, _ := cmd.Flags().GetString("community")
+	message, _ := cmd.Flags().GetString("message")
+	verb := map[string]string{api.ActionAccept: "Accept", api.ActionDecline: "Decline"}[action]
+
+	var matches []curation.Match
+	switch {
+	case slug != "" && len(ids) > 0:
+		return fmt.Errorf("give request IDs or --community, not both")
+	case slug != "":
+		var total int
+		var err error
+		if matches, total, err = selectRequests(cmd, client, slug, true); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "%d of %d open requests match:\n", len(matches), total)
+		for _, row := range requestRows(matches) {
+			fmt.Fprintf(os.Stderr, "  %s  %-10s %s  %s (%s)\n", row.ID, row.Type, row.Record, row.Title, row.Submitter)
+		}
+		if len(matches) == 0 {
+			return nil
+		}
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			fmt.Fprintln(os.Stderr, "Dry run — no requests changed.")
+			return nil
+		}
+		if yes, _ := cmd.Flags().GetBool("yes"); !yes {
+			if !confirm(fmt.Sprintf("%s %d requests?", verb, len(matches))) {
+				fmt.Fprintln(os.Stderr, "Cancelled.")
+				os.Exit(5)
+			}
+		}
+	case len(ids) > 0:
+		for _, name := range []string{"type", "orcid", "orcid-file", "dry-run"} {
+			if cmd.Flags().Changed(name) {
+				return fmt.Errorf("--%s needs --community", name)
+			}
+		}
+		if err := requireEndpoint(model.EndpointRequests); err != nil {
+			return err
+		}
+		for _, id := range ids {
+			matches = append(matches, curation.Match{Request: model.Request{ID: id}})
+		}
+	default:
+		return fmt.Errorf("give request IDs, or --community to select requests")
+	}
+
+	rows := requestRows(matches)
+	failed := 0
+	for i, m := range matches {
+		req, err := client.RequestAction(m.Request.ID, action, message)
+		if err != nil {
+			failed++
+			rows[i].Error = err.Error()
+			fmt.Fprintf(os.Stderr, "Request %s: %v\n", m.Request.ID, err)
+			continue
+		}
+		rows[i].Status = req.Status
+		if rows[i].Record == "" {
+			rows[i].Record, rows[i].Type, rows[i].Title = req.RecordID(), strings.TrimPrefix(req.Type, "community-"), req.Title
+		}
+		fmt.Fprintf(os.Stderr, "Request %s: %s\n", m.Request.ID, req.Status)
+	}
+
+	fields := appCtx.Fields
+	if fields == "" {
+		fields = "id,record,title,status,error"
+	}
+	if err := output.Format(os.Stdout, rows, appCtx.Output, fields); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d requests failed", failed, len(matches))
+	}
+	return nil
+}
+
+func init() {
+	for _, c := range []*cobra.Command{requestsListCmd, requestsAcceptCmd, requestsDeclineCmd} {
+		c.Flags().String("type", "", "Only requests of this type: submission or inclusion")
+		c.Flags().StringSlice("orcid", nil, "Only records with a creator or contributor with this ORCID iD (repeatable)")
+		c.Flags().String("orcid-file", "", "Like --orcid, for each ORCID iD in a file (one per line, # comments)")
+	}
+	requestsListCmd.Flags().Bool("all", false, "Include closed requests")
+	for _, c := range []*cobra.Command{requestsAcceptCmd, requestsDeclineCmd} {
+		c.Flags().String("community", "", "Act on the community's open requests that match the filters")
+		c.Flags().String("message", "", "Comment to add with the decision")
+		c.Flags().Bool("dry-run", false, "List the matching requests without changing them")
+		c.Flags().Bool("yes", false, "Skip confirmation prompt")
+	}
+
+	communitiesRequestsCmd.AddCommand(requestsListCmd)
+	communitiesRequestsCmd.AddCommand(requestsShowCmd)
+	communitiesRequestsCmd.AddCommand(requestsAcceptCmd)
+	communitiesRequestsCmd.AddCommand(requestsDeclineCmd)
+	communitiesRequestsCmd.AddCommand(requestsCommentCmd)
+	communitiesCmd.AddCommand(communitiesRequestsCmd)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/mattn/go-isatty"
 	"github.com/ran-codes/zenodo-cli/internal/config"
+	"github.com/ran-codes/zenodo-cli/internal/model"
 	"github.com/spf13/cobra"
 )
 
@@ -23,7 +24,7 @@ Get started:
   zenodo records get <id>                Get record details
 
 Exit codes: 0=success, 1=API error, 2=auth error, 3=validation error,
-            4=rate limit, 5=user cancelled`,
+            4=rate limit, 5=user cancelled, 6=check failed`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		// Set up logging.
 		verbose, _ := cmd.Flags().GetBool("verbose")
@@ -71,6 +72,19 @@ Exit codes: 0=success, 1=API error, 2=auth error, 3=validation error,
 		sandbox, _ := cmd.Flags().GetBool("sandbox")
 		baseURL := cfg.ResolveBaseURL(profile, sandbox)
 
+		// Write API: "deposit" (default) or "rdm"; parsed by the deposit
+		// commands, so a bad value cannot lock out `config set`.
+		mode := cfg.GetProfileValue(profile, "mode")
+
+		// Capabilities probed by `config add-instance`; they describe the
+		// profile's instance, not Zenodo's sandbox.
+		var caps *model.Capabilities
+		if !sandbox {
+			if caps, err = cfg.ProfileCapabilities(profile); err != nil {
+				slog.Warn("ignoring stored capabilities", "profile", profile, "error", err)
+			}
+		}
+
 		// Resolve output format.
 		output, _ := cmd.Flags().GetString("output")
 		if output == "" {
@@ -85,19 +99,23 @@ Exit codes: 0=success, 1=API error, 2=auth error, 3=validation error,
 
 		// Populate shared context.
 		appCtx = AppContext{
-			Config:  cfg,
-			Keyring: kr,
-			Profile: profile,
-			Token:   token,
-			BaseURL: baseURL,
-			Output:  output,
-			Fields:  fields,
-			Verbose: verbose,
+			Config:       cfg,
+			Keyring:      kr,
+			Profile:      profile,
+			Token:        token,
+			BaseURL:      baseURL,
+			Mode:         mode,
+			Capabilities: caps,
+			Output:       output,
+			Fields:       fields,
+			Verbose:      verbose,
 		}
 
 		slog.Debug("resolved context",
 			"profile", profile,
 			"base_url", baseURL,
+			"mode", mode,
+			"probed", caps != nil,
 			"output", output,
 			"has_token", token != "",
 		)
@@ -118,6 +136,17 @@ func init() {
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose logging")
 }
 
+// CheckFailedError is returned by check commands (verify, bag validate, ...)
+// that ran to completion but found problems, so callers can tell findings
+// from failures.
+type CheckFailedError struct {
+	Msg string
+}
+
+func (e *CheckFailedError) Error() string {
+	return e.Msg
+}
+
 // Execute runs the root command. Returns the error and resolved output format.
 // The caller (main) is responsible for formatting the error.
 func Execute() (error, string) {
@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/output"
+	"github.com/ran-codes/zenodo-cli/internal/validate"
+	"github.com/ran-codes/zenodo-cli/internal/vocab"
+	"github.com/spf13/cobra"
+)
+
+var vocabCmd = &cobra.Command{
+	Use:   "vocab",
+	Short: "Manage the cached controlled vocabularies used for validation",
+	Long: `Metadata validation checks licenses, resource types, relation types,
+languages and contributor types against Zenodo's controlled vocabularies.
+
+They are fetched from the profile's Zenodo instance the first time they are
+needed, cached under the config directory (vocab/<host>/), and refetched
+after 30 days. Offline, a stale cache is used, or else the copy built into
+the binary.`,
+}
+
+var vocabListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show the vocabularies in use and where they come from",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := vocabStore(nil)
+		var rows []vocabRow
+		for _, kind := range vocab.Kinds {
+			v, err := store.Load(kind, false)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+			row := vocabRow{Vocabulary: string(kind), Source: v.Source, Terms: len(v.Terms), Version: v.Version}
+			if !v.Fetched.IsZero() {
+				row.Fetched = v.Fetched.Format(time.RFC3339)
+				row.Stale = !store.Fresh(v)
+			}
+			rows = append(rows, row)
+		}
+		fields := appCtx.Fields
+		if fields == "" {
+			fields = "vocabulary,source,terms,version,fetched,stale"
+		}
+		return output.Format(os.Stdout, rows, appCtx.Output, fields)
+	},
+}
+
+// vocabRow describes one vocabulary for `vocab list`.
+type vocabRow struct {
+	Vocabulary string `json:"vocabulary"`
+	Source     string `json:"source"`
+	Terms      int    `json:"terms"`
+	Version    string `json:"version"`
+	Fetched    string `json:"fetched"`
+	Stale      bool   `json:"stale"`
+}
+
+var vocabUpdateCmd = &cobra.Command{
+	Use:   "update [vocabulary...]",
+	Short: "Fetch vocabularies from Zenodo and refresh the cache",
+	Long: `Fetch vocabularies from the profile's Zenodo instance and cache them.
+Without arguments, all are fetched: licenses, resourcetypes, relationtypes,
+languages and contributorsroles.
+
+Examples:
+  zenodo vocab update
+  zenodo vocab update licenses`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kinds := vocab.Kinds
+		if len(args) > 0 {
+			kinds = nil
+			for _, arg := range args {
+				kind, err := vocab.ParseKind(arg)
+				if err != nil {
+					return err
+				}
+				kinds = append(kinds, kind)
+			}
+		}
+
+		store := vocabStore(newClient())
+		for _, kind := range kinds {
+			v, previous, err := store.Update(kind)
+			if err != nil {
+				return err
+			}
+			change := "new"
+			switch previous {
+			case "":
+			case v.Version:
+				change = "unchanged"
+			default:
+				change = "changed from " + previous
+			}
+			fmt.Fprintf(os.Stderr, "%s: %d terms, version %s (%s)\n", kind, len(v.Terms), v.Version, change)
+		}
+		return nil
+	},
+}
+
+var vocabShowCmd = &cobra.Command{
+	Use:   "show <vocabulary> [query]",
+	Short: "List the terms of a vocabulary",
+	Long: `List the terms of a vocabulary, optionally only those whose ID or title
+contains the query. Relation and contributor types are also shown as the
+deposit API spells them.
+
+Examples:
+  zenodo vocab show relationtypes
+  zenodo vocab show licenses "creative commons"
+  zenodo vocab show languages german`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kind, err := vocab.ParseKind(args[0])
+		if err != nil {
+			return err
+		}
+		v, err := vocabStore(nil).Load(kind, false)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+
+		query := ""
+		if len(args) > 1 {
+			query = strings.ToLower(args[1])
+		}
+		rows := []vocabTermRow{}
+		for _, t := range v.Terms {
+			if query != "" && !strings.Contains(strings.ToLower(t.ID), query) && !strings.Contains(strings.ToLower(t.Title), query) {
+				continue
+			}
+			row := vocabTermRow{ID: t.ID, Title: t.Title}
+			switch kind {
+			case vocab.RelationTypes:
+				row.Deposit = t.Words(false)
+			case vocab.ContributorRoles:
+				row.Deposit = t.Words(true)
+			}
+			rows = append(rows, row)
+		}
+
+		fields := appCtx.Fields
+		if fields == "" {
+			fields = "id,title"
+			if kind == vocab.RelationTypes || kind == vocab.ContributorRoles {
+				fields = "id,deposit,title"
+			}
+		}
+		fmt.Fprintf(os.Stderr, "%d of %d terms (%s)\n", len(rows), len(v.Terms), v.Source)
+		return output.Format(os.Stdout, rows, appCtx.Output, fields)
+	},
+}
+
+// vocabTermRow is one term for `vocab show`.
+type vocabTermRow struct {
+	ID      string `json:"id"`
+	Deposit string `json:"deposit,omitempty"`
+	Title   string `json:"title"`
+}
+
+// useVocabularies points validation at the profile instance's cached
+// vocabularies, fetching any that are missing or stale. If a fetch fails
+// it warns and falls back to the cached or built-in copies. Grant IDs are
+// looked up as they are validated.
+func useVocabularies(client *api.Client) {
+	store := vocabStore(client)
+	validate.UseVocabularies(store.LoadSet(true, func(kind vocab.Kind, err error) {
+		fmt.Fprintf(os.Stderr, "Warning: %v; validating against cached or built-in vocabularies\n", err)
+	}))
+	validate.UseAwards(store.Awards(true))
+}
+
+// vocabStore returns the vocabulary store for the profile's instance.
+func vocabStore(client vocab.Client) *vocab.Store {
+	store := vocab.NewStore(appCtx.BaseURL, client)
+	store.Caps = appCtx.Capabilities
+	return store
+}
+
+func init() {
+	vocabCmd.AddCommand(vocabListCmd)
+	vocabCmd.AddCommand(vocabUpdateCmd)
+	vocabCmd.AddCommand(vocabShowCmd)
+	rootCmd.AddCommand(vocabCmd)
+}
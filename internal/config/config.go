@@ -1,12 +1,14 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 
+	"github.com/ran-codes/zenodo-cli/internal/model"
 	"github.com/spf13/viper"
 )
 
@@ -120,6 +122,35 @@ func (c *Config) GetProfileValue(profile, key string) string {
 	return c.v.GetString(profileKey(profile, key))
 }
 
+// ProfileCapabilities returns the capabilities probed for a profile's
+// instance by `zenodo config add-instance`, or nil if none are stored.
+func (c *Config) ProfileCapabilities(profile string) (*model.Capabilities, error) {
+	key := profileKey(profile, "capabilities")
+	if !c.v.IsSet(key) {
+		return nil, nil
+	}
+	var caps model.Capabilities
+	if err := c.v.UnmarshalKey(key, &caps); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", key, err)
+	}
+	return &caps, nil
+}
+
+// SetProfileCapabilities stores the capabilities probed for a profile's
+// instance.
+func (c *Config) SetProfileCapabilities(profile string, caps *model.Capabilities) error {
+	data, err := json.Marshal(caps)
+	if err != nil {
+		return fmt.Errorf("marshaling capabilities: %w", err)
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return fmt.Errorf("marshaling capabilities: %w", err)
+	}
+	c.v.Set(profileKey(profile, "capabilities"), m)
+	return nil
+}
+
 // ProfileNames returns all configured profile names.
 func (c *Config) ProfileNames() []string {
 	profiles := c.v.GetStringMap("profiles")
@@ -3,7 +3,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
 )
 
 func TestLoadDefaults(t *testing.T) {
@@ -170,3 +173,44 @@ func TestProfileNames(t *testing.T) {
 		t.Errorf("expected production and sandbox profiles, got %v", names)
 	}
 }
+
+func TestProfileCapabilities(t *testing.T) {
+	tmpDir := t.TempDir()
+	origXDG := os.Getenv("XDG_CONFIG_HOME")
+	defer os.Setenv("XDG_CONFIG_HOME", origXDG)
+	os.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if caps, err := cfg.ProfileCapabilities("production"); caps != nil || err != nil {
+		t.Errorf("ProfileCapabilities(production) = %+v, %v; want nil", caps, err)
+	}
+
+	want := &model.Capabilities{
+		Probed:         "2026-01-02T03:04:05Z",
+		Endpoints:      []string{"records", "drafts"},
+		Vocabularies:   []string{"languages"},
+		RateLimit:      500,
+		SearchPrefixes: []string{"/records"},
+	}
+	if err := cfg.SetProfileCapabilities("myinst", want); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	cfg2, err := Load()
+	if err != nil {
+		t.Fatalf("Load() after save error: %v", err)
+	}
+	got, err := cfg2.ProfileCapabilities("myinst")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ProfileCapabilities(myinst) = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,104 @@
+// Package crosswalk maps Zenodo record metadata to and from other metadata
+// schemas (RO-Crate, schema.org, DCAT, InvenioRDM).
+package crosswalk
+
+import (
+	"strings"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// orcidURL returns the canonical ORCID URL for a bare ORCID iD.
+func orcidURL(orcid string) string {
+	if orcid == "" || strings.HasPrefix(orcid, "http") {
+		return orcid
+	}
+	return "https://orcid.org/" + orcid
+}
+
+// orcidFromURL strips the orcid.org prefix, returning "" for other URLs.
+func orcidFromURL(s string) string {
+	for _, prefix := range []string{"https://orcid.org/", "http://orcid.org/"} {
+		if strings.HasPrefix(s, prefix) {
+			return strings.TrimPrefix(s, prefix)
+		}
+	}
+	return ""
+}
+
+// doiURL returns the resolver URL for a DOI.
+func doiURL(doi string) string {
+	if doi == "" || strings.HasPrefix(doi, "http") {
+		return doi
+	}
+	return "https://doi.org/" + doi
+}
+
+// recordDOI returns the record's DOI, falling back to the metadata DOI.
+func recordDOI(rec *model.Record) string {
+	if rec.DOI != "" {
+		return rec.DOI
+	}
+	return rec.Metadata.DOI
+}
+
+// resourceType returns the record's resource type, falling back to the
+// legacy upload_type.
+func resourceType(m model.Metadata) string {
+	if m.ResourceType != nil && m.ResourceType.Type != "" {
+		return m.ResourceType.Type
+	}
+	return m.UploadType
+}
+
+// isSoftware reports whether the record describes software.
+func isSoftware(m model.Metadata) bool {
+	return strings.HasPrefix(resourceType(m), "software")
+}
+
+// licenseURL maps a Zenodo license ID to a dereferenceable URL.
+// Zenodo license IDs are lower-cased SPDX identifiers.
+func licenseURL(id string) string {
+	if id == "" || strings.HasPrefix(id, "http") {
+		return id
+	}
+	return "https://spdx.org/licenses/" + spdxCase(id)
+}
+
+// licenseFromURL maps a license URL or SPDX identifier back to a Zenodo license ID.
+func licenseFromURL(s string) string {
+	for _, prefix := range []string{"https://spdx.org/licenses/", "http://spdx.org/licenses/"} {
+		if strings.HasPrefix(s, prefix) {
+			s = strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(s, prefix), ".html"), ".json")
+			break
+		}
+	}
+	return strings.ToLower(s)
+}
+
+// spdxCase restores SPDX capitalization for common Zenodo license IDs,
+// e.g. "cc-by-4.0" → "CC-BY-4.0". Unknown IDs are returned as-is.
+func spdxCase(id string) string {
+	lower := strings.ToLower(id)
+	switch {
+	case strings.HasPrefix(lower, "cc"), strings.HasPrefix(lower, "odc"),
+		strings.HasPrefix(lower, "gpl"), strings.HasPrefix(lower, "lgpl"),
+		strings.HasPrefix(lower, "agpl"), strings.HasPrefix(lower, "mpl"),
+		strings.HasPrefix(lower, "epl"), lower == "mit", lower == "isc":
+		return strings.ToUpper(lower)
+	case strings.HasPrefix(lower, "apache-"):
+		return "Apache-" + strings.TrimPrefix(lower, "apache-")
+	case strings.HasPrefix(lower, "bsd-"):
+		return "BSD-" + strings.TrimPrefix(lower, "bsd-")
+	}
+	return id
+}
+
+// splitName splits a "Family, Given" name into its parts.
+func splitName(name string) (family, given string) {
+	family, given, ok := strings.Cut(name, ",")
+	if !ok {
+		return "", ""
+	}
+	return strings.TrimSpace(family), strings.TrimSpace(given)
+}
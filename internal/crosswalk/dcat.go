@@ -0,0 +1,191 @@
+package crosswalk
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// DCAT RDF syntaxes.
+const (
+	DCATTurtle = "turtle"
+	DCATJSONLD = "jsonld"
+)
+
+// dctRelations maps DataCite relation types to Dublin Core terms.
+// Anything else is exported as dct:relation.
+var dctRelations = map[string]string{
+	"cites":          "dct:references",
+	"references":     "dct:references",
+	"isCitedBy":      "dct:isReferencedBy",
+	"isReferencedBy": "dct:isReferencedBy",
+	"isPartOf":       "dct:isPartOf",
+	"hasPart":        "dct:hasPart",
+	"isVersionOf":    "dct:isVersionOf",
+	"hasVersion":     "dct:hasVersion",
+	"isDerivedFrom":  "dct:source",
+	"requires":       "dct:requires",
+	"isRequiredBy":   "dct:isRequiredBy",
+}
+
+// WriteDCAT writes a single record as a dcat:Dataset.
+func WriteDCAT(w io.Writer, rec *model.Record, syntax string) error {
+	g := &rdfGraph{}
+	addDCATDataset(g, rec)
+	return writeGraph(w, g, syntax)
+}
+
+// WriteDCATCatalog writes a dcat:Catalog listing every record as a dataset.
+// catalogURL identifies the catalogue; if empty, a blank node is used.
+func WriteDCATCatalog(w io.Writer, title, catalogURL string, records []model.Record, syntax string) error {
+	g := &rdfGraph{}
+	var catalog term
+	if catalogURL != "" {
+		catalog = iriTerm(catalogURL)
+	} else {
+		catalog = g.blank()
+	}
+	g.add(catalog, "a", pname("dcat:Catalog"))
+	g.add(catalog, "dct:title", literal(title))
+	g.add(catalog, "dct:modified", typedLiteral(time.Now().UTC().Format("2006-01-02"), "xsd:date"))
+	if catalogURL != "" {
+		g.add(catalog, "foaf:homepage", iriTerm(catalogURL))
+	}
+
+	datasets := make([]term, 0, len(records))
+	for i := range records {
+		datasets = append(datasets, datasetTerm(g, &records[i]))
+	}
+	for _, d := range datasets {
+		g.add(catalog, "dcat:dataset", d)
+	}
+	for i := range records {
+		addDCATDatasetAs(g, &records[i], datasets[i])
+	}
+	return writeGraph(w, g, syntax)
+}
+
+func writeGraph(w io.Writer, g *rdfGraph, syntax string) error {
+	switch syntax {
+	case DCATTurtle, "":
+		return g.writeTurtle(w)
+	case DCATJSONLD:
+		return g.writeJSONLD(w)
+	default:
+		return fmt.Errorf("unsupported RDF syntax: %q", syntax)
+	}
+}
+
+// datasetTerm returns the dataset's IRI: its DOI, landing page, or a blank node.
+func datasetTerm(g *rdfGraph, rec *model.Record) term {
+	if doi := recordDOI(rec); doi != "" {
+		return iriTerm(doiURL(doi))
+	}
+	if rec.Links.HTML != "" {
+		return iriTerm(rec.Links.HTML)
+	}
+	return g.blank()
+}
+
+func addDCATDataset(g *rdfGraph, rec *model.Record) {
+	addDCATDatasetAs(g, rec, datasetTerm(g, rec))
+}
+
+func addDCATDatasetAs(g *rdfGraph, rec *model.Record, ds term) {
+	m := rec.Metadata
+	g.add(ds, "a", pname("dcat:Dataset"))
+	g.add(ds, "dct:title", literal(m.Title))
+	if m.Description != "" {
+		g.add(ds, "dct:description", literal(m.Description))
+	}
+	if doi := recordDOI(rec); doi != "" {
+		g.add(ds, "dct:identifier", literal(doi))
+	}
+	if m.PublicationDate != "" {
+		g.add(ds, "dct:issued", typedLiteral(m.PublicationDate, "xsd:date"))
+	}
+	if !rec.Updated.IsZero() {
+		g.add(ds, "dct:modified", typedLiteral(rec.Updated.UTC().Format(time.RFC3339), "xsd:dateTime"))
+	}
+	if rec.Links.HTML != "" {
+		g.add(ds, "dcat:landingPage", iriTerm(rec.Links.HTML))
+	}
+	if m.Version != "" {
+		g.add(ds, "dcat:version", literal(m.Version))
+	}
+	if m.Language != "" {
+		g.add(ds, "dct:language", literal(m.Language))
+	}
+	if m.AccessRight != "" {
+		g.add(ds, "dct:accessRights", literal(m.AccessRight))
+	}
+	for _, k := range m.Keywords {
+		g.add(ds, "dcat:keyword", literal(k))
+	}
+	if typ := resourceType(m); typ != "" {
+		g.add(ds, "dct:type", literal(typ))
+	}
+
+	license := m.LicenseString()
+	if license != "" {
+		g.add(ds, "dct:license", iriTerm(licenseURL(license)))
+	}
+
+	for _, c := range m.Creators {
+		g.add(ds, "dct:creator", dcatAgent(g, c.Name, c.ORCID))
+	}
+	for _, c := range m.Contributors {
+		g.add(ds, "dct:contributor", dcatAgent(g, c.Name, c.ORCID))
+	}
+
+	for _, ri := range m.RelatedIdentifiers {
+		pred, ok := dctRelations[ri.Relation]
+		if !ok {
+			pred = "dct:relation"
+		}
+		switch {
+		case ri.Scheme == "doi":
+			g.add(ds, pred, iriTerm(doiURL(ri.Identifier)))
+		case ri.Scheme == "url":
+			g.add(ds, pred, iriTerm(ri.Identifier))
+		default:
+			g.add(ds, pred, literal(ri.Identifier))
+		}
+	}
+
+	for _, f := range rec.Files {
+		var dist term
+		if u := f.DownloadURL(); u != "" {
+			dist = iriTerm(u)
+		} else {
+			dist = g.blank()
+		}
+		g.add(ds, "dcat:distribution", dist)
+		g.add(dist, "a", pname("dcat:Distribution"))
+		g.add(dist, "dct:title", literal(f.Key))
+		if u := f.DownloadURL(); u != "" {
+			g.add(dist, "dcat:downloadURL", iriTerm(u))
+			g.add(dist, "dcat:accessURL", iriTerm(u))
+		}
+		g.add(dist, "dcat:byteSize", typedLiteral(strconv.FormatInt(f.Size, 10), "xsd:nonNegativeInteger"))
+		if license != "" {
+			g.add(dist, "dct:license", iriTerm(licenseURL(license)))
+		}
+	}
+}
+
+// dcatAgent adds a foaf:Person, identified by ORCID when available.
+func dcatAgent(g *rdfGraph, name, orcid string) term {
+	var agent term
+	if orcid != "" {
+		agent = iriTerm(orcidURL(orcid))
+	} else {
+		agent = g.blank()
+	}
+	g.add(agent, "a", pname("foaf:Person"))
+	g.add(agent, "foaf:name", literal(name))
+	return agent
+}
@@ -0,0 +1,90 @@
+package crosswalk
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+func TestWriteDCAT_Turtle(t *testing.T) {
+	rec := sampleRecord()
+	rec.Metadata.Description = "Line one\nwith \"quotes\""
+	rec.Files[0].Links.Self = "https://zenodo.org/api/records/42/files/obs data.csv/content"
+
+	var buf bytes.Buffer
+	if err := WriteDCAT(&buf, rec, DCATTurtle); err != nil {
+		t.Fatalf("WriteDCAT() error: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"@prefix dcat: <http://www.w3.org/ns/dcat#> .",
+		"<https://doi.org/10.5281/zenodo.42>\n    a dcat:Dataset ;",
+		`dct:issued "2024-03-01"^^xsd:date`,
+		`dcat:keyword "soil", "hydrology"`,
+		"dct:license <https://spdx.org/licenses/CC-BY-4.0>",
+		"dct:creator <https://orcid.org/0000-0002-1825-0097>, _:b1",
+		`"Line one\nwith \"quotes\""`,
+		"<https://zenodo.org/api/records/42/files/obs%20data.csv/content>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("turtle output missing %q\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteDCAT_JSONLD(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteDCAT(&buf, sampleRecord(), DCATJSONLD); err != nil {
+		t.Fatalf("WriteDCAT() error: %v", err)
+	}
+
+	var doc struct {
+		Context map[string]string        `json:"@context"`
+		Graph   []map[string]interface{} `json:"@graph"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON-LD: %v", err)
+	}
+	if doc.Context["dcat"] != "http://www.w3.org/ns/dcat#" {
+		t.Errorf("context = %v", doc.Context)
+	}
+	ds := doc.Graph[0]
+	if ds["@id"] != "https://doi.org/10.5281/zenodo.42" || ds["@type"] != "dcat:Dataset" {
+		t.Errorf("dataset node = %v", ds)
+	}
+	if kw, ok := ds["dcat:keyword"].([]interface{}); !ok || len(kw) != 2 {
+		t.Errorf("keywords = %v", ds["dcat:keyword"])
+	}
+}
+
+func TestWriteDCATCatalog(t *testing.T) {
+	second := *sampleRecord()
+	second.DOI = "10.5281/zenodo.43"
+	records := []model.Record{*sampleRecord(), second}
+
+	var buf bytes.Buffer
+	if err := WriteDCATCatalog(&buf, "My Community", "https://zenodo.org/communities/mine", records, DCATTurtle); err != nil {
+		t.Fatalf("WriteDCATCatalog() error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "a dcat:Catalog") {
+		t.Errorf("missing catalog:\n%s", out)
+	}
+	if !strings.Contains(out, "dcat:dataset <https://doi.org/10.5281/zenodo.42>, <https://doi.org/10.5281/zenodo.43>") {
+		t.Errorf("missing dataset links:\n%s", out)
+	}
+	// The shared creator is described once.
+	if n := strings.Count(out, "<https://orcid.org/0000-0002-1825-0097>\n    a foaf:Person"); n != 1 {
+		t.Errorf("creator described %d times", n)
+	}
+}
+
+func TestWriteDCAT_UnknownSyntax(t *testing.T) {
+	if err := WriteDCAT(&bytes.Buffer{}, sampleRecord(), "rdfxml"); err == nil {
+		t.Error("expected error for unsupported syntax")
+	}
+}
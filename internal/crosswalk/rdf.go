@@ -0,0 +1,201 @@
+package crosswalk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// rdfPrefixes are the namespaces used by the DCAT mapping, in output order.
+var rdfPrefixes = []struct{ prefix, ns string }{
+	{"dcat", "http://www.w3.org/ns/dcat#"},
+	{"dct", "http://purl.org/dc/terms/"},
+	{"foaf", "http://xmlns.com/foaf/0.1/"},
+	{"xsd", "http://www.w3.org/2001/XMLSchema#"},
+}
+
+type termKind int
+
+const (
+	kindIRI     termKind = iota // absolute IRI
+	kindPName                   // prefixed name, e.g. dcat:Dataset
+	kindBlank                   // blank node, e.g. _:b1
+	kindLiteral                 // literal, optionally typed
+)
+
+// term is an RDF subject or object.
+type term struct {
+	kind     termKind
+	value    string
+	datatype string // prefixed name for typed literals
+}
+
+func iriTerm(s string) term          { return term{kind: kindIRI, value: s} }
+func pname(s string) term            { return term{kind: kindPName, value: s} }
+func literal(s string) term          { return term{kind: kindLiteral, value: s} }
+func typedLiteral(s, dt string) term { return term{kind: kindLiteral, value: s, datatype: dt} }
+
+// triple is a single statement. Predicates are prefixed names or "a".
+type triple struct {
+	subject   term
+	predicate string
+	object    term
+}
+
+// rdfGraph is a minimal in-memory RDF graph that renders as Turtle or JSON-LD.
+type rdfGraph struct {
+	triples []triple
+	seen    map[triple]bool
+	blanks  int
+}
+
+// add appends a triple, skipping exact duplicates (e.g. a person who
+// appears on several datasets in a catalogue).
+func (g *rdfGraph) add(s term, p string, o term) {
+	t := triple{s, p, o}
+	if g.seen == nil {
+		g.seen = make(map[triple]bool)
+	}
+	if g.seen[t] {
+		return
+	}
+	g.seen[t] = true
+	g.triples = append(g.triples, t)
+}
+
+// blank allocates a new blank node.
+func (g *rdfGraph) blank() term {
+	g.blanks++
+	return term{kind: kindBlank, value: fmt.Sprintf("_:b%d", g.blanks)}
+}
+
+// subjects groups triples by subject, preserving first-seen order.
+func (g *rdfGraph) subjects() ([]term, map[term][]triple) {
+	var order []term
+	bySubject := make(map[term][]triple)
+	for _, t := range g.triples {
+		if _, ok := bySubject[t.subject]; !ok {
+			order = append(order, t.subject)
+		}
+		bySubject[t.subject] = append(bySubject[t.subject], t)
+	}
+	return order, bySubject
+}
+
+// writeTurtle renders the graph as Turtle.
+func (g *rdfGraph) writeTurtle(w io.Writer) error {
+	var b strings.Builder
+	for _, p := range rdfPrefixes {
+		fmt.Fprintf(&b, "@prefix %s: <%s> .\n", p.prefix, p.ns)
+	}
+
+	order, bySubject := g.subjects()
+	for _, s := range order {
+		b.WriteString("\n")
+		b.WriteString(turtleTerm(s))
+
+		// Group objects by predicate, preserving order.
+		var preds []string
+		objects := make(map[string][]string)
+		for _, t := range bySubject[s] {
+			if _, ok := objects[t.predicate]; !ok {
+				preds = append(preds, t.predicate)
+			}
+			objects[t.predicate] = append(objects[t.predicate], turtleTerm(t.object))
+		}
+		for i, p := range preds {
+			sep := " ;"
+			if i == len(preds)-1 {
+				sep = " ."
+			}
+			fmt.Fprintf(&b, "\n    %s %s%s", p, strings.Join(objects[p], ", "), sep)
+		}
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func turtleTerm(t term) string {
+	switch t.kind {
+	case kindIRI:
+		return "<" + escapeIRI(t.value) + ">"
+	case kindLiteral:
+		s := `"` + escapeLiteral(t.value) + `"`
+		if t.datatype != "" {
+			s += "^^" + t.datatype
+		}
+		return s
+	default:
+		return t.value
+	}
+}
+
+// escapeIRI percent-encodes characters that are not allowed inside <...>.
+func escapeIRI(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r <= 0x20 || strings.ContainsRune("<>\"{}|^`\\", r) {
+			b.WriteString(url.PathEscape(string(r)))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func escapeLiteral(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return r.Replace(s)
+}
+
+// writeJSONLD renders the graph as compacted JSON-LD using the DCAT prefixes.
+func (g *rdfGraph) writeJSONLD(w io.Writer) error {
+	context := make(map[string]string, len(rdfPrefixes))
+	for _, p := range rdfPrefixes {
+		context[p.prefix] = p.ns
+	}
+
+	order, bySubject := g.subjects()
+	nodes := make([]map[string]interface{}, 0, len(order))
+	for _, s := range order {
+		node := map[string]interface{}{"@id": s.value}
+		for _, t := range bySubject[s] {
+			key, value := t.predicate, jsonldValue(t.object)
+			if key == "a" {
+				key, value = "@type", t.object.value
+			}
+			switch existing := node[key].(type) {
+			case nil:
+				node[key] = value
+			case []interface{}:
+				node[key] = append(existing, value)
+			default:
+				node[key] = []interface{}{existing, value}
+			}
+		}
+		nodes = append(nodes, node)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(map[string]interface{}{
+		"@context": context,
+		"@graph":   nodes,
+	})
+}
+
+func jsonldValue(t term) interface{} {
+	switch t.kind {
+	case kindLiteral:
+		if t.datatype != "" {
+			return map[string]string{"@value": t.value, "@type": t.datatype}
+		}
+		return t.value
+	default:
+		return map[string]string{"@id": t.value}
+	}
+}
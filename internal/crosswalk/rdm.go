@@ -0,0 +1,490 @@
+package crosswalk
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+	"github.com/ran-codes/zenodo-cli/internal/vocab"
+)
+
+// Legacy fields with no RDM field of their own are carried as these
+// vocabulary terms.
+const (
+	rdmNotesType      = "other"      // additional_descriptions type for notes
+	rdmSupervisorRole = "supervisor" // contributor role for thesis_supervisors
+)
+
+// rorAffiliation matches an affiliation written as "Name (https://ror.org/ID)".
+var rorAffiliation = regexp.MustCompile(`^(.*?)\s*\(?(?:https?://)?ror\.org/([0-9a-z]+)\)?$`)
+
+// ToRDM maps legacy deposit metadata to an InvenioRDM record, ready to be
+// sent as a draft. Legacy fields without an RDM equivalent in the metadata
+// block go where Zenodo keeps them: the DOI in pids, access_right in
+// access, journal, conference, imprint and thesis fields in custom_fields,
+// and access conditions in the parent's access-request settings.
+func ToRDM(m model.Metadata) *model.RDMRecord {
+	r := &model.RDMRecord{
+		Metadata: model.RDMMetadata{
+			Title:           m.Title,
+			Description:     m.Description,
+			PublicationDate: m.PublicationDate,
+			Version:         m.Version,
+		},
+	}
+	md := &r.Metadata
+
+	if id := rdmResourceType(m); id != "" {
+		md.ResourceType = &model.RDMVocabRef{ID: id}
+	}
+	for _, c := range m.Creators {
+		md.Creators = append(md.Creators, rdmCreatorship(c.Name, c.ORCID, c.GND, c.Affiliation, ""))
+	}
+	for _, c := range m.Contributors {
+		md.Contributors = append(md.Contributors, rdmCreatorship(c.Name, c.ORCID, c.GND, c.Affiliation, strings.ToLower(c.Type)))
+	}
+	for _, c := range m.ThesisSupervisors {
+		md.Contributors = append(md.Contributors, rdmCreatorship(c.Name, c.ORCID, c.GND, c.Affiliation, rdmSupervisorRole))
+	}
+	if license := m.LicenseString(); license != "" {
+		md.Rights = []model.RDMRight{{ID: license}}
+	}
+	for _, k := range m.Keywords {
+		md.Subjects = append(md.Subjects, model.RDMSubject{Subject: k})
+	}
+	for _, s := range m.Subjects {
+		md.Subjects = append(md.Subjects, model.RDMSubject{ID: s.Identifier, Subject: s.Term, Scheme: s.Scheme})
+	}
+	if m.Language != "" {
+		md.Languages = []model.RDMVocabRef{{ID: m.Language}}
+	}
+	if m.Notes != "" {
+		md.AdditionalDescriptions = []model.RDMDescription{{Description: m.Notes, Type: model.RDMVocabRef{ID: rdmNotesType}}}
+	}
+	for _, ri := range m.RelatedIdentifiers {
+		md.RelatedIdentifiers = append(md.RelatedIdentifiers, model.RDMRelatedIdentifier{
+			Identifier:   ri.Identifier,
+			Scheme:       ri.Scheme,
+			RelationType: model.RDMVocabRef{ID: strings.ToLower(ri.Relation)},
+		})
+	}
+	for _, g := range m.Grants {
+		if g.ID != "" {
+			md.Funding = append(md.Funding, rdmFunding(g.ID))
+		}
+	}
+	for _, ref := range m.References {
+		md.References = append(md.References, model.RDMReference{Reference: ref})
+	}
+	md.Publisher = m.ImprintPublisher
+
+	if m.DOI != "" {
+		provider := "external"
+		if strings.HasPrefix(strings.ToLower(m.DOI), "10.5281/zenodo.") {
+			provider = "datacite"
+		}
+		r.PIDs = map[string]model.RDMPID{"doi": {Identifier: m.DOI, Provider: provider}}
+	}
+
+	r.Access = model.RDMAccess{Record: model.RDMPublic, Files: model.RDMPublic}
+	var settings *model.RDMAccessSettings
+	switch m.AccessRight {
+	case "embargoed":
+		r.Access.Files = model.RDMRestricted
+		r.Access.Embargo = &model.RDMEmbargo{Active: true, Until: m.EmbargoDate}
+	case "restricted":
+		r.Access.Files = model.RDMRestricted
+		settings = &model.RDMAccessSettings{AllowUserRequests: true, AcceptConditionsText: m.AccessConditions}
+	case "closed":
+		r.Access.Files = model.RDMRestricted
+	}
+	var communities *model.RDMCommunities
+	if len(m.Communities) > 0 {
+		communities = &model.RDMCommunities{}
+		for _, c := range m.Communities {
+			communities.Entries = append(communities.Entries, model.RDMCommunityEntry{Slug: c.Slug()})
+		}
+	}
+	if settings != nil || communities != nil {
+		r.Parent = &model.RDMParent{Communities: communities}
+		if settings != nil {
+			r.Parent.Access = &model.RDMParentAccess{Settings: settings}
+		}
+	}
+
+	r.CustomFields = rdmCustomFields(m)
+	return r
+}
+
+// FromRDM maps an InvenioRDM record back to legacy deposit metadata. RDM
+// fields the legacy schema cannot express (additional titles, dates,
+// locations, alternate identifiers, more than one license or language) are
+// dropped, as are funders without an award.
+func FromRDM(r *model.RDMRecord) model.Metadata {
+	md := r.Metadata
+	m := model.Metadata{
+		Title:           md.Title,
+		Description:     md.Description,
+		PublicationDate: md.PublicationDate,
+		Version:         md.Version,
+		DOI:             r.DOI(),
+	}
+
+	if md.ResourceType != nil {
+		m.UploadType, m.PublicationType, m.ImageType = legacyUploadType(md.ResourceType.ID)
+	}
+	isThesis := m.PublicationType == "thesis"
+	for _, c := range md.Creators {
+		name, orcid, gnd, affiliation := legacyPerson(c)
+		m.Creators = append(m.Creators, model.Creator{Name: name, ORCID: orcid, GND: gnd, Affiliation: affiliation})
+	}
+	for _, c := range md.Contributors {
+		name, orcid, gnd, affiliation := legacyPerson(c)
+		role := ""
+		if c.Role != nil {
+			role = c.Role.ID
+		}
+		if role == rdmSupervisorRole && isThesis {
+			m.ThesisSupervisors = append(m.ThesisSupervisors, model.Creator{Name: name, ORCID: orcid, GND: gnd, Affiliation: affiliation})
+			continue
+		}
+		m.Contributors = append(m.Contributors, model.Contributor{
+			Name: name, ORCID: orcid, GND: gnd, Affiliation: affiliation,
+			Type: depositSpelling(vocab.ContributorRoles, role, true),
+		})
+	}
+	if len(md.Rights) > 0 && md.Rights[0].ID != "" {
+		m.License, _ = json.Marshal(md.Rights[0].ID)
+	}
+	for _, s := range md.Subjects {
+		if s.ID == "" && s.Scheme == "" {
+			m.Keywords = append(m.Keywords, s.Subject)
+			continue
+		}
+		m.Subjects = append(m.Subjects, model.Subject{Term: s.Subject, Identifier: s.ID, Scheme: s.Scheme})
+	}
+	if len(md.Languages) > 0 {
+		m.Language = md.Languages[0].ID
+	}
+	for _, d := range md.AdditionalDescriptions {
+		if d.Type.ID == rdmNotesType && m.Notes == "" {
+			m.Notes = d.Description
+		}
+	}
+	for _, ri := range md.RelatedIdentifiers {
+		m.RelatedIdentifiers = append(m.RelatedIdentifiers, model.RelatedIdentifier{
+			Identifier: ri.Identifier,
+			Scheme:     ri.Scheme,
+			Relation:   depositSpelling(vocab.RelationTypes, ri.RelationType.ID, false),
+		})
+	}
+	for _, f := range md.Funding {
+		if id := legacyGrantID(f); id != "" {
+			m.Grants = append(m.Grants, model.Grant{ID: id})
+		}
+	}
+	for _, ref := range md.References {
+		m.References = append(m.References, ref.Reference)
+	}
+
+	switch {
+	case r.Access.Embargo != nil && r.Access.Embargo.Active:
+		m.AccessRight = "embargoed"
+		m.EmbargoDate = r.Access.Embargo.Until
+	case r.Access.Files == "" || r.Access.Files == model.RDMPublic:
+		m.AccessRight = "open"
+	case r.Parent != nil && r.Parent.Access != nil && r.Parent.Access.Settings != nil && r.Parent.Access.Settings.AllowUserRequests:
+		m.AccessRight = "restricted"
+		m.AccessConditions = r.Parent.Access.Settings.AcceptConditionsText
+	default:
+		m.AccessRight = "closed"
+	}
+	if r.Parent != nil && r.Parent.Communities != nil {
+		if len(r.Parent.Communities.Entries) > 0 {
+			for _, e := range r.Parent.Communities.Entries {
+				m.Communities = append(m.Communities, model.CommunityRef{Identifier: e.Slug})
+			}
+		} else {
+			for _, id := range r.Parent.Communities.IDs {
+				m.Communities = append(m.Communities, model.CommunityRef{Identifier: id})
+			}
+		}
+	}
+
+	cf := r.CustomFields
+	if j := cf.Journal; j != nil {
+		m.JournalTitle, m.JournalVolume, m.JournalIssue, m.JournalPages = j.Title, j.Volume, j.Issue, j.Pages
+	}
+	if c := cf.Meeting; c != nil {
+		m.ConferenceTitle, m.ConferenceAcronym, m.ConferenceDates = c.Title, c.Acronym, c.Dates
+		m.ConferencePlace, m.ConferenceURL = c.Place, c.URL
+		m.ConferenceSession, m.ConferenceSessionPart = c.Session, c.SessionPart
+	}
+	if i := cf.Imprint; i != nil {
+		m.PartOfTitle, m.PartOfPages, m.ImprintISBN, m.ImprintPlace = i.Title, i.Pages, i.ISBN, i.Place
+		// Every Zenodo record has a publisher ("Zenodo" by default); it is
+		// only the imprint's publisher for books and chapters.
+		m.ImprintPublisher = md.Publisher
+	}
+	m.ThesisUniversity = cf.ThesisUniversity
+	return m
+}
+
+// rdmResourceType joins upload_type with its publication or image subtype,
+// as in "publication-article".
+func rdmResourceType(m model.Metadata) string {
+	switch {
+	case m.UploadType == "publication" && m.PublicationType != "":
+		return "publication-" + m.PublicationType
+	case m.UploadType == "image" && m.ImageType != "":
+		return "image-" + m.ImageType
+	}
+	return m.UploadType
+}
+
+// legacyUploadType splits an RDM resource type into upload_type and the
+// publication or image subtype. Other subtypes, which the legacy schema has
+// no field for, are dropped.
+func legacyUploadType(id string) (uploadType, publicationType, imageType string) {
+	uploadType, sub, _ := strings.Cut(id, "-")
+	switch uploadType {
+	case "publication":
+		publicationType = sub
+	case "image":
+		imageType = sub
+	}
+	return uploadType, publicationType, imageType
+}
+
+// rdmCreatorship maps a legacy creator or contributor. Legacy names are
+// "Family, Given" for people; a name without a comma is kept whole as the
+// family name, since the legacy schema cannot tell people from
+// organizations.
+func rdmCreatorship(name, orcid, gnd, affiliation, role string) model.RDMCreatorship {
+	p := model.RDMPersonOrOrg{Type: model.RDMPersonal, Name: name}
+	if family, given := splitName(name); family != "" {
+		p.FamilyName, p.GivenName = family, given
+	} else {
+		p.FamilyName = strings.TrimSpace(name)
+	}
+	if orcid != "" {
+		p.Identifiers = append(p.Identifiers, model.RDMIdentifier{Identifier: orcid, Scheme: "orcid"})
+	}
+	if gnd != "" {
+		p.Identifiers = append(p.Identifiers, model.RDMIdentifier{Identifier: gnd, Scheme: "gnd"})
+	}
+	c := model.RDMCreatorship{PersonOrOrg: p, Affiliations: rdmAffiliations(affiliation)}
+	if role != "" {
+		c.Role = &model.RDMVocabRef{ID: role}
+	}
+	return c
+}
+
+// rdmAffiliations splits a legacy affiliation on ";" and takes the ROR ID
+// out of "Name (https://ror.org/ID)".
+func rdmAffiliations(affiliation string) []model.RDMAffiliation {
+	var affs []model.RDMAffiliation
+	for _, part := range strings.Split(affiliation, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if m := rorAffiliation.FindStringSubmatch(part); m != nil {
+			affs = append(affs, model.RDMAffiliation{ID: m[2], Name: m[1]})
+			continue
+		}
+		affs = append(affs, model.RDMAffiliation{Name: part})
+	}
+	return affs
+}
+
+// legacyPerson maps an RDM creatorship to the legacy name, ORCID, GND and
+// affiliation, writing ROR-identified affiliations as "Name (https://ror.org/ID)".
+func legacyPerson(c model.RDMCreatorship) (name, orcid, gnd, affiliation string) {
+	p := c.PersonOrOrg
+	name = p.Name
+	if name == "" {
+		name = p.FamilyName
+		if p.GivenName != "" {
+			name += ", " + p.GivenName
+		}
+	}
+	affs := make([]string, 0, len(c.Affiliations))
+	for _, a := range c.Affiliations {
+		switch {
+		case a.ID != "" && a.Name != "":
+			affs = append(affs, a.Name+" (https://ror.org/"+a.ID+")")
+		case a.ID != "":
+			affs = append(affs, "https://ror.org/"+a.ID)
+		default:
+			affs = append(affs, a.Name)
+		}
+	}
+	return name, p.Identifier("orcid"), p.Identifier("gnd"), strings.Join(affs, "; ")
+}
+
+// depositSpelling returns a vocabulary ID as the deposit API spells it
+// (isCitedBy, ContactPerson), or the ID itself if it is not in the
+// vocabulary.
+func depositSpelling(kind vocab.Kind, id string, upper bool) string {
+	if t, ok := vocab.Embedded(kind).Lookup(id); ok {
+		return t.Words(upper)
+	}
+	return id
+}
+
+// rdmFunding converts a legacy grant ID to RDM funding, whose funders and
+// awards are keyed by ROR ID. A bare number is a European Commission grant.
+// A funder given by a Crossref Funder DOI that vocab.FunderROR does not know
+// is named by its DOI, with an inline award.
+func rdmFunding(grantID string) model.RDMFunding {
+	funder, number, ok := strings.Cut(grantID, "::")
+	if !ok {
+		funder, number = vocab.DefaultFunder, grantID
+	}
+	if ror, ok := vocab.FunderROR(funder); ok {
+		return model.RDMFunding{
+			Funder: model.RDMFunder{ID: ror},
+			Award:  &model.RDMAward{ID: ror + "::" + number},
+		}
+	}
+	return model.RDMFunding{
+		Funder: model.RDMFunder{Name: funder},
+		Award:  &model.RDMAward{Number: number, Title: map[string]string{"en": number}},
+	}
+}
+
+// legacyGrantID returns the "<funder>::<number>" grant ID of an award.
+func legacyGrantID(f model.RDMFunding) string {
+	if f.Award == nil {
+		return ""
+	}
+	switch {
+	case strings.Contains(f.Award.ID, "::"):
+		return f.Award.ID
+	case f.Funder.ID != "" && f.Award.Number != "":
+		return f.Funder.ID + "::" + f.Award.Number
+	case strings.HasPrefix(f.Funder.Name, "10.13039/") && f.Award.Number != "":
+		// A funder outside the vocabulary, named by its DOI by rdmFunding.
+		return f.Funder.Name + "::" + f.Award.Number
+	}
+	return ""
+}
+
+// rdmCustomFields carries the legacy journal, conference, imprint and
+// thesis fields.
+func rdmCustomFields(m model.Metadata) model.RDMCustomFields {
+	var cf model.RDMCustomFields
+	if j := (model.RDMJournal{Title: m.JournalTitle, Volume: m.JournalVolume, Issue: m.JournalIssue, Pages: m.JournalPages}); j != (model.RDMJournal{}) {
+		cf.Journal = &j
+	}
+	c := model.RDMMeeting{
+		Title: m.ConferenceTitle, Acronym: m.ConferenceAcronym, Dates: m.ConferenceDates,
+		Place: m.ConferencePlace, URL: m.ConferenceURL,
+		Session: m.ConferenceSession, SessionPart: m.ConferenceSessionPart,
+	}
+	if c != (model.RDMMeeting{}) {
+		cf.Meeting = &c
+	}
+	if i := (model.RDMImprint{Title: m.PartOfTitle, Pages: m.PartOfPages, ISBN: m.ImprintISBN, Place: m.ImprintPlace}); i != (model.RDMImprint{}) || m.ImprintPublisher != "" {
+		cf.Imprint = &i
+	}
+	cf.ThesisUniversity = m.ThesisUniversity
+	return cf
+}
+
+// MergeRDM applies legacy metadata m to the RDM record base, for updating
+// a draft from metadata edited in the legacy schema. Each legacy field
+// that m changes replaces the corresponding RDM field; fields m leaves as
+// FromRDM(base) has them keep their RDM form. RDM fields the legacy schema
+// cannot express (additional titles, dates, locations, alternate
+// identifiers, sizes, formats, copyright, other custom fields) are kept.
+func MergeRDM(base *model.RDMRecord, m model.Metadata) *model.RDMRecord {
+	old := FromRDM(base)
+	next := ToRDM(m)
+	merged := *base
+	md, bmd, nmd := &merged.Metadata, base.Metadata, next.Metadata
+
+	md.Title, md.Description, md.PublicationDate, md.Version = nmd.Title, nmd.Description, nmd.PublicationDate, nmd.Version
+	if old.UploadType != m.UploadType || old.PublicationType != m.PublicationType || old.ImageType != m.ImageType {
+		md.ResourceType = nmd.ResourceType
+	}
+	if !reflect.DeepEqual(old.Creators, m.Creators) {
+		md.Creators = nmd.Creators
+	}
+	if !reflect.DeepEqual(old.Contributors, m.Contributors) || !reflect.DeepEqual(old.ThesisSupervisors, m.ThesisSupervisors) {
+		md.Contributors = nmd.Contributors
+	}
+	if old.LicenseString() != m.LicenseString() {
+		md.Rights = nmd.Rights
+	}
+	if !reflect.DeepEqual(old.Keywords, m.Keywords) || !reflect.DeepEqual(old.Subjects, m.Subjects) {
+		md.Subjects = nmd.Subjects
+	}
+	if old.Language != m.Language {
+		md.Languages = nmd.Languages
+	}
+	if old.Notes != m.Notes {
+		md.AdditionalDescriptions = nil
+		for _, d := range bmd.AdditionalDescriptions {
+			if d.Type.ID != rdmNotesType {
+				md.AdditionalDescriptions = append(md.AdditionalDescriptions, d)
+			}
+		}
+		md.AdditionalDescriptions = append(md.AdditionalDescriptions, nmd.AdditionalDescriptions...)
+	}
+	if !reflect.DeepEqual(old.RelatedIdentifiers, m.RelatedIdentifiers) {
+		md.RelatedIdentifiers = nmd.RelatedIdentifiers
+	}
+	if !reflect.DeepEqual(old.Grants, m.Grants) {
+		// Funders without an award have no legacy form; keep them.
+		md.Funding = nil
+		for _, f := range bmd.Funding {
+			if legacyGrantID(f) == "" {
+				md.Funding = append(md.Funding, f)
+			}
+		}
+		md.Funding = append(md.Funding, nmd.Funding...)
+	}
+	if !reflect.DeepEqual(old.References, m.References) {
+		md.References = nmd.References
+	}
+	if old.ImprintPublisher != m.ImprintPublisher {
+		md.Publisher = nmd.Publisher
+	}
+
+	if old.DOI != m.DOI {
+		merged.PIDs = make(map[string]model.RDMPID, len(base.PIDs))
+		for scheme, pid := range base.PIDs {
+			if scheme != "doi" {
+				merged.PIDs[scheme] = pid
+			}
+		}
+		for scheme, pid := range next.PIDs {
+			merged.PIDs[scheme] = pid
+		}
+	}
+	if old.AccessRight != m.AccessRight || old.EmbargoDate != m.EmbargoDate || old.AccessConditions != m.AccessConditions {
+		merged.Access = next.Access
+		if next.Parent != nil && next.Parent.Access != nil {
+			parent := model.RDMParent{}
+			if base.Parent != nil {
+				parent = *base.Parent
+			}
+			parent.Access = next.Parent.Access
+			merged.Parent = &parent
+		}
+	}
+
+	if !reflect.DeepEqual(rdmCustomFields(old), next.CustomFields) {
+		cf := next.CustomFields
+		if cf.Journal != nil && base.CustomFields.Journal != nil {
+			cf.Journal.ISSN = base.CustomFields.Journal.ISSN
+		}
+		cf.Other = base.CustomFields.Other
+		merged.CustomFields = cf
+	}
+	return &merged
+}
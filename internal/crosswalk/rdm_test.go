@@ -0,0 +1,278 @@
+package crosswalk
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// rdmRecordJSON is a trimmed /records/{id} response in the InvenioRDM
+// serialization.
+const rdmRecordJSON = `{
+  "id": "123",
+  "pids": {"doi": {"identifier": "10.5281/zenodo.123", "provider": "datacite", "client": "datacite"}},
+  "metadata": {
+    "resource_type": {"id": "publication-article", "title": {"en": "Journal article"}},
+    "creators": [
+      {"person_or_org": {"type": "personal", "name": "Doe, Jane", "given_name": "Jane", "family_name": "Doe",
+        "identifiers": [{"identifier": "0000-0002-1825-0097", "scheme": "orcid"}]},
+       "affiliations": [{"id": "01ggx4157", "name": "CERN"}, {"name": "Example University"}]},
+      {"person_or_org": {"type": "organizational", "name": "Soil Consortium"}}
+    ],
+    "title": "Soil Moisture",
+    "publication_date": "2024-03-01",
+    "description": "<p>Readings.</p>",
+    "additional_descriptions": [{"description": "Calibrated weekly.", "type": {"id": "other", "title": {"en": "Other"}}}],
+    "rights": [{"id": "cc-by-4.0", "title": {"en": "Creative Commons Attribution 4.0 International"}}],
+    "contributors": [
+      {"person_or_org": {"type": "personal", "name": "Roe, Richard", "family_name": "Roe", "given_name": "Richard"},
+       "role": {"id": "contactperson", "title": {"en": "Contact person"}}}
+    ],
+    "subjects": [{"subject": "soil"}, {"id": "https://id.loc.gov/authorities/subjects/sh85124094", "subject": "Soil moisture", "scheme": "url"}],
+    "languages": [{"id": "eng", "title": {"en": "English"}}],
+    "dates": [{"date": "2023-01/2023-12", "type": {"id": "collected"}}],
+    "version": "1.2",
+    "publisher": "Zenodo",
+    "related_identifiers": [{"identifier": "10.1234/paper", "scheme": "doi", "relation_type": {"id": "iscitedby"}}],
+    "locations": {"features": [{"geometry": {"type": "Point", "coordinates": [6.05, 46.23]}, "place": "Geneva"}]},
+    "funding": [
+      {"funder": {"id": "00k4n6c32", "name": "European Commission"},
+       "award": {"id": "00k4n6c32::101000987", "number": "101000987", "title": {"en": "SOIL"}}},
+      {"funder": {"name": "A foundation"}}
+    ],
+    "references": [{"reference": "Doe J. (2020) Soil."}]
+  },
+  "custom_fields": {"journal:journal": {"title": "Soil Science", "volume": "12", "issue": "3", "pages": "1-10"}},
+  "access": {"record": "public", "files": "restricted", "embargo": {"active": true, "until": "2030-01-01"}},
+  "files": {"enabled": true, "count": 2, "total_bytes": 2048},
+  "parent": {"id": "122", "communities": {"ids": ["c0ffee"], "entries": [{"id": "c0ffee", "slug": "soil"}]}},
+  "versions": {"index": 2, "is_latest": true},
+  "status": "published",
+  "is_published": true,
+  "created": "2024-03-01T10:00:00.123456+00:00",
+  "links": {"self_html": "https://zenodo.org/records/123"}
+}`
+
+func TestFromRDM(t *testing.T) {
+	var r model.RDMRecord
+	if err := json.Unmarshal([]byte(rdmRecordJSON), &r); err != nil {
+		t.Fatal(err)
+	}
+	m := FromRDM(&r)
+
+	if m.UploadType != "publication" || m.PublicationType != "article" {
+		t.Errorf("upload type = %q/%q", m.UploadType, m.PublicationType)
+	}
+	if m.DOI != "10.5281/zenodo.123" || m.LicenseString() != "cc-by-4.0" || m.Language != "eng" {
+		t.Errorf("doi %q, license %q, language %q", m.DOI, m.LicenseString(), m.Language)
+	}
+	wantCreators := []model.Creator{
+		{Name: "Doe, Jane", ORCID: "0000-0002-1825-0097", Affiliation: "CERN (https://ror.org/01ggx4157); Example University"},
+		{Name: "Soil Consortium"},
+	}
+	if !reflect.DeepEqual(m.Creators, wantCreators) {
+		t.Errorf("creators = %+v", m.Creators)
+	}
+	if len(m.Contributors) != 1 || m.Contributors[0].Type != "ContactPerson" {
+		t.Errorf("contributors = %+v", m.Contributors)
+	}
+	if len(m.RelatedIdentifiers) != 1 || m.RelatedIdentifiers[0].Relation != "isCitedBy" {
+		t.Errorf("related identifiers = %+v", m.RelatedIdentifiers)
+	}
+	if !reflect.DeepEqual(m.Keywords, []string{"soil"}) || len(m.Subjects) != 1 || m.Subjects[0].Term != "Soil moisture" {
+		t.Errorf("keywords = %v, subjects = %+v", m.Keywords, m.Subjects)
+	}
+	if len(m.Grants) != 1 || m.Grants[0].ID != "00k4n6c32::101000987" {
+		t.Errorf("grants = %+v", m.Grants)
+	}
+	if m.AccessRight != "embargoed" || m.EmbargoDate != "2030-01-01" {
+		t.Errorf("access = %q until %q", m.AccessRight, m.EmbargoDate)
+	}
+	if len(m.Communities) != 1 || m.Communities[0].Slug() != "soil" {
+		t.Errorf("communities = %+v", m.Communities)
+	}
+	if m.Notes != "Calibrated weekly." || m.JournalTitle != "Soil Science" || m.JournalPages != "1-10" {
+		t.Errorf("notes %q, journal %q %q", m.Notes, m.JournalTitle, m.JournalPages)
+	}
+	if m.ImprintPublisher != "" {
+		t.Errorf("imprint publisher = %q, want none without an imprint", m.ImprintPublisher)
+	}
+	if !reflect.DeepEqual(m.References, []string{"Doe J. (2020) Soil."}) {
+		t.Errorf("references = %v", m.References)
+	}
+
+	// Fields only RDM has stay typed on the record.
+	if r.Metadata.Locations.Features[0].Place != "Geneva" || r.Metadata.Dates[0].Type.ID != "collected" {
+		t.Errorf("locations/dates not decoded: %+v", r.Metadata)
+	}
+	if r.Metadata.Creators[0].Affiliations[0].ID != "01ggx4157" || r.Files.Count != 2 || !r.Versions.IsLatest {
+		t.Errorf("record not decoded: %+v", r)
+	}
+}
+
+func TestRDMRoundTrip(t *testing.T) {
+	m := model.Metadata{
+		Title:            "Thesis on soil",
+		Description:      "Abstract.",
+		UploadType:       "publication",
+		PublicationType:  "thesis",
+		PublicationDate:  "2024-05-01",
+		AccessRight:      "restricted",
+		AccessConditions: "Research use only.",
+		License:          json.RawMessage(`"cc-by-4.0"`),
+		DOI:              "10.1234/external",
+		Keywords:         []string{"soil"},
+		Notes:            "Defended in 2024.",
+		Version:          "1",
+		Language:         "deu",
+		Creators: []model.Creator{
+			{Name: "Doe, Jane", ORCID: "0000-0002-1825-0097", GND: "118540238", Affiliation: "CERN (https://ror.org/01ggx4157)"},
+		},
+		Contributors:      []model.Contributor{{Name: "Roe, Richard", Type: "DataCollector"}},
+		ThesisSupervisors: []model.Creator{{Name: "Smith, Anna"}},
+		RelatedIdentifiers: []model.RelatedIdentifier{
+			{Identifier: "10.1234/data", Relation: "isSupplementedBy", Scheme: "doi"},
+		},
+		Communities:       []model.CommunityRef{{Identifier: "soil"}},
+		Grants:            []model.Grant{{ID: "00k4n6c32::283595"}, {ID: "10.13039/100000050::R01HL123"}},
+		ConferenceTitle:   "Soil Days",
+		ConferenceAcronym: "SD24",
+		ImprintPublisher:  "University Press",
+		PartOfTitle:       "Collected Theses",
+		ThesisUniversity:  "Example University",
+		Subjects:          []model.Subject{{Term: "Soil", Identifier: "sh1", Scheme: "lcsh"}},
+		References:        []string{"Ref 1"},
+	}
+
+	r := ToRDM(m)
+	if r.Metadata.ResourceType.ID != "publication-thesis" || r.DOI() != "10.1234/external" || r.PIDs["doi"].Provider != "external" {
+		t.Errorf("resource type %+v, pids %+v", r.Metadata.ResourceType, r.PIDs)
+	}
+	if a := r.Metadata.Creators[0].Affiliations; len(a) != 1 || a[0].ID != "01ggx4157" || a[0].Name != "CERN" {
+		t.Errorf("affiliations = %+v", a)
+	}
+	if r.Access.Files != model.RDMRestricted || !r.Parent.Access.Settings.AllowUserRequests {
+		t.Errorf("access = %+v, parent = %+v", r.Access, r.Parent)
+	}
+	if f := r.Metadata.Funding; f[0].Funder.ID != "00k4n6c32" || f[0].Award.ID != "00k4n6c32::283595" || f[1].Funder.Name != "10.13039/100000050" || f[1].Award.Number != "R01HL123" {
+		t.Errorf("funding = %+v", r.Metadata.Funding)
+	}
+
+	// Through JSON, as a draft would travel.
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded model.RDMRecord
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if got := FromRDM(&decoded); !reflect.DeepEqual(got, m) {
+		gotJSON, _ := json.MarshalIndent(got, "", "  ")
+		wantJSON, _ := json.MarshalIndent(m, "", "  ")
+		t.Errorf("round trip:\n got %s\nwant %s", gotJSON, wantJSON)
+	}
+}
+
+func TestToRDM_Funding(t *testing.T) {
+	for _, tt := range []struct{ grant, funder, award string }{
+		{"283595", "00k4n6c32", "00k4n6c32::283595"},
+		{"10.13039/501100000780::283595", "00k4n6c32", "00k4n6c32::283595"},
+		{"10.13039/100000002::R01GM123456", "01cwqze88", "01cwqze88::R01GM123456"},
+		{"00k4n6c32::101000987", "00k4n6c32", "00k4n6c32::101000987"},
+	} {
+		m := model.Metadata{Grants: []model.Grant{{ID: tt.grant}}}
+		f := ToRDM(m).Metadata.Funding
+		if len(f) != 1 || f[0].Funder.ID != tt.funder || f[0].Award == nil || f[0].Award.ID != tt.award {
+			t.Errorf("grant %q: funding = %+v", tt.grant, f)
+		}
+	}
+}
+
+func TestFromRDM_AccessRights(t *testing.T) {
+	tests := []struct {
+		access model.RDMAccess
+		parent *model.RDMParent
+		want   string
+	}{
+		{model.RDMAccess{Record: "public", Files: "public"}, nil, "open"},
+		{model.RDMAccess{}, nil, "open"},
+		{model.RDMAccess{Record: "public", Files: "restricted"}, nil, "closed"},
+		{model.RDMAccess{Record: "restricted", Files: "restricted"}, nil, "closed"},
+		{model.RDMAccess{Files: "restricted", Embargo: &model.RDMEmbargo{Active: false, Until: "2020-01-01"}},
+			&model.RDMParent{Access: &model.RDMParentAccess{Settings: &model.RDMAccessSettings{AllowUserRequests: true}}}, "restricted"},
+	}
+	for _, tt := range tests {
+		r := &model.RDMRecord{Access: tt.access, Parent: tt.parent}
+		if got := FromRDM(r).AccessRight; got != tt.want {
+			t.Errorf("access %+v = %q, want %q", tt.access, got, tt.want)
+		}
+	}
+}
+
+func TestToRDM_OpenByDefault(t *testing.T) {
+	r := ToRDM(model.Metadata{Title: "x", UploadType: "dataset"})
+	if r.Access.Record != model.RDMPublic || r.Access.Files != model.RDMPublic || r.Parent != nil || r.PIDs != nil {
+		t.Errorf("record = %+v", r)
+	}
+	b, _ := json.Marshal(r)
+	var raw map[string]json.RawMessage
+	json.Unmarshal(b, &raw)
+	if _, ok := raw["custom_fields"]; ok {
+		t.Errorf("empty custom_fields serialized: %s", b)
+	}
+}
+
+func TestMergeRDM(t *testing.T) {
+	var base model.RDMRecord
+	if err := json.Unmarshal([]byte(rdmRecordJSON), &base); err != nil {
+		t.Fatal(err)
+	}
+	base.CustomFields.Journal.ISSN = "1234-5678"
+	base.CustomFields.Other = map[string]json.RawMessage{"code:codeRepository": json.RawMessage(`"https://example.org/repo"`)}
+
+	m := FromRDM(&base)
+	m.Title = "Soil Moisture, revised"
+	m.Keywords = append(m.Keywords, "hydrology")
+	m.JournalIssue = "4"
+	merged := MergeRDM(&base, m)
+
+	if merged.Metadata.Title != "Soil Moisture, revised" || len(merged.Metadata.Subjects) != 3 {
+		t.Errorf("changes not applied: %q, %+v", merged.Metadata.Title, merged.Metadata.Subjects)
+	}
+	// Unchanged fields keep their RDM form.
+	if merged.Metadata.Creators[1].PersonOrOrg.Type != model.RDMOrganizational {
+		t.Errorf("creator type = %q", merged.Metadata.Creators[1].PersonOrOrg.Type)
+	}
+	if merged.Metadata.Rights[0].Title["en"] == "" || merged.Access != base.Access || merged.Metadata.Publisher != "Zenodo" {
+		t.Errorf("rights %+v, access %+v, publisher %q", merged.Metadata.Rights, merged.Access, merged.Metadata.Publisher)
+	}
+	if len(merged.Metadata.Funding) != 2 {
+		t.Errorf("funding = %+v", merged.Metadata.Funding)
+	}
+	// RDM-only fields survive.
+	if len(merged.Metadata.Dates) != 1 || merged.Metadata.Locations == nil {
+		t.Errorf("dates %+v, locations %+v", merged.Metadata.Dates, merged.Metadata.Locations)
+	}
+	cf := merged.CustomFields
+	if cf.Journal.Issue != "4" || cf.Journal.ISSN != "1234-5678" || cf.Other["code:codeRepository"] == nil {
+		t.Errorf("custom fields = %+v, journal %+v", cf, cf.Journal)
+	}
+	b, _ := json.Marshal(cf)
+	if !strings.Contains(string(b), `"code:codeRepository"`) || !strings.Contains(string(b), `"journal:journal"`) {
+		t.Errorf("custom fields JSON = %s", b)
+	}
+	// The base record is not modified.
+	if base.Metadata.Title != "Soil Moisture" {
+		t.Errorf("base modified: %q", base.Metadata.Title)
+	}
+
+	// Changing the grants keeps funders without an award.
+	m.Grants = nil
+	if f := MergeRDM(&base, m).Metadata.Funding; len(f) != 1 || f[0].Award != nil {
+		t.Errorf("funding = %+v", f)
+	}
+}
@@ -0,0 +1,382 @@
+package crosswalk
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+	"github.com/ran-codes/zenodo-cli/internal/safepath"
+)
+
+// ROCrateMetadataFile is the name of an RO-Crate metadata descriptor file.
+const ROCrateMetadataFile = "ro-crate-metadata.json"
+
+const (
+	roCrateContext = "https://w3id.org/ro/crate/1.1/context"
+	roCrateSpec    = "https://w3id.org/ro/crate/1.1"
+)
+
+// Entity is a single JSON-LD node in an RO-Crate @graph.
+type Entity map[string]interface{}
+
+// ROCrate is an RO-Crate 1.1 metadata document.
+type ROCrate struct {
+	Context interface{} `json:"@context"`
+	Graph   []Entity    `json:"@graph"`
+}
+
+// relationProperties maps DataCite relation types to schema.org properties.
+// Relations without a close schema.org equivalent are exported as "mentions".
+var relationProperties = map[string]string{
+	"cites":          "citation",
+	"references":     "citation",
+	"isDerivedFrom":  "isBasedOn",
+	"isPartOf":       "isPartOf",
+	"isSupplementTo": "isPartOf",
+	"isVersionOf":    "isBasedOn",
+}
+
+// propertyRelations is the reverse of relationProperties used on import.
+var propertyRelations = map[string]string{
+	"citation":  "cites",
+	"isBasedOn": "isDerivedFrom",
+	"isPartOf":  "isPartOf",
+	"mentions":  "references",
+}
+
+// ToROCrate maps a record to an RO-Crate 1.1 metadata document. Files are
+// referenced by key, relative to the crate root.
+func ToROCrate(rec *model.Record) *ROCrate {
+	m := rec.Metadata
+	root := Entity{
+		"@id":   "./",
+		"@type": "Dataset",
+		"name":  m.Title,
+	}
+	if m.Description != "" {
+		root["description"] = m.Description
+	}
+	if m.PublicationDate != "" {
+		root["datePublished"] = m.PublicationDate
+	}
+	if doi := recordDOI(rec); doi != "" {
+		root["identifier"] = doiURL(doi)
+	}
+	if m.Version != "" {
+		root["version"] = m.Version
+	}
+	if len(m.Keywords) > 0 {
+		root["keywords"] = m.Keywords
+	}
+	if m.Language != "" {
+		root["inLanguage"] = m.Language
+	}
+
+	graph := []Entity{
+		{
+			"@id":        ROCrateMetadataFile,
+			"@type":      "CreativeWork",
+			"conformsTo": Entity{"@id": roCrateSpec},
+			"about":      Entity{"@id": "./"},
+		},
+		root,
+	}
+	seen := map[string]bool{}
+	addEntity := func(e Entity) {
+		id := e["@id"].(string)
+		if !seen[id] {
+			seen[id] = true
+			graph = append(graph, e)
+		}
+	}
+
+	if lic := m.LicenseString(); lic != "" {
+		id := licenseURL(lic)
+		root["license"] = Entity{"@id": id}
+		addEntity(Entity{"@id": id, "@type": "CreativeWork", "identifier": lic, "name": lic})
+	}
+
+	people := func(names []personRef) []Entity {
+		var refs []Entity
+		for _, p := range names {
+			person := personEntity(p)
+			if p.affiliation != "" {
+				org := Entity{"@id": "#" + slugify(p.affiliation), "@type": "Organization", "name": p.affiliation}
+				person["affiliation"] = Entity{"@id": org["@id"]}
+				addEntity(org)
+			}
+			addEntity(person)
+			refs = append(refs, Entity{"@id": person["@id"]})
+		}
+		return refs
+	}
+
+	var creators, contributors []personRef
+	for _, c := range m.Creators {
+		creators = append(creators, personRef{c.Name, c.ORCID, c.Affiliation})
+	}
+	for _, c := range m.Contributors {
+		contributors = append(contributors, personRef{c.Name, c.ORCID, c.Affiliation})
+	}
+	if refs := people(creators); len(refs) > 0 {
+		root["author"] = refs
+	}
+	if refs := people(contributors); len(refs) > 0 {
+		root["contributor"] = refs
+	}
+
+	related := map[string][]Entity{}
+	for _, ri := range m.RelatedIdentifiers {
+		prop, ok := relationProperties[ri.Relation]
+		if !ok {
+			prop = "mentions"
+		}
+		id := ri.Identifier
+		if ri.Scheme == "doi" {
+			id = doiURL(id)
+		}
+		related[prop] = append(related[prop], Entity{"@id": id})
+	}
+	for prop, refs := range related {
+		root[prop] = refs
+	}
+
+	var parts []Entity
+	for _, f := range rec.Files {
+		file := Entity{"@id": f.Key, "@type": "File", "name": f.Key, "contentSize": f.Size}
+		if sum := f.MD5(); sum != "" {
+			file["identifier"] = f.Checksum
+		}
+		addEntity(file)
+		parts = append(parts, Entity{"@id": f.Key})
+	}
+	if len(parts) > 0 {
+		root["hasPart"] = parts
+	}
+
+	return &ROCrate{Context: roCrateContext, Graph: graph}
+}
+
+type personRef struct {
+	name, orcid, affiliation string
+}
+
+func personEntity(p personRef) Entity {
+	id := orcidURL(p.orcid)
+	if id == "" {
+		id = "#" + slugify(p.name)
+	}
+	e := Entity{"@id": id, "@type": "Person", "name": p.name}
+	if family, given := splitName(p.name); family != "" {
+		e["familyName"] = family
+		e["givenName"] = given
+	}
+	return e
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+func slugify(s string) string {
+	return strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
+
+// FromROCrate maps an RO-Crate metadata document to deposit metadata. It
+// also returns the crate-relative paths of the root dataset's File parts,
+// and fails if one is absolute or leaves the crate directory.
+func FromROCrate(data []byte) (model.Metadata, []string, error) {
+	var crate ROCrate
+	if err := json.Unmarshal(data, &crate); err != nil {
+		return model.Metadata{}, nil, fmt.Errorf("parsing RO-Crate: %w", err)
+	}
+
+	byID := make(map[string]Entity, len(crate.Graph))
+	for _, e := range crate.Graph {
+		if id, ok := e["@id"].(string); ok {
+			byID[id] = e
+		}
+	}
+
+	rootID := "./"
+	if desc, ok := byID[ROCrateMetadataFile]; ok {
+		if id := refID(desc["about"]); id != "" {
+			rootID = id
+		}
+	}
+	root, ok := byID[rootID]
+	if !ok {
+		return model.Metadata{}, nil, fmt.Errorf("RO-Crate has no root data entity %q", rootID)
+	}
+
+	m := model.Metadata{
+		Title:           str(root["name"]),
+		Description:     str(root["description"]),
+		PublicationDate: str(root["datePublished"]),
+		Version:         str(root["version"]),
+		Language:        str(root["inLanguage"]),
+		UploadType:      "dataset",
+		AccessRight:     "open",
+	}
+	if len(m.PublicationDate) > 10 {
+		m.PublicationDate = m.PublicationDate[:10]
+	}
+	for _, t := range types(root) {
+		if t == "SoftwareSourceCode" || t == "SoftwareApplication" {
+			m.UploadType = "software"
+		}
+	}
+
+	switch kw := root["keywords"].(type) {
+	case string:
+		for _, k := range strings.Split(kw, ",") {
+			if k = strings.TrimSpace(k); k != "" {
+				m.Keywords = append(m.Keywords, k)
+			}
+		}
+	case []interface{}:
+		for _, k := range kw {
+			if s := str(k); s != "" {
+				m.Keywords = append(m.Keywords, s)
+			}
+		}
+	}
+
+	if lic := root["license"]; lic != nil {
+		id := refID(lic)
+		if id == "" {
+			id = str(lic)
+		}
+		if e, ok := byID[id]; ok && str(e["identifier"]) != "" && !strings.HasPrefix(str(e["identifier"]), "http") {
+			id = str(e["identifier"])
+		}
+		if id != "" {
+			b, _ := json.Marshal(licenseFromURL(id))
+			m.License = b
+		}
+	}
+
+	for _, ref := range refs(root["author"]) {
+		name, orcid, aff := resolvePerson(byID, ref)
+		m.Creators = append(m.Creators, model.Creator{Name: name, ORCID: orcid, Affiliation: aff})
+	}
+	for _, ref := range refs(root["contributor"]) {
+		name, orcid, aff := resolvePerson(byID, ref)
+		m.Contributors = append(m.Contributors, model.Contributor{Name: name, ORCID: orcid, Affiliation: aff, Type: "Other"})
+	}
+
+	for _, prop := range []string{"citation", "isBasedOn", "isPartOf", "mentions"} {
+		for _, id := range refs(root[prop]) {
+			m.RelatedIdentifiers = append(m.RelatedIdentifiers, relatedIdentifier(id, propertyRelations[prop]))
+		}
+	}
+
+	var files []string
+	for _, id := range refs(root["hasPart"]) {
+		e, ok := byID[id]
+		if !ok || strings.Contains(id, "://") || strings.HasPrefix(id, "#") {
+			continue
+		}
+		for _, t := range types(e) {
+			if t == "File" {
+				if _, err := safepath.Join(".", id); err != nil {
+					return model.Metadata{}, nil, fmt.Errorf("crate file: %w", err)
+				}
+				files = append(files, id)
+				break
+			}
+		}
+	}
+
+	return m, files, nil
+}
+
+// resolvePerson returns a contributor's name, ORCID and affiliation name.
+func resolvePerson(byID map[string]Entity, id string) (name, orcid, affiliation string) {
+	orcid = orcidFromURL(id)
+	e, ok := byID[id]
+	if !ok {
+		return id, orcid, ""
+	}
+	name = str(e["name"])
+	if name == "" && str(e["familyName"]) != "" {
+		name = str(e["familyName"]) + ", " + str(e["givenName"])
+	}
+	if orcid == "" {
+		orcid = orcidFromURL(str(e["identifier"]))
+	}
+	for _, affID := range refs(e["affiliation"]) {
+		if org, ok := byID[affID]; ok {
+			affiliation = str(org["name"])
+		} else {
+			affiliation = affID
+		}
+		break
+	}
+	if affiliation == "" {
+		affiliation = str(e["affiliation"])
+	}
+	return name, orcid, affiliation
+}
+
+// relatedIdentifier builds a related identifier, detecting DOI URLs.
+func relatedIdentifier(id, relation string) model.RelatedIdentifier {
+	for _, prefix := range []string{"https://doi.org/", "http://doi.org/", "https://dx.doi.org/"} {
+		if strings.HasPrefix(id, prefix) {
+			return model.RelatedIdentifier{Identifier: strings.TrimPrefix(id, prefix), Relation: relation, Scheme: "doi"}
+		}
+	}
+	scheme := ""
+	if strings.HasPrefix(id, "http://") || strings.HasPrefix(id, "https://") {
+		scheme = "url"
+	}
+	return model.RelatedIdentifier{Identifier: id, Relation: relation, Scheme: scheme}
+}
+
+// str returns v as a string, or "" if it is not one.
+func str(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+// refID returns the @id of a JSON-LD reference object.
+func refID(v interface{}) string {
+	if m, ok := v.(map[string]interface{}); ok {
+		return str(m["@id"])
+	}
+	return ""
+}
+
+// refs returns the @ids of a single reference or a list of references.
+// Plain strings are treated as ids.
+func refs(v interface{}) []string {
+	var out []string
+	switch x := v.(type) {
+	case []interface{}:
+		for _, item := range x {
+			out = append(out, refs(item)...)
+		}
+	case map[string]interface{}:
+		if id := str(x["@id"]); id != "" {
+			out = append(out, id)
+		}
+	case string:
+		out = append(out, x)
+	}
+	return out
+}
+
+// types returns an entity's @type as a list.
+func types(e Entity) []string {
+	switch t := e["@type"].(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		var out []string
+		for _, v := range t {
+			out = append(out, str(v))
+		}
+		return out
+	}
+	return nil
+}
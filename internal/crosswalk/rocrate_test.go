@@ -0,0 +1,158 @@
+package crosswalk
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+func sampleRecord() *model.Record {
+	return &model.Record{
+		ID:  42,
+		DOI: "10.5281/zenodo.42",
+		Metadata: model.Metadata{
+			Title:           "Soil Moisture Observations",
+			Description:     "Daily soil moisture readings.",
+			PublicationDate: "2024-03-01",
+			ResourceType:    &model.ResourceType{Type: "dataset"},
+			License:         json.RawMessage(`{"id": "cc-by-4.0"}`),
+			Keywords:        []string{"soil", "hydrology"},
+			Version:         "1.2",
+			Creators: []model.Creator{
+				{Name: "Doe, Jane", ORCID: "0000-0002-1825-0097", Affiliation: "Example University"},
+				{Name: "Roe, Richard"},
+			},
+			RelatedIdentifiers: []model.RelatedIdentifier{
+				{Identifier: "10.1234/paper", Relation: "isSupplementTo", Scheme: "doi"},
+				{Identifier: "https://example.org/code", Relation: "isDocumentedBy", Scheme: "url"},
+			},
+		},
+		Files: []model.File{{Key: "obs.csv", Size: 100, Checksum: "md5:abc"}},
+	}
+}
+
+func findEntity(crate *ROCrate, id string) Entity {
+	for _, e := range crate.Graph {
+		if e["@id"] == id {
+			return e
+		}
+	}
+	return nil
+}
+
+func TestToROCrate(t *testing.T) {
+	crate := ToROCrate(sampleRecord())
+
+	if crate.Context != roCrateContext {
+		t.Errorf("context = %v", crate.Context)
+	}
+	root := findEntity(crate, "./")
+	if root == nil {
+		t.Fatal("root data entity missing")
+	}
+	if root["identifier"] != "https://doi.org/10.5281/zenodo.42" {
+		t.Errorf("identifier = %v", root["identifier"])
+	}
+	if lic := root["license"].(Entity); lic["@id"] != "https://spdx.org/licenses/CC-BY-4.0" {
+		t.Errorf("license = %v", lic)
+	}
+
+	person := findEntity(crate, "https://orcid.org/0000-0002-1825-0097")
+	if person == nil || person["familyName"] != "Doe" {
+		t.Fatalf("person entity = %v", person)
+	}
+	if aff := person["affiliation"].(Entity); findEntity(crate, aff["@id"].(string)) == nil {
+		t.Errorf("affiliation entity missing for %v", aff)
+	}
+	if findEntity(crate, "#roe-richard") == nil {
+		t.Error("person without ORCID should get a local id")
+	}
+	if f := findEntity(crate, "obs.csv"); f == nil || f["@type"] != "File" {
+		t.Errorf("file entity = %v", f)
+	}
+}
+
+func TestROCrateRoundTrip(t *testing.T) {
+	data, err := json.Marshal(ToROCrate(sampleRecord()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, files, err := FromROCrate(data)
+	if err != nil {
+		t.Fatalf("FromROCrate() error: %v", err)
+	}
+	if m.Title != "Soil Moisture Observations" || m.PublicationDate != "2024-03-01" || m.Version != "1.2" {
+		t.Errorf("unexpected metadata: %+v", m)
+	}
+	if m.LicenseString() != "cc-by-4.0" {
+		t.Errorf("license = %q", m.LicenseString())
+	}
+	if len(m.Creators) != 2 || m.Creators[0].ORCID != "0000-0002-1825-0097" || m.Creators[0].Affiliation != "Example University" {
+		t.Errorf("creators = %+v", m.Creators)
+	}
+	if len(m.Keywords) != 2 {
+		t.Errorf("keywords = %v", m.Keywords)
+	}
+	if len(m.RelatedIdentifiers) != 2 || m.RelatedIdentifiers[0].Scheme == "" {
+		t.Errorf("related = %+v", m.RelatedIdentifiers)
+	}
+	if len(files) != 1 || files[0] != "obs.csv" {
+		t.Errorf("files = %v", files)
+	}
+}
+
+func TestFromROCrate_Handwritten(t *testing.T) {
+	data := []byte(`{
+	  "@context": "https://w3id.org/ro/crate/1.1/context",
+	  "@graph": [
+	    {"@id": "ro-crate-metadata.json", "@type": "CreativeWork", "about": {"@id": "./"}},
+	    {"@id": "./", "@type": ["Dataset", "SoftwareSourceCode"], "name": "Workflow run",
+	     "datePublished": "2024-05-01T10:00:00Z", "keywords": "a, b",
+	     "license": "MIT", "author": {"@id": "#alice"},
+	     "hasPart": [{"@id": "out/result.txt"}, {"@id": "https://example.org/remote"}]},
+	    {"@id": "#alice", "@type": "Person", "name": "Alice", "identifier": "https://orcid.org/0000-0001-2345-6789"},
+	    {"@id": "out/result.txt", "@type": "File"}
+	  ]
+	}`)
+
+	m, files, err := FromROCrate(data)
+	if err != nil {
+		t.Fatalf("FromROCrate() error: %v", err)
+	}
+	if m.UploadType != "software" {
+		t.Errorf("upload_type = %q", m.UploadType)
+	}
+	if m.PublicationDate != "2024-05-01" {
+		t.Errorf("publication_date = %q", m.PublicationDate)
+	}
+	if m.LicenseString() != "mit" {
+		t.Errorf("license = %q", m.LicenseString())
+	}
+	if len(m.Creators) != 1 || m.Creators[0].ORCID != "0000-0001-2345-6789" {
+		t.Errorf("creators = %+v", m.Creators)
+	}
+	if len(files) != 1 || files[0] != "out/result.txt" {
+		t.Errorf("files = %v", files)
+	}
+}
+
+func TestFromROCrate_Invalid(t *testing.T) {
+	if _, _, err := FromROCrate([]byte(`{"@graph": []}`)); err == nil {
+		t.Error("expected error for crate without root entity")
+	}
+}
+
+func TestFromROCrate_UnsafePath(t *testing.T) {
+	for _, id := range []string{"../../../etc/passwd", "/etc/passwd", "data/../../secret.txt"} {
+		crate := `{"@graph": [
+			{"@id": "ro-crate-metadata.json", "@type": "CreativeWork", "about": {"@id": "./"}},
+			{"@id": "./", "@type": "Dataset", "name": "Escape", "hasPart": [{"@id": "` + id + `"}]},
+			{"@id": "` + id + `", "@type": "File"}
+		]}`
+		if _, files, err := FromROCrate([]byte(crate)); err == nil {
+			t.Errorf("FromROCrate accepted %q: files = %v", id, files)
+		}
+	}
+}
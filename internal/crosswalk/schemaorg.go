@@ -0,0 +1,154 @@
+package crosswalk
+
+import (
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// schemaOrgType picks the schema.org type for a record.
+func schemaOrgType(m model.Metadata) string {
+	switch {
+	case isSoftware(m):
+		return "SoftwareSourceCode"
+	case resourceType(m) == "dataset":
+		return "Dataset"
+	default:
+		return "CreativeWork"
+	}
+}
+
+// ToSchemaOrg maps a record to a schema.org JSON-LD object, typed as
+// Dataset, SoftwareSourceCode or CreativeWork depending on the resource type.
+func ToSchemaOrg(rec *model.Record) map[string]interface{} {
+	obj := schemaOrgNode(rec)
+	obj["@context"] = "https://schema.org"
+	return obj
+}
+
+// ToSchemaOrgCatalog wraps several records in a schema.org DataCatalog.
+func ToSchemaOrgCatalog(name, url string, records []model.Record) map[string]interface{} {
+	items := make([]map[string]interface{}, 0, len(records))
+	for i := range records {
+		items = append(items, schemaOrgNode(&records[i]))
+	}
+	catalog := map[string]interface{}{
+		"@context": "https://schema.org",
+		"@type":    "DataCatalog",
+		"name":     name,
+		"dataset":  items,
+	}
+	if url != "" {
+		catalog["@id"] = url
+		catalog["url"] = url
+	}
+	return catalog
+}
+
+// schemaOrgNode builds the schema.org object for a record without @context.
+func schemaOrgNode(rec *model.Record) map[string]interface{} {
+	m := rec.Metadata
+	typ := schemaOrgType(m)
+	obj := map[string]interface{}{
+		"@type": typ,
+		"name":  m.Title,
+	}
+
+	if doi := recordDOI(rec); doi != "" {
+		obj["@id"] = doiURL(doi)
+		obj["identifier"] = map[string]interface{}{
+			"@type":      "PropertyValue",
+			"propertyID": "DOI",
+			"value":      doi,
+			"url":        doiURL(doi),
+		}
+	}
+	if rec.Links.HTML != "" {
+		obj["url"] = rec.Links.HTML
+	}
+	if m.Description != "" {
+		obj["description"] = m.Description
+	}
+	if m.PublicationDate != "" {
+		obj["datePublished"] = m.PublicationDate
+	}
+	if !rec.Updated.IsZero() {
+		obj["dateModified"] = rec.Updated.Format("2006-01-02")
+	}
+	if m.Version != "" {
+		obj["version"] = m.Version
+	}
+	if len(m.Keywords) > 0 {
+		obj["keywords"] = m.Keywords
+	}
+	if m.Language != "" {
+		obj["inLanguage"] = m.Language
+	}
+	if lic := m.LicenseString(); lic != "" {
+		obj["license"] = licenseURL(lic)
+	}
+	if m.AccessRight != "" {
+		obj["isAccessibleForFree"] = m.AccessRight == "open"
+	}
+
+	var creators, contributors []interface{}
+	for _, c := range m.Creators {
+		creators = append(creators, schemaOrgPerson(c.Name, c.ORCID, c.Affiliation))
+	}
+	for _, c := range m.Contributors {
+		contributors = append(contributors, schemaOrgPerson(c.Name, c.ORCID, c.Affiliation))
+	}
+	if len(creators) > 0 {
+		obj["creator"] = creators
+	}
+	if len(contributors) > 0 {
+		obj["contributor"] = contributors
+	}
+
+	var citations []string
+	for _, ri := range m.RelatedIdentifiers {
+		if ri.Relation == "cites" || ri.Relation == "references" {
+			id := ri.Identifier
+			if ri.Scheme == "doi" {
+				id = doiURL(id)
+			}
+			citations = append(citations, id)
+		}
+	}
+	if len(citations) > 0 {
+		obj["citation"] = citations
+	}
+
+	// Google Dataset Search reads downloads from distribution; other
+	// types have no distribution property.
+	if typ == "Dataset" && len(rec.Files) > 0 {
+		var dist []interface{}
+		for _, f := range rec.Files {
+			dist = append(dist, map[string]interface{}{
+				"@type":       "DataDownload",
+				"name":        f.Key,
+				"contentUrl":  f.DownloadURL(),
+				"contentSize": f.Size,
+			})
+		}
+		obj["distribution"] = dist
+	}
+
+	return obj
+}
+
+func schemaOrgPerson(name, orcid, affiliation string) map[string]interface{} {
+	p := map[string]interface{}{
+		"@type": "Person",
+		"name":  name,
+	}
+	if orcid != "" {
+		p["@id"] = orcidURL(orcid)
+	}
+	if family, given := splitName(name); family != "" {
+		p["familyName"] = family
+		p["givenName"] = given
+	}
+	if affiliation != "" {
+		p["affiliation"] = map[string]interface{}{"@type": "Organization", "name": affiliation}
+	}
+	return p
+}
@@ -0,0 +1,57 @@
+package crosswalk
+
+import (
+	"testing"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+func TestToSchemaOrg_Dataset(t *testing.T) {
+	rec := sampleRecord()
+	rec.Files[0].Links.Self = "https://zenodo.org/api/records/42/files/obs.csv/content"
+	obj := ToSchemaOrg(rec)
+
+	if obj["@context"] != "https://schema.org" || obj["@type"] != "Dataset" {
+		t.Errorf("context/type = %v/%v", obj["@context"], obj["@type"])
+	}
+	if obj["@id"] != "https://doi.org/10.5281/zenodo.42" {
+		t.Errorf("@id = %v", obj["@id"])
+	}
+	if obj["license"] != "https://spdx.org/licenses/CC-BY-4.0" {
+		t.Errorf("license = %v", obj["license"])
+	}
+	creators := obj["creator"].([]interface{})
+	first := creators[0].(map[string]interface{})
+	if first["@id"] != "https://orcid.org/0000-0002-1825-0097" || first["givenName"] != "Jane" {
+		t.Errorf("creator = %v", first)
+	}
+	dist := obj["distribution"].([]interface{})
+	if dist[0].(map[string]interface{})["contentUrl"] != rec.Files[0].Links.Self {
+		t.Errorf("distribution = %v", dist)
+	}
+}
+
+func TestToSchemaOrg_Software(t *testing.T) {
+	rec := sampleRecord()
+	rec.Metadata.ResourceType = &model.ResourceType{Type: "software"}
+	obj := ToSchemaOrg(rec)
+	if obj["@type"] != "SoftwareSourceCode" {
+		t.Errorf("@type = %v", obj["@type"])
+	}
+	if _, ok := obj["distribution"]; ok {
+		t.Error("software should not have a distribution")
+	}
+}
+
+func TestToSchemaOrgCatalog(t *testing.T) {
+	records := []model.Record{*sampleRecord(), *sampleRecord()}
+	catalog := ToSchemaOrgCatalog("My Community", "https://zenodo.org/communities/mine", records)
+	if catalog["@type"] != "DataCatalog" {
+		t.Errorf("@type = %v", catalog["@type"])
+	}
+	if items := catalog["dataset"].([]map[string]interface{}); len(items) != 2 {
+		t.Errorf("dataset count = %d", len(items))
+	} else if _, ok := items[0]["@context"]; ok {
+		t.Error("nested datasets should not repeat @context")
+	}
+}
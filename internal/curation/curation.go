@@ -0,0 +1,151 @@
+// Package curation selects the requests a community has received for
+// review in bulk, such as accepting every open submission whose record
+// has a creator with a known ORCID iD.
+package curation
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+	"github.com/ran-codes/zenodo-cli/internal/validate"
+)
+
+// pageSize is the page size used to list a community's requests.
+const pageSize = 100
+
+// maxPages bounds a listing, in case an API never reports the total.
+const maxPages = 100
+
+// Filter selects requests. Zero fields match every request.
+type Filter struct {
+	// Type is a request type, model.RequestCommunitySubmission or
+	// model.RequestCommunityInclusion.
+	Type string
+	// ORCIDs matches requests whose record has a creator or contributor
+	// with one of these ORCID iDs.
+	ORCIDs []string
+}
+
+// Match is a request selected by a filter.
+type Match struct {
+	Request model.Request `json:"request"`
+	// Title is the record's title, if it was fetched.
+	Title string `json:"title,omitempty"`
+	// ORCIDs are the record's ORCID iDs that matched the filter.
+	ORCIDs []string `json:"orcids,omitempty"`
+}
+
+// List returns all of a community's requests, or only the open ones.
+func List(client *api.Client, communityID, q string, open bool) ([]model.Request, error) {
+	var reqs []model.Request
+	for page := 1; page <= maxPages; page++ {
+		res, err := client.ListCommunityRequests(communityID, q, open, page, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, res.Hits.Hits...)
+		if len(res.Hits.Hits) < pageSize || len(reqs) >= res.Hits.Total {
+			break
+		}
+	}
+	return reqs, nil
+}
+
+// Record fetches the record a request is about: the draft under review for
+// a submission, or the published record for an inclusion request.
+func Record(client *api.Client, req *model.Request) (*model.RDMRecord, error) {
+	id := req.RecordID()
+	if id == "" {
+		return nil, fmt.Errorf("request %s is not about a record", req.ID)
+	}
+	if req.Type == model.RequestCommunitySubmission {
+		return client.GetDraft(id)
+	}
+	return client.GetRDMRecordByID(id)
+}
+
+// Select returns the requests that match f. Records are only fetched when
+// f filters on ORCID iDs.
+func Select(client *api.Client, reqs []model.Request, f Filter) ([]Match, error) {
+	var matches []Match
+	for _, req := range reqs {
+		if f.Type != "" && req.Type != f.Type {
+			continue
+		}
+		if len(f.ORCIDs) == 0 {
+			matches = append(matches, Match{Request: req})
+			continue
+		}
+		rec, err := Record(client, &req)
+		if err != nil {
+			return nil, fmt.Errorf("request %s: fetching record %s: %w", req.ID, req.RecordID(), err)
+		}
+		if found := matchORCIDs(rec, f.ORCIDs); len(found) > 0 {
+			matches = append(matches, Match{Request: req, Title: rec.Metadata.Title, ORCIDs: found})
+		}
+	}
+	return matches, nil
+}
+
+// matchORCIDs returns the ORCID iDs of rec's creators and contributors
+// that are in orcids.
+func matchORCIDs(rec *model.RDMRecord, orcids []string) []string {
+	var found []string
+	people := append(slices.Clone(rec.Metadata.Creators), rec.Metadata.Contributors...)
+	for _, p := range people {
+		id := NormalizeORCID(p.PersonOrOrg.Identifier("orcid"))
+		if id != "" && slices.Contains(orcids, id) && !slices.Contains(found, id) {
+			found = append(found, id)
+		}
+	}
+	return found
+}
+
+// NormalizeORCID returns an ORCID iD without its orcid.org URL prefix.
+func NormalizeORCID(s string) string {
+	s = strings.TrimSpace(s)
+	for _, prefix := range []string{"https://orcid.org/", "http://orcid.org/", "orcid.org/"} {
+		s = strings.TrimPrefix(s, prefix)
+	}
+	return strings.ToUpper(s)
+}
+
+// ParseORCIDs validates and normalizes ORCID iDs.
+func ParseORCIDs(ids []string) ([]string, error) {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = NormalizeORCID(id)
+		if err := validate.ORCID(id); err != nil {
+			return nil, err
+		}
+		out = append(out, id)
+	}
+	return out, nil
+}
+
+// ReadIDs reads IDs one per line, skipping blank lines and #-comments.
+func ReadIDs(r io.Reader) ([]string, error) {
+	var ids []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			ids = append(ids, line)
+		}
+	}
+	return ids, scanner.Err()
+}
+
+// ReadORCIDs reads ORCID iDs as ReadIDs does and validates them.
+func ReadORCIDs(r io.Reader) ([]string, error) {
+	ids, err := ReadIDs(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParseORCIDs(ids)
+}
@@ -0,0 +1,72 @@
+package curation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+const knownORCID = "0000-0002-1825-0097"
+
+func TestSelect(t *testing.T) {
+	var fetched []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched = append(fetched, r.URL.Path)
+		switch r.URL.Path {
+		case "/records/known/draft":
+			w.Write([]byte(`{"id": "known", "metadata": {"title": "Soil", "creators": [{"person_or_org":
+				{"type": "personal", "family_name": "Doe", "identifiers": [{"scheme": "orcid", "identifier": "https://orcid.org/` + knownORCID + `"}]}}]}}`))
+		case "/records/stranger":
+			w.Write([]byte(`{"id": "stranger", "metadata": {"title": "Spam", "creators": [{"person_or_org": {"type": "personal", "family_name": "Roe"}}]}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	client := api.NewClient(srv.URL, "tok")
+
+	reqs := []model.Request{
+		{ID: "r-1", Type: model.RequestCommunitySubmission, Topic: map[string]string{"record": "known"}},
+		{ID: "r-2", Type: model.RequestCommunityInclusion, Topic: map[string]string{"record": "stranger"}},
+	}
+
+	matches, err := Select(client, reqs, Filter{Type: model.RequestCommunityInclusion})
+	if err != nil || len(matches) != 1 || matches[0].Request.ID != "r-2" || len(fetched) != 0 {
+		t.Errorf("type filter: matches = %+v, err = %v, fetched = %v", matches, err, fetched)
+	}
+
+	matches, err = Select(client, reqs, Filter{ORCIDs: []string{knownORCID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Request.ID != "r-1" || matches[0].Title != "Soil" || matches[0].ORCIDs[0] != knownORCID {
+		t.Errorf("ORCID filter: matches = %+v", matches)
+	}
+	if len(fetched) != 2 {
+		t.Errorf("fetched = %v", fetched)
+	}
+}
+
+func TestReadIDs(t *testing.T) {
+	ids, err := ReadIDs(strings.NewReader("# records\n123\n\n  456  # second\n"))
+	if err != nil || strings.Join(ids, ",") != "123,456" {
+		t.Errorf("ReadIDs = %v, %v", ids, err)
+	}
+}
+
+func TestReadORCIDs(t *testing.T) {
+	ids, err := ReadORCIDs(strings.NewReader("# lab members\nhttps://orcid.org/" + knownORCID + "\n\n0000-0002-9079-593x  # Jane\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != knownORCID || ids[1] != "0000-0002-9079-593X" {
+		t.Errorf("ids = %v", ids)
+	}
+	if _, err := ReadORCIDs(strings.NewReader("0000-0002-1825-0098\n")); err == nil {
+		t.Error("expected a check digit error")
+	}
+}
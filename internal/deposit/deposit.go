@@ -0,0 +1,285 @@
+// Package deposit runs the deposit workflow (create, edit, update,
+// publish, discard, new version and file upload) against either of the
+// two write APIs: Zenodo's legacy /deposit/depositions API, or the
+// InvenioRDM /records draft API that newer Zenodo features and
+// self-hosted InvenioRDM instances require. Both are presented with
+// metadata in the legacy schema, so commands work the same in either mode.
+package deposit
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/crosswalk"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// Mode selects the write API, from the profile's "mode" setting.
+type Mode string
+
+const (
+	ModeDeposit Mode = "deposit"
+	ModeRDM     Mode = "rdm"
+)
+
+// ParseMode returns the mode named s; "" is ModeDeposit.
+func ParseMode(s string) (Mode, error) {
+	switch Mode(strings.ToLower(s)) {
+	case "", ModeDeposit:
+		return ModeDeposit, nil
+	case ModeRDM:
+		return ModeRDM, nil
+	}
+	return "", fmt.Errorf("unknown mode %q (supported: deposit, rdm)", s)
+}
+
+// ResolveMode returns the mode for a profile's "mode" setting s. With no
+// setting, an instance probed without the deposit API only has the RDM one.
+func ResolveMode(s string, caps *model.Capabilities) (Mode, error) {
+	mode, err := ParseMode(s)
+	if err != nil {
+		return "", err
+	}
+	if s == "" && !caps.Has(model.EndpointDeposit) {
+		mode = ModeRDM
+	}
+	return mode, nil
+}
+
+// Draft is a deposition or RDM draft, with metadata in the legacy schema.
+type Draft struct {
+	// ID is the record ID: numeric on Zenodo, but a string such as
+	// "q5jr8-hny72" on other InvenioRDM instances.
+	ID        string         `json:"id"`
+	DOI       string         `json:"doi,omitempty"`
+	State     string         `json:"state,omitempty"`
+	Published bool           `json:"published"`
+	Metadata  model.Metadata `json:"metadata"`
+	URL       string         `json:"url,omitempty"`
+	Modified  time.Time      `json:"modified,omitzero"`
+
+	// bucket and latestDraft are the deposition's file bucket and newest
+	// draft (deposit mode only).
+	bucket      string
+	latestDraft string
+	// rdm is the draft as the RDM API returned it (rdm mode only), so an
+	// update keeps the fields the legacy schema cannot express.
+	rdm *model.RDMRecord
+}
+
+// Backend performs deposit operations through one of the write APIs.
+type Backend interface {
+	// Get returns the draft of a record, or the deposition in deposit mode.
+	Get(id string) (*Draft, error)
+	// Published returns the published version of a record, read-only. In
+	// rdm mode a published record has no draft until Edit.
+	Published(id string) (*Draft, error)
+	// Create creates a new draft.
+	Create(m model.Metadata) (*Draft, error)
+	// Update replaces the metadata of a draft. d must come from Get.
+	Update(d *Draft, m model.Metadata) (*Draft, error)
+	// Edit unlocks a published record for editing.
+	Edit(id string) (*Draft, error)
+	// Publish publishes a draft.
+	Publish(id string) (*Draft, error)
+	// Discard discards a draft's unpublished changes.
+	Discard(id string) error
+	// NewVersion creates a draft of a new version of a published record.
+	NewVersion(id string) (*Draft, error)
+	// Upload adds a file to a draft under the given name.
+	Upload(d *Draft, name string, r io.Reader) error
+}
+
+// New returns the backend for mode.
+func New(client *api.Client, mode Mode) Backend {
+	if mode == ModeRDM {
+		return rdmBackend{client}
+	}
+	return depositBackend{client}
+}
+
+// depositBackend uses the legacy /deposit/depositions API.
+type depositBackend struct {
+	client *api.Client
+}
+
+func fromDeposition(dep *model.Deposition) *Draft {
+	return &Draft{
+		ID:          strconv.Itoa(dep.ID),
+		DOI:         dep.DOI,
+		State:       dep.State,
+		Published:   dep.Submitted,
+		Metadata:    dep.Metadata,
+		URL:         dep.Links.HTML,
+		Modified:    dep.Modified,
+		bucket:      dep.Links.Bucket,
+		latestDraft: dep.Links.LatestDraft,
+	}
+}
+
+func depositionID(id string) (int, error) {
+	n, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, fmt.Errorf("invalid deposition ID: %s", id)
+	}
+	return n, nil
+}
+
+// call runs a deposition API call on a numeric ID.
+func (b depositBackend) call(id string, fn func(int) (*model.Deposition, error)) (*Draft, error) {
+	n, err := depositionID(id)
+	if err != nil {
+		return nil, err
+	}
+	dep, err := fn(n)
+	if err != nil {
+		return nil, err
+	}
+	return fromDeposition(dep), nil
+}
+
+func (b depositBackend) Get(id string) (*Draft, error) {
+	return b.call(id, b.client.GetDeposition)
+}
+
+func (b depositBackend) Published(id string) (*Draft, error) {
+	n, err := depositionID(id)
+	if err != nil {
+		return nil, err
+	}
+	rec, err := b.client.GetRecord(n)
+	if err != nil {
+		return nil, err
+	}
+	return &Draft{
+		ID:        id,
+		DOI:       rec.DOI,
+		State:     rec.State,
+		Published: true,
+		Metadata:  rec.Metadata,
+		URL:       rec.Links.HTML,
+		Modified:  rec.Updated,
+	}, nil
+}
+
+func (b depositBackend) Create(m model.Metadata) (*Draft, error) {
+	dep, err := b.client.CreateDeposition(m)
+	if err != nil {
+		return nil, err
+	}
+	return fromDeposition(dep), nil
+}
+
+func (b depositBackend) Update(d *Draft, m model.Metadata) (*Draft, error) {
+	return b.call(d.ID, func(n int) (*model.Deposition, error) { return b.client.UpdateDeposition(n, m) })
+}
+
+func (b depositBackend) Edit(id string) (*Draft, error) {
+	return b.call(id, b.client.EditDeposition)
+}
+
+func (b depositBackend) Publish(id string) (*Draft, error) {
+	return b.call(id, b.client.PublishDeposition)
+}
+
+func (b depositBackend) Discard(id string) error {
+	_, err := b.call(id, b.client.DiscardDeposition)
+	return err
+}
+
+// NewVersion returns the new draft, which the API only links to from the
+// original deposition.
+func (b depositBackend) NewVersion(id string) (*Draft, error) {
+	orig, err := b.call(id, b.client.NewVersion)
+	if err != nil {
+		return nil, err
+	}
+	link := orig.latestDraft
+	if link == "" {
+		return nil, fmt.Errorf("deposition %s: no link to the new version draft", id)
+	}
+	return b.Get(path.Base(link))
+}
+
+func (b depositBackend) Upload(d *Draft, name string, r io.Reader) error {
+	if d.bucket == "" {
+		return fmt.Errorf("deposition %s has no file bucket", d.ID)
+	}
+	_, err := b.client.UploadFile(d.bucket, name, r)
+	return err
+}
+
+// rdmBackend uses the InvenioRDM /records draft API.
+type rdmBackend struct {
+	client *api.Client
+}
+
+func fromRDM(r *model.RDMRecord) *Draft {
+	return &Draft{
+		ID:        r.ID,
+		DOI:       r.DOI(),
+		State:     r.Status,
+		Published: r.IsPublished,
+		Metadata:  crosswalk.FromRDM(r),
+		URL:       r.Links["self_html"],
+		Modified:  r.Updated,
+		rdm:       r,
+	}
+}
+
+// rdmCall converts the result of an RDM API call.
+func rdmCall(r *model.RDMRecord, err error) (*Draft, error) {
+	if err != nil {
+		return nil, err
+	}
+	return fromRDM(r), nil
+}
+
+func (b rdmBackend) Get(id string) (*Draft, error) {
+	return rdmCall(b.client.GetDraft(id))
+}
+
+func (b rdmBackend) Published(id string) (*Draft, error) {
+	return rdmCall(b.client.GetRDMRecordByID(id))
+}
+
+// Create creates a draft with files enabled, as the deposit API's are.
+func (b rdmBackend) Create(m model.Metadata) (*Draft, error) {
+	draft := crosswalk.ToRDM(m)
+	draft.Files.Enabled = true
+	return rdmCall(b.client.CreateDraft(draft))
+}
+
+func (b rdmBackend) Update(d *Draft, m model.Metadata) (*Draft, error) {
+	base := d.rdm
+	if base == nil {
+		base = crosswalk.ToRDM(d.Metadata)
+	}
+	return rdmCall(b.client.UpdateDraft(d.ID, crosswalk.MergeRDM(base, m)))
+}
+
+func (b rdmBackend) Edit(id string) (*Draft, error) {
+	return rdmCall(b.client.EditRecord(id))
+}
+
+func (b rdmBackend) Publish(id string) (*Draft, error) {
+	return rdmCall(b.client.PublishDraft(id))
+}
+
+func (b rdmBackend) Discard(id string) error {
+	return b.client.DiscardDraft(id)
+}
+
+func (b rdmBackend) NewVersion(id string) (*Draft, error) {
+	return rdmCall(b.client.NewRecordVersion(id))
+}
+
+func (b rdmBackend) Upload(d *Draft, name string, r io.Reader) error {
+	_, err := b.client.UploadDraftFile(d.ID, name, r)
+	return err
+}
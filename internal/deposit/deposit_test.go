@@ -0,0 +1,148 @@
+package deposit
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+func TestParseMode(t *testing.T) {
+	for s, want := range map[string]Mode{"": ModeDeposit, "deposit": ModeDeposit, "RDM": ModeRDM} {
+		if got, err := ParseMode(s); err != nil || got != want {
+			t.Errorf("ParseMode(%q) = %q, %v", s, got, err)
+		}
+	}
+	if _, err := ParseMode("graphql"); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestResolveMode(t *testing.T) {
+	rdmOnly := &model.Capabilities{Endpoints: []string{model.EndpointRecords, model.EndpointDrafts}}
+	for _, tt := range []struct {
+		setting string
+		caps    *model.Capabilities
+		want    Mode
+	}{
+		{"", nil, ModeDeposit},
+		{"", rdmOnly, ModeRDM},
+		{"deposit", rdmOnly, ModeDeposit},
+		{"rdm", nil, ModeRDM},
+	} {
+		if got, err := ResolveMode(tt.setting, tt.caps); err != nil || got != tt.want {
+			t.Errorf("ResolveMode(%q, %v) = %q, %v", tt.setting, tt.caps, got, err)
+		}
+	}
+}
+
+func TestRDMBackend_CreateUploadPublish(t *testing.T) {
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "POST /records":
+			var draft model.RDMRecord
+			json.NewDecoder(r.Body).Decode(&draft)
+			if !draft.Files.Enabled || draft.Metadata.ResourceType.ID != "dataset" || draft.Metadata.Creators[0].PersonOrOrg.FamilyName != "Doe" {
+				t.Errorf("draft = %+v", draft)
+			}
+			draft.ID, draft.Status = "q5jr8-hny72", "draft"
+			json.NewEncoder(w).Encode(draft)
+		case "POST /records/q5jr8-hny72/draft/files", "POST /records/q5jr8-hny72/draft/files/obs.csv/commit":
+			w.Write([]byte(`{}`))
+		case "PUT /records/q5jr8-hny72/draft/files/obs.csv/content":
+			io.Copy(io.Discard, r.Body)
+			w.Write([]byte(`{}`))
+		case "POST /records/q5jr8-hny72/draft/actions/publish":
+			w.Write([]byte(`{"id": "q5jr8-hny72", "status": "published", "is_published": true,
+				"pids": {"doi": {"identifier": "10.1234/q5jr8-hny72"}}, "metadata": {"title": "Soil"}}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	b := New(api.NewClient(srv.URL, "tok"), ModeRDM)
+	d, err := b.Create(model.Metadata{Title: "Soil", UploadType: "dataset", Creators: []model.Creator{{Name: "Doe, Jane"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.ID != "q5jr8-hny72" || d.Metadata.Title != "Soil" || d.Published {
+		t.Errorf("draft = %+v", d)
+	}
+	if err := b.Upload(d, "obs.csv", strings.NewReader("a,b\n")); err != nil {
+		t.Fatal(err)
+	}
+	pub, err := b.Publish(d.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pub.Published || pub.DOI != "10.1234/q5jr8-hny72" {
+		t.Errorf("published = %+v", pub)
+	}
+	if len(calls) != 5 {
+		t.Errorf("calls = %v", calls)
+	}
+}
+
+func TestRDMBackend_UpdateKeepsRDMFields(t *testing.T) {
+	var put model.RDMRecord
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"id": "abc", "status": "draft", "metadata": {"title": "Old",
+				"dates": [{"date": "2023", "type": {"id": "collected"}}],
+				"creators": [{"person_or_org": {"type": "organizational", "name": "Soil Consortium"}}]}}`))
+		case http.MethodPut:
+			json.NewDecoder(r.Body).Decode(&put)
+			json.NewEncoder(w).Encode(put)
+		}
+	}))
+	defer srv.Close()
+
+	b := New(api.NewClient(srv.URL, "tok"), ModeRDM)
+	d, err := b.Get("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := d.Metadata
+	m.Title = "New"
+	if _, err := b.Update(d, m); err != nil {
+		t.Fatal(err)
+	}
+	if put.Metadata.Title != "New" || len(put.Metadata.Dates) != 1 || put.Metadata.Creators[0].PersonOrOrg.Type != model.RDMOrganizational {
+		t.Errorf("PUT body = %+v", put.Metadata)
+	}
+}
+
+func TestDepositBackend_NewVersion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /deposit/depositions/100/actions/newversion":
+			json.NewEncoder(w).Encode(model.Deposition{ID: 100, Links: model.Links{LatestDraft: "https://zenodo.org/api/deposit/depositions/101"}})
+		case "GET /deposit/depositions/101":
+			json.NewEncoder(w).Encode(model.Deposition{ID: 101, State: "unsubmitted", Links: model.Links{Bucket: "https://zenodo.org/api/files/b"}})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	b := New(api.NewClient(srv.URL, "tok"), ModeDeposit)
+	d, err := b.NewVersion("100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.ID != "101" || d.bucket == "" {
+		t.Errorf("draft = %+v", d)
+	}
+	if _, err := b.Get("q5jr8-hny72"); err == nil || !strings.Contains(err.Error(), "invalid deposition ID") {
+		t.Errorf("err = %v", err)
+	}
+}
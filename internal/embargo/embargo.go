@@ -0,0 +1,117 @@
+// Package embargo finds the embargoed and restricted records in a listing
+// and works out when each embargo lifts, so data owners get advance notice
+// before records become public.
+package embargo
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// Access rights that keep a record's files from the public.
+const (
+	Embargoed  = "embargoed"
+	Restricted = "restricted"
+)
+
+// Entry is an embargoed or restricted record.
+type Entry struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	AccessRight string `json:"access_right"`
+	// EmbargoDate is when the record becomes open, for embargoed records.
+	EmbargoDate string `json:"embargo_date,omitempty"`
+	// DaysLeft is the number of days until the embargo lifts; negative if
+	// the date has passed. It is nil for records without an embargo date.
+	DaysLeft *int   `json:"days_left"`
+	DOI      string `json:"doi,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+// LiftsWithin reports whether the embargo lifts in the next days days,
+// or has already passed.
+func (e Entry) LiftsWithin(days int) bool {
+	return e.DaysLeft != nil && *e.DaysLeft <= days
+}
+
+// row is the part of a listing row (a record or deposition) the monitor
+// needs.
+type row struct {
+	ID       int            `json:"id"`
+	Title    string         `json:"title"`
+	DOI      string         `json:"doi"`
+	Metadata model.Metadata `json:"metadata"`
+	Links    model.Links    `json:"links"`
+}
+
+// Find returns the embargoed and restricted records among listing rows,
+// soonest embargo first, then restricted records. Days are counted from
+// today's date in now's location. A record whose embargo date cannot be
+// parsed is kept without DaysLeft and reported to warn, if set.
+func Find(rows []map[string]interface{}, now time.Time, warn func(id int, err error)) ([]Entry, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	entries := []Entry{}
+	for _, m := range rows {
+		var r row
+		b, err := json.Marshal(m)
+		if err == nil {
+			err = json.Unmarshal(b, &r)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading record: %w", err)
+		}
+
+		access := r.Metadata.AccessRight
+		if access != Embargoed && access != Restricted {
+			continue
+		}
+		e := Entry{
+			ID:          r.ID,
+			Title:       r.Metadata.Title,
+			AccessRight: access,
+			EmbargoDate: r.Metadata.EmbargoDate,
+			DOI:         r.DOI,
+			URL:         r.Links.HTML,
+		}
+		if e.Title == "" {
+			e.Title = r.Title
+		}
+		if e.DOI == "" {
+			e.DOI = r.Metadata.DOI
+		}
+		if access == Embargoed && e.EmbargoDate != "" {
+			if until, err := time.Parse(time.DateOnly, e.EmbargoDate); err == nil {
+				days := int(until.Sub(today).Hours() / 24)
+				e.DaysLeft = &days
+			} else if warn != nil {
+				warn(r.ID, fmt.Errorf("invalid embargo date %q", e.EmbargoDate))
+			}
+		}
+		entries = append(entries, e)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].DaysLeft, entries[j].DaysLeft
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return *a < *b
+	})
+	return entries, nil
+}
+
+// Within returns the entries whose embargo lifts in the next days days, or
+// has already passed.
+func Within(entries []Entry, days int) []Entry {
+	out := []Entry{}
+	for _, e := range entries {
+		if e.LiftsWithin(days) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
@@ -0,0 +1,68 @@
+package embargo
+
+import (
+	"testing"
+	"time"
+)
+
+func rows() []map[string]interface{} {
+	return []map[string]interface{}{
+		{"id": 1, "metadata": map[string]interface{}{"title": "Open", "access_right": "open"}},
+		{"id": 2, "metadata": map[string]interface{}{"title": "Late", "access_right": "embargoed", "embargo_date": "2027-06-01"}},
+		{"id": 3, "metadata": map[string]interface{}{"title": "Closed", "access_right": "restricted"}},
+		{"id": 4, "metadata": map[string]interface{}{"title": "Soon", "access_right": "embargoed", "embargo_date": "2026-11-01"},
+			"links": map[string]interface{}{"html": "https://zenodo.org/records/4"}},
+		{"id": 5, "metadata": map[string]interface{}{"title": "Lifted", "access_right": "embargoed", "embargo_date": "2026-10-01"}},
+	}
+}
+
+func TestFind(t *testing.T) {
+	now := time.Date(2026, 10, 18, 23, 30, 0, 0, time.UTC)
+	entries, err := Find(rows(), now, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []int
+	for _, e := range entries {
+		ids = append(ids, e.ID)
+	}
+	if len(ids) != 4 || ids[0] != 5 || ids[1] != 4 || ids[2] != 2 || ids[3] != 3 {
+		t.Fatalf("order = %v", ids)
+	}
+	if soon := entries[1]; *soon.DaysLeft != 14 || soon.URL != "https://zenodo.org/records/4" {
+		t.Errorf("soon = %+v", soon)
+	}
+	if *entries[0].DaysLeft != -17 {
+		t.Errorf("lifted days = %d", *entries[0].DaysLeft)
+	}
+	if entries[3].DaysLeft != nil {
+		t.Error("restricted record has days left")
+	}
+
+	within := Within(entries, 30)
+	if len(within) != 2 || within[0].ID != 5 || within[1].ID != 4 {
+		t.Errorf("within 30 days = %+v", within)
+	}
+}
+
+func TestFind_InvalidDate(t *testing.T) {
+	bad := map[string]interface{}{"id": 6, "metadata": map[string]interface{}{"title": "Typo", "access_right": "embargoed", "embargo_date": "next year"}}
+	var warned []int
+	entries, err := Find(append(rows(), bad), time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), func(id int, err error) {
+		warned = append(warned, id)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 5 || len(warned) != 1 || warned[0] != 6 {
+		t.Fatalf("entries = %d, warned = %v", len(entries), warned)
+	}
+	for _, e := range entries {
+		if e.ID == 6 && (e.DaysLeft != nil || e.EmbargoDate != "next year") {
+			t.Errorf("bad row = %+v", e)
+		}
+	}
+	if within := Within(entries, 30); len(within) != 2 {
+		t.Errorf("within 30 days = %+v", within)
+	}
+}
@@ -0,0 +1,465 @@
+// Package fair scores records against a subset of the FAIR principles
+// (Findable, Accessible, Interoperable, Reusable). Each indicator is
+// computed from the record alone, without fetching anything, so an
+// assessment is reproducible evidence for reporting.
+package fair
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"strings"
+
+	"github.com/ran-codes/zenodo-cli/internal/lint"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+	"github.com/ran-codes/zenodo-cli/internal/validate"
+)
+
+// Principle is one of the four FAIR principles.
+type Principle string
+
+const (
+	Findable      Principle = "F"
+	Accessible    Principle = "A"
+	Interoperable Principle = "I"
+	Reusable      Principle = "R"
+)
+
+// Principles lists the principles in FAIR order.
+var Principles = []Principle{Findable, Accessible, Interoperable, Reusable}
+
+// Name returns the principle's full name.
+func (p Principle) Name() string {
+	switch p {
+	case Findable:
+		return "Findable"
+	case Accessible:
+		return "Accessible"
+	case Interoperable:
+		return "Interoperable"
+	case Reusable:
+		return "Reusable"
+	}
+	return string(p)
+}
+
+// MinDescriptionWords is the description length rich-metadata expects.
+const MinDescriptionWords = lint.DefaultMinDescriptionWords
+
+// Indicator is one measurable FAIR criterion. Check returns a score from 0
+// to 1 (partial credit is allowed, e.g. for a share of creators), what was
+// found, and a suggestion when the score is below 1.
+type Indicator struct {
+	ID          string
+	Principle   Principle
+	Ref         string // the FAIR sub-principle, e.g. "F1"
+	Description string
+	Check       func(r *model.Record) (score float64, detail, suggestion string)
+}
+
+// Indicators are the assessed criteria, in FAIR order.
+var Indicators = []Indicator{
+	{
+		ID:          "persistent-identifier",
+		Principle:   Findable,
+		Ref:         "F1",
+		Description: "The record has a well-formed DOI",
+		Check:       checkPersistentIdentifier,
+	},
+	{
+		ID:          "rich-metadata",
+		Principle:   Findable,
+		Ref:         "F2",
+		Description: "Title, description, keywords, publication date and resource type are present",
+		Check:       checkRichMetadata,
+	},
+	{
+		ID:          "creator-orcid",
+		Principle:   Findable,
+		Ref:         "F2",
+		Description: "Creators are identified by valid ORCIDs",
+		Check:       checkCreatorORCID,
+	},
+	{
+		ID:          "access-right",
+		Principle:   Accessible,
+		Ref:         "A1",
+		Description: "The access right is machine-readable, with an embargo date or access conditions where needed",
+		Check:       checkAccessRight,
+	},
+	{
+		ID:          "open-formats",
+		Principle:   Interoperable,
+		Ref:         "I1",
+		Description: "Files use open, standard formats",
+		Check:       checkOpenFormats,
+	},
+	{
+		ID:          "affiliation-ror",
+		Principle:   Interoperable,
+		Ref:         "I2",
+		Description: "Creator affiliations are identified by ROR IDs",
+		Check:       checkAffiliationROR,
+	},
+	{
+		ID:          "related-identifiers",
+		Principle:   Interoperable,
+		Ref:         "I3",
+		Description: "Related works are linked by identifiers with a scheme",
+		Check:       checkRelatedIdentifiers,
+	},
+	{
+		ID:          "open-license",
+		Principle:   Reusable,
+		Ref:         "R1.1",
+		Description: "The record has an open license",
+		Check:       checkOpenLicense,
+	},
+	{
+		ID:          "funding",
+		Principle:   Reusable,
+		Ref:         "R1.2",
+		Description: "Funding is recorded as grants, for provenance",
+		Check:       checkFunding,
+	},
+}
+
+// Result is the outcome of one indicator.
+type Result struct {
+	Indicator   string    `json:"indicator"`
+	Principle   Principle `json:"principle"`
+	Ref         string    `json:"ref"`
+	Description string    `json:"description"`
+	// Score is the indicator's score from 0 to 100.
+	Score      int    `json:"score"`
+	Passed     bool   `json:"passed"`
+	Detail     string `json:"detail"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// PrincipleScore is the mean score of a principle's indicators.
+type PrincipleScore struct {
+	Principle Principle `json:"principle"`
+	Name      string    `json:"name"`
+	Score     int       `json:"score"`
+}
+
+// Assessment is the FAIR assessment of one record.
+type Assessment struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	DOI   string `json:"doi,omitempty"`
+	// Score is the mean of all indicator scores, from 0 to 100.
+	Score       int              `json:"score"`
+	Principles  []PrincipleScore `json:"principles"`
+	Indicators  []Result         `json:"indicators"`
+	Suggestions []string         `json:"suggestions"`
+}
+
+// PrincipleScore returns the score of principle p.
+func (a *Assessment) PrincipleScore(p Principle) int {
+	for _, s := range a.Principles {
+		if s.Principle == p {
+			return s.Score
+		}
+	}
+	return 0
+}
+
+// Failed returns the IDs of the indicators that did not fully pass.
+func (a *Assessment) Failed() []string {
+	var ids []string
+	for _, r := range a.Indicators {
+		if !r.Passed {
+			ids = append(ids, r.Indicator)
+		}
+	}
+	return ids
+}
+
+// Assess scores r against every indicator.
+func Assess(r *model.Record) *Assessment {
+	a := &Assessment{ID: r.ID, Title: r.Metadata.Title, DOI: recordDOI(r), Suggestions: []string{}}
+	if a.Title == "" {
+		a.Title = r.Title
+	}
+	sums := make(map[Principle]float64)
+	counts := make(map[Principle]int)
+	var total float64
+	for _, ind := range Indicators {
+		score, detail, suggestion := ind.Check(r)
+		score = math.Max(0, math.Min(1, score))
+		res := Result{
+			Indicator:   ind.ID,
+			Principle:   ind.Principle,
+			Ref:         ind.Ref,
+			Description: ind.Description,
+			Score:       percent(score),
+			Passed:      score >= 1,
+			Detail:      detail,
+		}
+		if !res.Passed {
+			res.Suggestion = suggestion
+			a.Suggestions = append(a.Suggestions, suggestion)
+		}
+		a.Indicators = append(a.Indicators, res)
+		sums[ind.Principle] += score
+		counts[ind.Principle]++
+		total += score
+	}
+	for _, p := range Principles {
+		if counts[p] == 0 {
+			continue
+		}
+		a.Principles = append(a.Principles, PrincipleScore{
+			Principle: p,
+			Name:      p.Name(),
+			Score:     percent(sums[p] / float64(counts[p])),
+		})
+	}
+	a.Score = percent(total / float64(len(Indicators)))
+	return a
+}
+
+func percent(f float64) int {
+	return int(math.Round(f * 100))
+}
+
+// recordDOI returns the record's DOI from whichever field carries it.
+func recordDOI(r *model.Record) string {
+	switch {
+	case r.DOI != "":
+		return r.DOI
+	case r.Metadata.DOI != "":
+		return r.Metadata.DOI
+	case r.Metadata.PrereserveDOI != nil:
+		return r.Metadata.PrereserveDOI.DOI
+	}
+	return ""
+}
+
+func checkPersistentIdentifier(r *model.Record) (float64, string, string) {
+	doi := recordDOI(r)
+	if doi == "" {
+		return 0, "no DOI", "Publish the record (or reserve a DOI) so it gets a persistent identifier"
+	}
+	if err := validate.DOI(doi); err != nil {
+		return 0, err.Error(), "Correct the DOI so it resolves"
+	}
+	return 1, "DOI " + doi, ""
+}
+
+func checkRichMetadata(r *model.Record) (float64, string, string) {
+	m := &r.Metadata
+	var missing []string
+	if m.Title == "" {
+		missing = append(missing, "title")
+	}
+	if n := lint.WordCount(m.Description); n < MinDescriptionWords {
+		missing = append(missing, fmt.Sprintf("description of %d+ words (has %d)", MinDescriptionWords, n))
+	}
+	if len(m.Keywords) == 0 && len(m.Subjects) == 0 {
+		missing = append(missing, "keywords or subjects")
+	}
+	if m.PublicationDate == "" {
+		missing = append(missing, "publication date")
+	}
+	if m.UploadType == "" && (m.ResourceType == nil || m.ResourceType.Type == "") {
+		missing = append(missing, "resource type")
+	}
+	const fields = 5
+	if len(missing) == 0 {
+		return 1, "all core descriptive fields present", ""
+	}
+	return float64(fields-len(missing)) / fields,
+		"missing " + strings.Join(missing, ", "),
+		"Add " + strings.Join(missing, ", ") + " so the record can be found by search"
+}
+
+func checkCreatorORCID(r *model.Record) (float64, string, string) {
+	creators := r.Metadata.Creators
+	if len(creators) == 0 {
+		return 0, "no creators", "Add creators with their ORCIDs"
+	}
+	valid := 0
+	var without []string
+	for _, c := range creators {
+		if c.ORCID != "" && validate.ORCID(c.ORCID) == nil {
+			valid++
+		} else {
+			without = append(without, c.Name)
+		}
+	}
+	detail := fmt.Sprintf("%d of %d creators have a valid ORCID", valid, len(creators))
+	if valid == len(creators) {
+		return 1, detail, ""
+	}
+	return float64(valid) / float64(len(creators)), detail,
+		"Add valid ORCIDs for " + strings.Join(without, "; ")
+}
+
+func checkAccessRight(r *model.Record) (float64, string, string) {
+	m := &r.Metadata
+	switch m.AccessRight {
+	case "open":
+		return 1, "open access", ""
+	case "embargoed":
+		if m.EmbargoDate == "" {
+			return 0.5, "embargoed without an embargo date", "Set embargo_date so machines know when the files open"
+		}
+		return 1, "embargoed until " + m.EmbargoDate, ""
+	case "restricted":
+		if strings.TrimSpace(m.AccessConditions) == "" {
+			return 0.5, "restricted without access conditions", "Describe how to request access in access_conditions"
+		}
+		return 1, "restricted, with access conditions", ""
+	case "closed":
+		return 1, "closed access (metadata remains open)", ""
+	case "":
+		return 0, "no access right", "Set access_right to open, embargoed, restricted or closed"
+	}
+	return 0, fmt.Sprintf("unknown access right %q", m.AccessRight), "Set access_right to open, embargoed, restricted or closed"
+}
+
+// openFormats are file extensions of open, documented formats.
+var openFormats = map[string]bool{
+	"txt": true, "md": true, "rst": true, "csv": true, "tsv": true, "json": true, "jsonld": true,
+	"geojson": true, "xml": true, "yaml": true, "yml": true, "html": true, "htm": true, "ttl": true,
+	"rdf": true, "nt": true, "nq": true, "owl": true, "sql": true, "tex": true, "bib": true,
+	"pdf": true, "odt": true, "ods": true, "odp": true, "epub": true,
+	"png": true, "tif": true, "tiff": true, "jpg": true, "jpeg": true, "svg": true, "webp": true, "gif": true,
+	"flac": true, "wav": true, "ogg": true, "opus": true, "mkv": true, "webm": true, "mp4": true, "mp3": true,
+	"nc": true, "cdf": true, "h5": true, "hdf5": true, "hdf": true, "parquet": true, "arrow": true,
+	"feather": true, "avro": true, "orc": true, "zarr": true, "fits": true, "gpkg": true, "shp": true,
+	"las": true, "laz": true, "vcf": true, "fasta": true, "fa": true, "fastq": true, "bam": true, "sam": true,
+	"pdb": true, "cif": true, "dcm": true, "nii": true,
+	"zip": true, "gz": true, "tgz": true, "tar": true, "bz2": true, "xz": true, "zst": true, "7z": true,
+	"py": true, "r": true, "jl": true, "ipynb": true, "c": true, "h": true, "cpp": true, "go": true,
+	"rs": true, "java": true, "js": true, "ts": true, "sh": true, "m": true,
+	"docx": true, "xlsx": true, "pptx": true, // ISO/IEC 29500
+}
+
+// extension returns a file's lower-case extension, looking past a
+// compression suffix (data.csv.gz is a CSV).
+func extension(key string) string {
+	key = strings.ToLower(key)
+	ext := strings.TrimPrefix(path.Ext(key), ".")
+	switch ext {
+	case "gz", "bz2", "xz", "zst":
+		if inner := strings.TrimPrefix(path.Ext(strings.TrimSuffix(key, "."+ext)), "."); inner != "" {
+			return inner
+		}
+	}
+	return ext
+}
+
+func checkOpenFormats(r *model.Record) (float64, string, string) {
+	if len(r.Files) == 0 {
+		if r.Metadata.AccessRight != "" && r.Metadata.AccessRight != "open" {
+			return 1, "no public files to assess", ""
+		}
+		return 0, "no files", "Upload the data or software files"
+	}
+	open := 0
+	var closed []string
+	for _, f := range r.Files {
+		if openFormats[extension(f.Key)] {
+			open++
+		} else {
+			closed = append(closed, f.Key)
+		}
+	}
+	detail := fmt.Sprintf("%d of %d files in open formats", open, len(r.Files))
+	if len(closed) == 0 {
+		return 1, detail, ""
+	}
+	if len(closed) > 3 {
+		closed = append(closed[:3], fmt.Sprintf("and %d more", len(closed)-3))
+	}
+	return float64(open) / float64(len(r.Files)), detail,
+		"Also provide " + strings.Join(closed, ", ") + " in an open format (e.g. CSV, NetCDF, PDF/A)"
+}
+
+func checkAffiliationROR(r *model.Record) (float64, string, string) {
+	creators := r.Metadata.Creators
+	if len(creators) == 0 {
+		return 0, "no creators", "Add creators with ROR-identified affiliations"
+	}
+	withROR := 0
+	for _, c := range creators {
+		for _, id := range validate.AffiliationRORs(c.Affiliation) {
+			if validate.ROR(id) == nil {
+				withROR++
+				break
+			}
+		}
+	}
+	detail := fmt.Sprintf("%d of %d creators have a ROR-identified affiliation", withROR, len(creators))
+	if withROR == len(creators) {
+		return 1, detail, ""
+	}
+	return float64(withROR) / float64(len(creators)), detail,
+		`Identify affiliations by ROR, e.g. "CERN (https://ror.org/01ggx4157)"`
+}
+
+func checkRelatedIdentifiers(r *model.Record) (float64, string, string) {
+	ids := r.Metadata.RelatedIdentifiers
+	if len(ids) == 0 {
+		return 0, "no related identifiers", "Link the paper, software or data this record relates to as related identifiers"
+	}
+	schemed := 0
+	for _, id := range ids {
+		scheme := id.Scheme
+		if scheme == "" {
+			scheme = validate.DetectScheme(id.Identifier)
+		}
+		if scheme != "" {
+			schemed++
+		}
+	}
+	detail := fmt.Sprintf("%d related identifiers, %d with a scheme", len(ids), schemed)
+	if schemed == len(ids) {
+		return 1, detail, ""
+	}
+	return float64(schemed) / float64(len(ids)), detail, "Use resolvable identifiers (DOI, URL, ...) for related works"
+}
+
+// openLicensePrefixes match the IDs of open licenses in Zenodo's license
+// vocabulary (SPDX-style, lower case).
+var openLicensePrefixes = []string{
+	"cc-by", "cc0", "cc-zero", "pddl", "odc-", "mit", "apache", "bsd", "gpl", "lgpl", "agpl",
+	"mpl", "epl", "eupl", "isc", "unlicense", "artistic", "zlib", "ofl", "etalab",
+}
+
+// IsOpenLicense reports whether a license ID is an open license. Creative
+// Commons licenses with NC or ND terms are not.
+func IsOpenLicense(id string) bool {
+	id = strings.ToLower(id)
+	if strings.Contains(id, "-nc") || strings.Contains(id, "-nd") {
+		return false
+	}
+	for _, p := range openLicensePrefixes {
+		if strings.HasPrefix(id, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkOpenLicense(r *model.Record) (float64, string, string) {
+	license := r.Metadata.LicenseString()
+	switch {
+	case license == "":
+		return 0, "no license", "Choose an open license such as CC-BY-4.0 or CC0-1.0 (see 'zenodo licenses search')"
+	case IsOpenLicense(license):
+		return 1, "license " + license, ""
+	}
+	return 0.5, "license " + license + " restricts reuse", "Consider an open license (CC-BY-4.0, CC0-1.0, MIT, ...) if the terms allow"
+}
+
+func checkFunding(r *model.Record) (float64, string, string) {
+	if n := len(r.Metadata.Grants); n > 0 {
+		return 1, fmt.Sprintf("%d grant(s)", n), ""
+	}
+	return 0, "no grants", "If the work was funded, add the awards as grants"
+}
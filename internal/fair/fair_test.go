@@ -0,0 +1,149 @@
+package fair
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+func fairRecord() *model.Record {
+	return &model.Record{
+		ID:  1,
+		DOI: "10.5281/zenodo.1",
+		Metadata: model.Metadata{
+			Title:           "Ocean temperatures",
+			Description:     strings.Repeat("word ", 60),
+			UploadType:      "dataset",
+			PublicationDate: "2024-01-15",
+			AccessRight:     "open",
+			License:         json.RawMessage(`{"id": "cc-by-4.0"}`),
+			Keywords:        []string{"ocean"},
+			Creators: []model.Creator{
+				{Name: "Doe, Jane", ORCID: "0000-0002-1825-0097", Affiliation: "CERN (https://ror.org/01ggx4157)"},
+			},
+			Grants: []model.Grant{{ID: "10.13039/501100000780::283595"}},
+			RelatedIdentifiers: []model.RelatedIdentifier{
+				{Identifier: "10.1234/paper", Relation: "isSupplementTo", Scheme: "doi"},
+			},
+		},
+		Files: []model.File{{Key: "temperatures.csv"}, {Key: "readings.nc.gz"}},
+	}
+}
+
+func result(a *Assessment, id string) Result {
+	for _, r := range a.Indicators {
+		if r.Indicator == id {
+			return r
+		}
+	}
+	return Result{}
+}
+
+func TestAssess_Complete(t *testing.T) {
+	a := Assess(fairRecord())
+	if a.Score != 100 {
+		t.Errorf("score = %d, failed %v", a.Score, a.Failed())
+	}
+	if len(a.Indicators) != len(Indicators) || len(a.Principles) != 4 {
+		t.Errorf("indicators = %d, principles = %d", len(a.Indicators), len(a.Principles))
+	}
+	if len(a.Suggestions) != 0 {
+		t.Errorf("suggestions = %v", a.Suggestions)
+	}
+}
+
+func TestAssess_Empty(t *testing.T) {
+	a := Assess(&model.Record{ID: 2})
+	if a.Score != 0 {
+		t.Errorf("score = %d", a.Score)
+	}
+	if len(a.Suggestions) != len(Indicators) {
+		t.Errorf("suggestions = %d, want one per indicator", len(a.Suggestions))
+	}
+	for _, r := range a.Indicators {
+		if r.Passed || r.Suggestion == "" {
+			t.Errorf("%s: passed %v, suggestion %q", r.Indicator, r.Passed, r.Suggestion)
+		}
+	}
+}
+
+func TestAssess_PartialCredit(t *testing.T) {
+	r := fairRecord()
+	r.Metadata.Creators = append(r.Metadata.Creators,
+		model.Creator{Name: "Roe, Rick", ORCID: "0000-0002-1825-0098", Affiliation: "Example University"})
+	r.Files = append(r.Files, model.File{Key: "model.mat"}, model.File{Key: "notes.txt"})
+	r.Metadata.License = json.RawMessage(`"cc-by-nc-4.0"`)
+
+	a := Assess(r)
+	if got := result(a, "creator-orcid"); got.Score != 50 || got.Passed || !strings.Contains(got.Suggestion, "Roe, Rick") {
+		t.Errorf("creator-orcid = %+v", got)
+	}
+	if got := result(a, "affiliation-ror"); got.Score != 50 {
+		t.Errorf("affiliation-ror = %+v", got)
+	}
+	if got := result(a, "open-formats"); got.Score != 75 || !strings.Contains(got.Suggestion, "model.mat") {
+		t.Errorf("open-formats = %+v", got)
+	}
+	if got := result(a, "open-license"); got.Score != 50 {
+		t.Errorf("open-license = %+v", got)
+	}
+	if a.PrincipleScore(Interoperable) != 75 || a.PrincipleScore(Findable) != 83 {
+		t.Errorf("principles = %+v", a.Principles)
+	}
+}
+
+func TestAccessRight(t *testing.T) {
+	tests := []struct {
+		access, embargo, conditions string
+		want                        float64
+	}{
+		{"open", "", "", 1},
+		{"embargoed", "2030-01-01", "", 1},
+		{"embargoed", "", "", 0.5},
+		{"restricted", "", "Email the PI", 1},
+		{"restricted", "", "", 0.5},
+		{"closed", "", "", 1},
+		{"", "", "", 0},
+		{"secret", "", "", 0},
+	}
+	for _, tt := range tests {
+		r := &model.Record{Metadata: model.Metadata{AccessRight: tt.access, EmbargoDate: tt.embargo, AccessConditions: tt.conditions}}
+		if got, _, _ := checkAccessRight(r); got != tt.want {
+			t.Errorf("%q: score %v, want %v", tt.access, got, tt.want)
+		}
+	}
+}
+
+func TestIsOpenLicense(t *testing.T) {
+	for id, want := range map[string]bool{
+		"cc-by-4.0":    true,
+		"CC-BY-SA-4.0": true,
+		"cc0-1.0":      true,
+		"mit":          true,
+		"apache-2.0":   true,
+		"cc-by-nc-4.0": false,
+		"cc-by-nd-4.0": false,
+		"other-closed": false,
+		"":             false,
+	} {
+		if got := IsOpenLicense(id); got != want {
+			t.Errorf("IsOpenLicense(%q) = %v", id, got)
+		}
+	}
+}
+
+func TestExtension(t *testing.T) {
+	for key, want := range map[string]string{
+		"data.CSV":       "csv",
+		"model.mat.gz":   "mat",
+		"archive.tar.gz": "tar",
+		"bundle.gz":      "gz",
+		"README":         "",
+	} {
+		if got := extension(key); got != want {
+			t.Errorf("extension(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Package harvest incrementally harvests records over OAI-PMH, keeping a
+// checkpoint so interrupted or nightly runs pick up where they left off.
+package harvest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/ran-codes/zenodo-cli/internal/config"
+)
+
+// Checkpoint is the saved state of a harvest for one endpoint, set and
+// metadata prefix.
+type Checkpoint struct {
+	Endpoint        string    `json:"endpoint"`
+	Set             string    `json:"set,omitempty"`
+	MetadataPrefix  string    `json:"metadata_prefix"`
+	From            string    `json:"from,omitempty"`
+	Until           string    `json:"until,omitempty"`
+	ResumptionToken string    `json:"resumption_token,omitempty"`
+	Started         string    `json:"started,omitempty"`
+	LastHarvest     string    `json:"last_harvest,omitempty"`
+	Harvested       int       `json:"harvested"`
+	Updated         time.Time `json:"updated"`
+}
+
+var unsafeName = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// CheckpointPath returns the checkpoint file for a set and metadata prefix
+// under the config directory.
+func CheckpointPath(set, prefix string) string {
+	name := set
+	if name == "" {
+		name = "all"
+	}
+	name = unsafeName.ReplaceAllString(name+"-"+prefix, "_")
+	return filepath.Join(config.GetConfigDir(), "harvest", name+".json")
+}
+
+// LoadCheckpoint reads a checkpoint. A missing file returns (nil, nil).
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading checkpoint: %w", err)
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("parsing checkpoint %s: %w", path, err)
+	}
+	return &cp, nil
+}
+
+// Save writes the checkpoint atomically, creating its directory if needed.
+func (cp *Checkpoint) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("creating checkpoint directory: %w", err)
+	}
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling checkpoint: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("writing checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("writing checkpoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package harvest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ran-codes/zenodo-cli/internal/api"
+)
+
+// Options configures a harvest run.
+type Options struct {
+	Endpoint       string
+	Set            string
+	MetadataPrefix string
+	// From and Until are OAI-PMH datestamps (YYYY-MM-DD or full UTC). An
+	// empty From falls back to the checkpoint's last completed harvest.
+	From  string
+	Until string
+	// Checkpoint is the path of the checkpoint file; empty disables it.
+	Checkpoint string
+	// Reset ignores any saved checkpoint and starts from From.
+	Reset bool
+	// OnPage is called after each page, e.g. for progress reporting.
+	OnPage func(cp *Checkpoint, page int, total int)
+}
+
+// Result is the outcome of a harvest run.
+type Result struct {
+	Records []Row
+	// From is the effective lower bound of the harvest, after applying the checkpoint.
+	From string
+	// Resumed is true when the run continued from a saved resumptionToken.
+	Resumed bool
+}
+
+// Run harvests every page of ListRecords, following resumptionTokens.
+//
+// The checkpoint is saved after each page, so an interrupted run resumes
+// from the last token. Once the list is complete the token is cleared and
+// LastHarvest is set to the server's responseDate of the first page of the
+// run that started the list, which becomes the default From of the next run.
+func Run(client *api.Client, opts Options) (*Result, error) {
+	cp := &Checkpoint{Endpoint: opts.Endpoint, Set: opts.Set, MetadataPrefix: opts.MetadataPrefix}
+	if opts.Checkpoint != "" && !opts.Reset {
+		saved, err := LoadCheckpoint(opts.Checkpoint)
+		if err != nil {
+			return nil, err
+		}
+		if saved != nil && saved.Endpoint == opts.Endpoint {
+			cp = saved
+		}
+	}
+
+	res := &Result{}
+	params := api.OAIParams{MetadataPrefix: opts.MetadataPrefix, Set: opts.Set, From: opts.From, Until: opts.Until}
+	if cp.ResumptionToken != "" && opts.From == "" && opts.Until == "" {
+		params = api.OAIParams{ResumptionToken: cp.ResumptionToken}
+		res.Resumed = true
+		res.From = cp.From
+	} else {
+		if params.From == "" {
+			params.From = cp.LastHarvest
+		}
+		cp.ResumptionToken = ""
+		cp.Harvested = 0
+		cp.From, cp.Until = params.From, params.Until
+		res.From = params.From
+	}
+
+	for page := 1; ; page++ {
+		resp, err := client.OAIListRecords(opts.Endpoint, params)
+		if err != nil {
+			if res.Resumed && page == 1 {
+				return nil, fmt.Errorf("resuming saved harvest (use --reset to start over): %w", err)
+			}
+			return res, err
+		}
+		if page == 1 && !res.Resumed {
+			cp.Started = resp.ResponseDate
+		}
+
+		for _, rec := range resp.ListRecords.Records {
+			res.Records = append(res.Records, ParseRecord(rec))
+		}
+
+		token := resp.ListRecords.ResumptionToken
+		cp.ResumptionToken = token.Token
+		cp.Harvested += len(resp.ListRecords.Records)
+		cp.Updated = time.Now().UTC()
+		if token.Token == "" {
+			cp.LastHarvest = cp.Started
+		}
+		if opts.Checkpoint != "" {
+			if err := cp.Save(opts.Checkpoint); err != nil {
+				return res, err
+			}
+		}
+		if opts.OnPage != nil {
+			opts.OnPage(cp, page, token.CompleteListSize)
+		}
+
+		if token.Token == "" {
+			return res, nil
+		}
+		params = api.OAIParams{ResumptionToken: token.Token}
+	}
+}
@@ -0,0 +1,146 @@
+package harvest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ran-codes/zenodo-cli/internal/api"
+)
+
+const recordXML = `<record><header><identifier>oai:zenodo.org:%d</identifier><datestamp>2024-05-0%dT00:00:00Z</datestamp><setSpec>user-my-org</setSpec></header>
+<metadata><oai_datacite xmlns="http://schema.datacite.org/oai/oai-1.1/"><payload><resource xmlns="http://datacite.org/schema/kernel-4">
+<identifier identifierType="DOI">10.5281/zenodo.%d</identifier><titles><title>Record %d</title></titles>
+</resource></payload></oai_datacite></metadata></record>`
+
+// pagedServer serves two ListRecords pages joined by a resumptionToken and
+// records the query of every request.
+func pagedServer(t *testing.T, queries *[]string, failSecond bool) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		*queries = append(*queries, r.URL.RawQuery)
+		switch q.Get("resumptionToken") {
+		case "":
+			fmt.Fprintf(w, `<OAI-PMH><responseDate>2024-06-01T02:00:00Z</responseDate><ListRecords>`+recordXML+
+				`<resumptionToken completeListSize="2" cursor="0">page-2</resumptionToken></ListRecords></OAI-PMH>`, 1, 1, 1, 1)
+		case "page-2":
+			if failSecond {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprintf(w, `<OAI-PMH><responseDate>2024-06-01T02:00:05Z</responseDate><ListRecords>`+recordXML+
+				`<resumptionToken completeListSize="2" cursor="1"></resumptionToken></ListRecords></OAI-PMH>`, 2, 2, 2, 2)
+		default:
+			fmt.Fprint(w, `<OAI-PMH><error code="badResumptionToken">unknown</error></OAI-PMH>`)
+		}
+	}))
+}
+
+func TestRun_FollowsResumptionTokens(t *testing.T) {
+	var queries []string
+	srv := pagedServer(t, &queries, false)
+	defer srv.Close()
+
+	cpPath := filepath.Join(t.TempDir(), "cp.json")
+	opts := Options{Endpoint: srv.URL, Set: "user-my-org", MetadataPrefix: "oai_datacite", From: "2024-01-01", Checkpoint: cpPath}
+	res, err := Run(api.NewClient(srv.URL, ""), opts)
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if len(res.Records) != 2 || res.Records[1].DOI != "10.5281/zenodo.2" || res.Records[0].Title != "Record 1" {
+		t.Errorf("records = %+v", res.Records)
+	}
+
+	cp, err := LoadCheckpoint(cpPath)
+	if err != nil || cp == nil {
+		t.Fatalf("LoadCheckpoint() = %v, %v", cp, err)
+	}
+	if cp.ResumptionToken != "" || cp.LastHarvest != "2024-06-01T02:00:00Z" || cp.Harvested != 2 {
+		t.Errorf("checkpoint = %+v", cp)
+	}
+
+	// The next run defaults --from to the last harvest.
+	queries = nil
+	opts.From = ""
+	if _, err := Run(api.NewClient(srv.URL, ""), opts); err != nil {
+		t.Fatalf("second Run() error: %v", err)
+	}
+	if want := "from=2024-06-01T02%3A00%3A00Z"; !strings.Contains(queries[0], want) {
+		t.Errorf("first query = %q, want %s", queries[0], want)
+	}
+}
+
+func TestRun_ResumesFromCheckpoint(t *testing.T) {
+	var queries []string
+	srv := pagedServer(t, &queries, true)
+	cpPath := filepath.Join(t.TempDir(), "cp.json")
+	opts := Options{Endpoint: srv.URL, Set: "user-my-org", MetadataPrefix: "oai_datacite", From: "2024-01-01", Checkpoint: cpPath}
+
+	res, err := Run(api.NewClient(srv.URL, ""), opts)
+	srv.Close()
+	if err == nil {
+		t.Fatal("expected error on second page")
+	}
+	if len(res.Records) != 1 {
+		t.Errorf("partial records = %d, want 1", len(res.Records))
+	}
+	cp, _ := LoadCheckpoint(cpPath)
+	if cp.ResumptionToken != "page-2" || cp.LastHarvest != "" {
+		t.Fatalf("checkpoint after failure = %+v", cp)
+	}
+
+	queries = nil
+	srv = pagedServer(t, &queries, false)
+	defer srv.Close()
+	opts.Endpoint, opts.From = srv.URL, ""
+	cp.Endpoint = srv.URL
+	cp.Save(cpPath)
+
+	res, err = Run(api.NewClient(srv.URL, ""), opts)
+	if err != nil {
+		t.Fatalf("resumed Run() error: %v", err)
+	}
+	if !res.Resumed || len(res.Records) != 1 || res.Records[0].DOI != "10.5281/zenodo.2" {
+		t.Errorf("resumed result = %+v", res)
+	}
+	if len(queries) != 1 || queries[0] != "resumptionToken=page-2&verb=ListRecords" {
+		t.Errorf("queries = %v", queries)
+	}
+	cp, _ = LoadCheckpoint(cpPath)
+	if cp.LastHarvest != "2024-06-01T02:00:00Z" || cp.Harvested != 2 {
+		t.Errorf("checkpoint after resume = %+v", cp)
+	}
+}
+
+func TestRun_ResetIgnoresCheckpoint(t *testing.T) {
+	var queries []string
+	srv := pagedServer(t, &queries, false)
+	defer srv.Close()
+
+	cpPath := filepath.Join(t.TempDir(), "cp.json")
+	(&Checkpoint{Endpoint: srv.URL, ResumptionToken: "stale", LastHarvest: "2030-01-01"}).Save(cpPath)
+
+	_, err := Run(api.NewClient(srv.URL, ""), Options{Endpoint: srv.URL, MetadataPrefix: "oai_dc", Checkpoint: cpPath, Reset: true})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if strings.Contains(queries[0], "stale") || strings.Contains(queries[0], "from=") {
+		t.Errorf("first query = %q, want a fresh harvest", queries[0])
+	}
+}
+
+func TestCheckpointPath(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/cfg")
+	got := CheckpointPath("user-my/org", "oai_datacite")
+	want := filepath.Join("/tmp/cfg", "zenodo-cli", "harvest", "user-my_org-oai_datacite.json")
+	if got != want {
+		t.Errorf("CheckpointPath() = %q, want %q", got, want)
+	}
+	if got := CheckpointPath("", "oai_dc"); filepath.Base(got) != "all-oai_dc.json" {
+		t.Errorf("CheckpointPath(all) = %q", got)
+	}
+}
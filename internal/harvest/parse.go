@@ -0,0 +1,166 @@
+package harvest
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// Row is a harvested record flattened for the output formatters.
+type Row struct {
+	Identifier      string   `json:"identifier"`
+	Datestamp       string   `json:"datestamp"`
+	Sets            []string `json:"sets,omitempty"`
+	Deleted         bool     `json:"deleted"`
+	DOI             string   `json:"doi,omitempty"`
+	Title           string   `json:"title,omitempty"`
+	Creators        []string `json:"creators,omitempty"`
+	ORCIDs          []string `json:"orcids,omitempty"`
+	PublicationYear string   `json:"publication_year,omitempty"`
+	ResourceType    string   `json:"resource_type,omitempty"`
+	// Metadata is the raw metadata XML, kept when the format is not one
+	// this package understands.
+	Metadata string `json:"metadata,omitempty"`
+}
+
+// dataciteResource is the subset of the DataCite kernel (3.x/4.x) we flatten.
+// Element names are matched without namespace so either version parses.
+type dataciteResource struct {
+	XMLName    xml.Name `xml:"resource"`
+	Identifier struct {
+		Type  string `xml:"identifierType,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"identifier"`
+	Creators []struct {
+		Name            string `xml:"creatorName"`
+		NameIdentifiers []struct {
+			Scheme string `xml:"nameIdentifierScheme,attr"`
+			Value  string `xml:",chardata"`
+		} `xml:"nameIdentifier"`
+	} `xml:"creators>creator"`
+	Titles          []string `xml:"titles>title"`
+	PublicationYear string   `xml:"publicationYear"`
+	ResourceType    struct {
+		General string `xml:"resourceTypeGeneral,attr"`
+		Value   string `xml:",chardata"`
+	} `xml:"resourceType"`
+}
+
+// oaiDC is the unqualified Dublin Core format every OAI-PMH server supports.
+type oaiDC struct {
+	XMLName     xml.Name `xml:"dc"`
+	Titles      []string `xml:"title"`
+	Creators    []string `xml:"creator"`
+	Dates       []string `xml:"date"`
+	Types       []string `xml:"type"`
+	Identifiers []string `xml:"identifier"`
+}
+
+// oaiDataCite wraps a DataCite resource in Zenodo's oai_datacite payload.
+type oaiDataCite struct {
+	XMLName xml.Name `xml:"oai_datacite"`
+	Payload struct {
+		Resource dataciteResource `xml:"resource"`
+	} `xml:"payload"`
+}
+
+// ParseRecord flattens an OAI-PMH record. DataCite (oai_datacite, datacite)
+// and Dublin Core (oai_dc) metadata are parsed; anything else is kept raw.
+func ParseRecord(rec model.OAIRecord) Row {
+	row := Row{
+		Identifier: rec.Header.Identifier,
+		Datestamp:  rec.Header.Datestamp,
+		Sets:       rec.Header.SetSpecs,
+		Deleted:    rec.Header.Status == "deleted",
+	}
+	inner := strings.TrimSpace(string(rec.Metadata.Inner))
+	if row.Deleted || inner == "" {
+		return row
+	}
+
+	switch rootElement(inner) {
+	case "oai_datacite":
+		var v oaiDataCite
+		if xml.Unmarshal([]byte(inner), &v) == nil {
+			fillDataCite(&row, v.Payload.Resource)
+			return row
+		}
+	case "resource":
+		var v dataciteResource
+		if xml.Unmarshal([]byte(inner), &v) == nil {
+			fillDataCite(&row, v)
+			return row
+		}
+	case "dc":
+		var v oaiDC
+		if xml.Unmarshal([]byte(inner), &v) == nil {
+			fillDC(&row, v)
+			return row
+		}
+	}
+	row.Metadata = inner
+	return row
+}
+
+func fillDataCite(row *Row, r dataciteResource) {
+	if strings.EqualFold(r.Identifier.Type, "DOI") {
+		row.DOI = strings.TrimSpace(r.Identifier.Value)
+	}
+	if len(r.Titles) > 0 {
+		row.Title = strings.TrimSpace(r.Titles[0])
+	}
+	for _, c := range r.Creators {
+		row.Creators = append(row.Creators, strings.TrimSpace(c.Name))
+		for _, id := range c.NameIdentifiers {
+			if strings.EqualFold(id.Scheme, "ORCID") {
+				row.ORCIDs = append(row.ORCIDs, strings.TrimSpace(id.Value))
+			}
+		}
+	}
+	row.PublicationYear = strings.TrimSpace(r.PublicationYear)
+	row.ResourceType = r.ResourceType.General
+	if v := strings.TrimSpace(r.ResourceType.Value); v != "" {
+		row.ResourceType += "/" + v
+	}
+}
+
+func fillDC(row *Row, dc oaiDC) {
+	if len(dc.Titles) > 0 {
+		row.Title = strings.TrimSpace(dc.Titles[0])
+	}
+	for _, c := range dc.Creators {
+		row.Creators = append(row.Creators, strings.TrimSpace(c))
+	}
+	if len(dc.Dates) > 0 && len(dc.Dates[0]) >= 4 {
+		row.PublicationYear = dc.Dates[0][:4]
+	}
+	if len(dc.Types) > 0 {
+		row.ResourceType = strings.TrimSpace(dc.Types[0])
+	}
+	for _, id := range dc.Identifiers {
+		id = strings.TrimSpace(id)
+		if i := strings.Index(id, "doi.org/"); i >= 0 {
+			row.DOI = id[i+len("doi.org/"):]
+			break
+		}
+		if strings.HasPrefix(id, "10.") {
+			row.DOI = id
+			break
+		}
+	}
+}
+
+// rootElement returns the local name of the first element in an XML fragment.
+func rootElement(fragment string) string {
+	dec := xml.NewDecoder(strings.NewReader(fragment))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return ""
+		}
+		if se, ok := tok.(xml.StartElement); ok {
+			return se.Name.Local
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package harvest
+
+import (
+	"testing"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+func TestParseRecord_DataCite(t *testing.T) {
+	rec := model.OAIRecord{
+		Header: model.OAIHeader{Identifier: "oai:zenodo.org:7", Datestamp: "2024-05-01T00:00:00Z", SetSpecs: []string{"user-a", "user-b"}},
+		Metadata: model.OAIMetadata{Inner: []byte(`<oai_datacite xmlns="http://schema.datacite.org/oai/oai-1.1/"><payload>
+<resource xmlns="http://datacite.org/schema/kernel-4">
+  <identifier identifierType="DOI">10.5281/zenodo.7</identifier>
+  <creators><creator><creatorName>Doe, Jane</creatorName>
+    <nameIdentifier nameIdentifierScheme="ORCID">0000-0002-1825-0097</nameIdentifier></creator>
+    <creator><creatorName>Roe, Rick</creatorName></creator></creators>
+  <titles><title>Ocean temperatures</title></titles>
+  <publicationYear>2024</publicationYear>
+  <resourceType resourceTypeGeneral="Dataset"/>
+</resource></payload></oai_datacite>`)},
+	}
+	row := ParseRecord(rec)
+	if row.DOI != "10.5281/zenodo.7" || row.Title != "Ocean temperatures" || row.PublicationYear != "2024" {
+		t.Errorf("row = %+v", row)
+	}
+	if len(row.Creators) != 2 || row.Creators[0] != "Doe, Jane" || len(row.ORCIDs) != 1 {
+		t.Errorf("creators = %v, orcids = %v", row.Creators, row.ORCIDs)
+	}
+	if row.ResourceType != "Dataset" || len(row.Sets) != 2 || row.Metadata != "" {
+		t.Errorf("row = %+v", row)
+	}
+}
+
+func TestParseRecord_DublinCore(t *testing.T) {
+	rec := model.OAIRecord{
+		Header: model.OAIHeader{Identifier: "oai:zenodo.org:8"},
+		Metadata: model.OAIMetadata{Inner: []byte(`<oai_dc:dc xmlns:oai_dc="http://www.openarchives.org/OAI/2.0/oai_dc/" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<dc:title>Model code</dc:title><dc:creator>Doe, Jane</dc:creator><dc:date>2023-02-01</dc:date>
+<dc:identifier>https://zenodo.org/records/8</dc:identifier><dc:identifier>https://doi.org/10.5281/zenodo.8</dc:identifier>
+<dc:type>info:eu-repo/semantics/other</dc:type></oai_dc:dc>`)},
+	}
+	row := ParseRecord(rec)
+	if row.Title != "Model code" || row.DOI != "10.5281/zenodo.8" || row.PublicationYear != "2023" {
+		t.Errorf("row = %+v", row)
+	}
+}
+
+func TestParseRecord_DeletedAndUnknown(t *testing.T) {
+	deleted := ParseRecord(model.OAIRecord{Header: model.OAIHeader{Identifier: "oai:zenodo.org:9", Status: "deleted"}})
+	if !deleted.Deleted {
+		t.Error("expected deleted record")
+	}
+
+	raw := ParseRecord(model.OAIRecord{Metadata: model.OAIMetadata{Inner: []byte(`<marc:record xmlns:marc="x"/>`)}})
+	if raw.Metadata == "" {
+		t.Error("expected raw metadata for an unknown format")
+	}
+}
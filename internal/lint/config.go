@@ -0,0 +1,105 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Config adjusts the built-in rules. In YAML:
+//
+//	min_description_words: 80
+//	rules:
+//	  creator-orcid: error
+//	  grants: off
+type Config struct {
+	// MinDescriptionWords is the description-length threshold; 0 means
+	// DefaultMinDescriptionWords.
+	MinDescriptionWords int `yaml:"min_description_words,omitempty"`
+	// Rules maps rule IDs to levels, overriding the rules' defaults.
+	Rules map[string]Level `yaml:"rules,omitempty"`
+}
+
+// ParseConfig parses a YAML rule-set configuration, rejecting unknown rules
+// and levels.
+func ParseConfig(data []byte) (Config, error) {
+	var raw struct {
+		MinDescriptionWords int               `yaml:"min_description_words"`
+		Rules               map[string]string `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return Config{}, fmt.Errorf("parsing lint config: %w", err)
+	}
+	if raw.MinDescriptionWords < 0 {
+		return Config{}, fmt.Errorf("min_description_words must not be negative")
+	}
+	cfg := Config{MinDescriptionWords: raw.MinDescriptionWords}
+	for id, s := range raw.Rules {
+		if _, ok := FindRule(id); !ok {
+			return Config{}, fmt.Errorf("unknown lint rule %q (known rules: %s)", id, ruleIDs())
+		}
+		level, err := ParseLevel(s)
+		if err != nil {
+			return Config{}, fmt.Errorf("rule %s: %w", id, err)
+		}
+		if cfg.Rules == nil {
+			cfg.Rules = make(map[string]Level)
+		}
+		cfg.Rules[id] = level
+	}
+	return cfg, nil
+}
+
+// ConfigFromValue converts a decoded config value, such as a profile's
+// "lint" section read through viper, to a Config.
+func ConfigFromValue(v interface{}) (Config, error) {
+	if v == nil {
+		return Config{}, nil
+	}
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return Config{}, fmt.Errorf("reading lint config: %w", err)
+	}
+	return ParseConfig(data)
+}
+
+// Merge returns c overlaid with other; settings in other win.
+func (c Config) Merge(other Config) Config {
+	out := Config{MinDescriptionWords: c.MinDescriptionWords, Rules: make(map[string]Level)}
+	if other.MinDescriptionWords != 0 {
+		out.MinDescriptionWords = other.MinDescriptionWords
+	}
+	for id, l := range c.Rules {
+		out.Rules[id] = l
+	}
+	for id, l := range other.Rules {
+		out.Rules[id] = l
+	}
+	return out
+}
+
+// LevelFor returns the effective level of r.
+func (c Config) LevelFor(r Rule) Level {
+	if l, ok := c.Rules[r.ID]; ok {
+		return l
+	}
+	return r.Level
+}
+
+func (c Config) minDescriptionWords() int {
+	if c.MinDescriptionWords > 0 {
+		return c.MinDescriptionWords
+	}
+	return DefaultMinDescriptionWords
+}
+
+func ruleIDs() string {
+	ids := make([]string, len(Rules))
+	for i, r := range Rules {
+		ids[i] = r.ID
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ", ")
+}
@@ -0,0 +1,320 @@
+// Package lint checks record metadata against a metadata policy. Where
+// validate rejects metadata the API would refuse, lint reports metadata
+// that is accepted but falls short of good practice: creators without
+// ORCIDs, thin descriptions, missing keywords and so on.
+//
+// Each rule has a default level (error, warning or info), which a Config can
+// override or turn off, so an institution can enforce its own policy.
+package lint
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+	"github.com/ran-codes/zenodo-cli/internal/validate"
+)
+
+// Level is the severity of a finding.
+type Level string
+
+const (
+	LevelError   Level = "error"
+	LevelWarning Level = "warning"
+	LevelInfo    Level = "info"
+	// LevelOff disables a rule.
+	LevelOff Level = "off"
+)
+
+// rank orders levels so findings can be compared against a threshold.
+func (l Level) rank() int {
+	switch l {
+	case LevelError:
+		return 3
+	case LevelWarning:
+		return 2
+	case LevelInfo:
+		return 1
+	}
+	return 0
+}
+
+// AtLeast reports whether l is as severe as min.
+func (l Level) AtLeast(min Level) bool {
+	return l.rank() >= min.rank() && l.rank() > 0
+}
+
+// ParseLevel parses a level name.
+func ParseLevel(s string) (Level, error) {
+	switch l := Level(strings.ToLower(strings.TrimSpace(s))); l {
+	case LevelError, LevelWarning, LevelInfo, LevelOff:
+		return l, nil
+	}
+	return "", fmt.Errorf("invalid level %q (use error, warning, info or off)", s)
+}
+
+// Finding is one problem found by a rule.
+type Finding struct {
+	Rule    string `json:"rule"`
+	Level   Level  `json:"level"`
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// Report is the result of linting one target. URI locates the target in
+// SARIF output; it defaults to Target.
+type Report struct {
+	Target   string    `json:"target"`
+	URI      string    `json:"uri,omitempty"`
+	Findings []Finding `json:"findings"`
+}
+
+// Rule is a single metadata check. Check returns findings without Rule and
+// Level set; the engine fills them in.
+type Rule struct {
+	ID          string
+	Description string
+	Level       Level
+	Check       func(m *model.Metadata, cfg Config) []Finding
+}
+
+// DefaultMinDescriptionWords is the description-length threshold when the
+// config does not set one.
+const DefaultMinDescriptionWords = 50
+
+// Rules is the built-in rule set, in report order.
+var Rules = []Rule{
+	{
+		ID:          "creator-orcid",
+		Description: "Every creator should be identified by an ORCID",
+		Level:       LevelWarning,
+		Check:       checkCreatorORCID,
+	},
+	{
+		ID:          "creator-affiliation",
+		Description: "Every creator should have an affiliation",
+		Level:       LevelWarning,
+		Check:       checkCreatorAffiliation,
+	},
+	{
+		ID:          "description-length",
+		Description: "The description should be at least min_description_words words long",
+		Level:       LevelWarning,
+		Check:       checkDescriptionLength,
+	},
+	{
+		ID:          "keywords",
+		Description: "The record should have keywords",
+		Level:       LevelWarning,
+		Check:       checkKeywords,
+	},
+	{
+		ID:          "open-access-license",
+		Description: "Open access records must have a license",
+		Level:       LevelError,
+		Check:       checkOpenAccessLicense,
+	},
+	{
+		ID:          "grants",
+		Description: "Funded work should list its grants",
+		Level:       LevelInfo,
+		Check:       checkGrants,
+	},
+	{
+		ID:          "software-version",
+		Description: "Software should have a version string",
+		Level:       LevelWarning,
+		Check:       checkSoftwareVersion,
+	},
+	{
+		ID:          "related-identifier-scheme",
+		Description: "Related identifiers should state their scheme",
+		Level:       LevelWarning,
+		Check:       checkRelatedIdentifierScheme,
+	},
+	{
+		ID:          "identifier-syntax",
+		Description: "Identifiers, dates and language codes must be well formed",
+		Level:       LevelError,
+		Check:       checkIdentifierSyntax,
+	},
+}
+
+// FindRule returns the built-in rule with the given ID.
+func FindRule(id string) (Rule, bool) {
+	for _, r := range Rules {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Lint runs every enabled rule against m. Findings are in rule order; the
+// result is never nil, so it encodes as an empty JSON array.
+func Lint(m model.Metadata, cfg Config) []Finding {
+	findings := []Finding{}
+	for _, r := range Rules {
+		level := cfg.LevelFor(r)
+		if level == LevelOff {
+			continue
+		}
+		for _, f := range r.Check(&m, cfg) {
+			f.Rule = r.ID
+			f.Level = level
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+// Count returns the number of findings at each level.
+func Count(findings []Finding) map[Level]int {
+	counts := map[Level]int{LevelError: 0, LevelWarning: 0, LevelInfo: 0}
+	for _, f := range findings {
+		counts[f.Level]++
+	}
+	return counts
+}
+
+// levelWeight is how much a rule at each level counts towards a score.
+var levelWeight = map[Level]int{LevelError: 3, LevelWarning: 2, LevelInfo: 1}
+
+// Score rates metadata from 0 to 100: the share of enabled rules it passes,
+// weighted by level so a failed error rule costs more than a failed info
+// rule. findings must come from Lint with the same cfg.
+func Score(findings []Finding, cfg Config) int {
+	failed := make(map[string]bool)
+	for _, f := range findings {
+		failed[f.Rule] = true
+	}
+	total, passed := 0, 0
+	for _, r := range Rules {
+		w := levelWeight[cfg.LevelFor(r)]
+		total += w
+		if !failed[r.ID] {
+			passed += w
+		}
+	}
+	if total == 0 {
+		return 100
+	}
+	return passed * 100 / total
+}
+
+// Failed reports whether any finding is at least as severe as threshold.
+func Failed(findings []Finding, threshold Level) bool {
+	for _, f := range findings {
+		if f.Level.AtLeast(threshold) {
+			return true
+		}
+	}
+	return false
+}
+
+// --- rules ---
+
+func checkCreatorORCID(m *model.Metadata, _ Config) []Finding {
+	var out []Finding
+	for i, c := range m.Creators {
+		if strings.TrimSpace(c.ORCID) == "" {
+			out = append(out, Finding{
+				Path:    fmt.Sprintf("metadata.creators[%d].orcid", i),
+				Message: fmt.Sprintf("creator %q has no ORCID", c.Name),
+			})
+		}
+	}
+	return out
+}
+
+func checkCreatorAffiliation(m *model.Metadata, _ Config) []Finding {
+	var out []Finding
+	for i, c := range m.Creators {
+		if strings.TrimSpace(c.Affiliation) == "" {
+			out = append(out, Finding{
+				Path:    fmt.Sprintf("metadata.creators[%d].affiliation", i),
+				Message: fmt.Sprintf("creator %q has no affiliation", c.Name),
+			})
+		}
+	}
+	return out
+}
+
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// WordCount counts the words of an HTML description.
+func WordCount(description string) int {
+	text := html.UnescapeString(tagPattern.ReplaceAllString(description, " "))
+	return len(strings.Fields(text))
+}
+
+func checkDescriptionLength(m *model.Metadata, cfg Config) []Finding {
+	min := cfg.minDescriptionWords()
+	if n := WordCount(m.Description); n < min {
+		return []Finding{{
+			Path:    "metadata.description",
+			Message: fmt.Sprintf("description has %d words; at least %d expected", n, min),
+		}}
+	}
+	return nil
+}
+
+func checkKeywords(m *model.Metadata, _ Config) []Finding {
+	for _, k := range m.Keywords {
+		if strings.TrimSpace(k) != "" {
+			return nil
+		}
+	}
+	return []Finding{{Path: "metadata.keywords", Message: "no keywords"}}
+}
+
+func checkOpenAccessLicense(m *model.Metadata, _ Config) []Finding {
+	if m.AccessRight == "open" && m.LicenseString() == "" {
+		return []Finding{{Path: "metadata.license", Message: "open access record has no license"}}
+	}
+	return nil
+}
+
+func checkGrants(m *model.Metadata, _ Config) []Finding {
+	if len(m.Grants) == 0 {
+		return []Finding{{Path: "metadata.grants", Message: "no grants listed; add the funding award if the work was funded"}}
+	}
+	return nil
+}
+
+func checkSoftwareVersion(m *model.Metadata, _ Config) []Finding {
+	software := m.UploadType == "software" || (m.ResourceType != nil && m.ResourceType.Type == "software")
+	if software && strings.TrimSpace(m.Version) == "" {
+		return []Finding{{Path: "metadata.version", Message: "software has no version string"}}
+	}
+	return nil
+}
+
+func checkRelatedIdentifierScheme(m *model.Metadata, _ Config) []Finding {
+	var out []Finding
+	for i, r := range m.RelatedIdentifiers {
+		if strings.TrimSpace(r.Scheme) == "" {
+			out = append(out, Finding{
+				Path:    fmt.Sprintf("metadata.related_identifiers[%d].scheme", i),
+				Message: fmt.Sprintf("related identifier %q has no scheme", r.Identifier),
+			})
+		}
+	}
+	return out
+}
+
+// checkIdentifierSyntax reports the offline identifier checks of validate.
+// Their messages start with the field, which becomes the finding's path.
+func checkIdentifierSyntax(m *model.Metadata, _ Config) []Finding {
+	var out []Finding
+	for _, e := range validate.Identifiers(*m) {
+		path, msg := "metadata", e
+		if field, rest, ok := strings.Cut(e, ": "); ok {
+			path, msg = "metadata."+field, rest
+		}
+		out = append(out, Finding{Path: path, Message: msg})
+	}
+	return out
+}
@@ -0,0 +1,151 @@
+package lint
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+func goodMetadata() model.Metadata {
+	return model.Metadata{
+		Title:       "Ocean temperatures",
+		Description: "<p>" + strings.Repeat("word ", 60) + "</p>",
+		UploadType:  "software",
+		Version:     "1.0.0",
+		AccessRight: "open",
+		License:     json.RawMessage(`"cc-by-4.0"`),
+		Keywords:    []string{"ocean"},
+		Creators:    []model.Creator{{Name: "Doe, Jane", ORCID: "0000-0002-1825-0097", Affiliation: "Example University"}},
+		Grants:      []model.Grant{{ID: "10.13039/501100000780::283595"}},
+		RelatedIdentifiers: []model.RelatedIdentifier{
+			{Identifier: "10.1234/paper", Relation: "isSupplementTo", Scheme: "doi"},
+		},
+	}
+}
+
+func rulesOf(findings []Finding) []string {
+	var ids []string
+	for _, f := range findings {
+		ids = append(ids, f.Rule)
+	}
+	return ids
+}
+
+func TestLint_Clean(t *testing.T) {
+	if got := Lint(goodMetadata(), Config{}); len(got) != 0 {
+		t.Errorf("findings = %+v", got)
+	}
+}
+
+func TestLint_AllRules(t *testing.T) {
+	m := model.Metadata{
+		Description: "<p>Too <b>short</b></p>",
+		UploadType:  "software",
+		AccessRight: "open",
+		Creators:    []model.Creator{{Name: "Doe, Jane"}, {Name: "Roe, Rick", ORCID: "0000-0001-2345-6789", Affiliation: "CERN"}},
+		RelatedIdentifiers: []model.RelatedIdentifier{
+			{Identifier: "10.1234/paper", Relation: "cites"},
+		},
+	}
+	got := Lint(m, Config{})
+	want := "creator-orcid,creator-affiliation,description-length,keywords,open-access-license,grants,software-version,related-identifier-scheme"
+	if strings.Join(rulesOf(got), ",") != want {
+		t.Fatalf("rules = %v", rulesOf(got))
+	}
+	if got[0].Path != "metadata.creators[0].orcid" || got[0].Level != LevelWarning {
+		t.Errorf("creator-orcid finding = %+v", got[0])
+	}
+	if got[2].Message != "description has 2 words; at least 50 expected" {
+		t.Errorf("description finding = %q", got[2].Message)
+	}
+	if got[4].Level != LevelError || got[5].Level != LevelInfo {
+		t.Errorf("levels = %s, %s", got[4].Level, got[5].Level)
+	}
+}
+
+func TestLint_IdentifierSyntax(t *testing.T) {
+	m := goodMetadata()
+	m.Creators[0].ORCID = "0000-0002-1825-0098"
+	got := Lint(m, Config{})
+	if len(got) != 1 || got[0].Rule != "identifier-syntax" || got[0].Level != LevelError || got[0].Path != "metadata.creators[0].orcid" {
+		t.Errorf("findings = %+v", got)
+	}
+}
+
+func TestLint_ConfigOverrides(t *testing.T) {
+	m := goodMetadata()
+	m.Creators[0].ORCID = ""
+	m.Grants = nil
+	m.Description = strings.Repeat("word ", 60)
+
+	cfg, err := ParseConfig([]byte("min_description_words: 100\nrules:\n  creator-orcid: error\n  grants: off\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := Lint(m, cfg)
+	if strings.Join(rulesOf(got), ",") != "creator-orcid,description-length" {
+		t.Fatalf("rules = %v", rulesOf(got))
+	}
+	if got[0].Level != LevelError {
+		t.Errorf("creator-orcid level = %s", got[0].Level)
+	}
+	// Only the creator-orcid finding is at error level.
+	if !Failed(got, LevelError) || Failed(got[1:], LevelError) || !Failed(got[1:], LevelWarning) {
+		t.Error("Failed() threshold mismatch")
+	}
+}
+
+func TestParseConfig_Errors(t *testing.T) {
+	for _, in := range []string{
+		"rules:\n  no-such-rule: error\n",
+		"rules:\n  keywords: fatal\n",
+		"min_description_words: -1\n",
+		"rules: [",
+	} {
+		if _, err := ParseConfig([]byte(in)); err == nil {
+			t.Errorf("ParseConfig(%q) succeeded", in)
+		}
+	}
+}
+
+func TestConfigFromValue(t *testing.T) {
+	// Profile config arrives from viper as nested maps.
+	cfg, err := ConfigFromValue(map[string]interface{}{
+		"min_description_words": 20,
+		"rules":                 map[string]interface{}{"keywords": "info"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.MinDescriptionWords != 20 || cfg.Rules["keywords"] != LevelInfo {
+		t.Errorf("cfg = %+v", cfg)
+	}
+
+	merged := cfg.Merge(Config{Rules: map[string]Level{"keywords": LevelOff, "grants": LevelWarning}})
+	if merged.MinDescriptionWords != 20 || merged.Rules["keywords"] != LevelOff || merged.Rules["grants"] != LevelWarning {
+		t.Errorf("merged = %+v", merged)
+	}
+}
+
+func TestWordCount(t *testing.T) {
+	if n := WordCount("<p>Data&nbsp;from <a href=\"x\">three</a> sites.</p><p>More</p>"); n != 5 {
+		t.Errorf("WordCount() = %d, want 5", n)
+	}
+}
+
+func TestScore(t *testing.T) {
+	if s := Score(nil, Config{}); s != 100 {
+		t.Errorf("clean score = %d", s)
+	}
+	// Default weights: 2 errors (3 each), 6 warnings (2 each), 1 info = 19.
+	findings := []Finding{{Rule: "open-access-license"}, {Rule: "keywords"}, {Rule: "keywords"}}
+	if s := Score(findings, Config{}); s != (19-3-2)*100/19 {
+		t.Errorf("score = %d", s)
+	}
+	off := Config{Rules: map[string]Level{"keywords": LevelOff}}
+	if s := Score([]Finding{{Rule: "open-access-license"}}, off); s != (17-3)*100/17 {
+		t.Errorf("score with keywords off = %d", s)
+	}
+}
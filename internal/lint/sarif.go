@@ -0,0 +1,125 @@
+package lint
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolName     = "zenodo-lint"
+	toolURI      = "https://github.com/ran-codes/zenodo-cli"
+)
+
+// SARIF 2.1.0 log structure, limited to what the linter emits.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string       `json:"id"`
+	ShortDescription     sarifMessage `json:"shortDescription"`
+	DefaultConfiguration struct {
+		Level   string `json:"level"`
+		Enabled bool   `json:"enabled"`
+	} `json:"defaultConfiguration"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+	} `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// sarifLevel maps a level to SARIF's error, warning and note.
+func sarifLevel(l Level) string {
+	switch l {
+	case LevelError:
+		return "error"
+	case LevelWarning:
+		return "warning"
+	case LevelOff:
+		return "none"
+	}
+	return "note"
+}
+
+// WriteSARIF writes reports as a SARIF 2.1.0 log with one run. The rule
+// descriptors reflect cfg, so disabled rules are marked as such.
+func WriteSARIF(w io.Writer, reports []Report, cfg Config) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{Name: toolName, InformationURI: toolURI}},
+		// Results must be an array, even when empty.
+		Results: []sarifResult{},
+	}
+	index := make(map[string]int, len(Rules))
+	for i, r := range Rules {
+		sr := sarifRule{ID: r.ID, ShortDescription: sarifMessage{Text: r.Description}}
+		level := cfg.LevelFor(r)
+		sr.DefaultConfiguration.Level = sarifLevel(level)
+		sr.DefaultConfiguration.Enabled = level != LevelOff
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sr)
+		index[r.ID] = i
+	}
+	for _, rep := range reports {
+		uri := rep.URI
+		if uri == "" {
+			uri = rep.Target
+		}
+		for _, f := range rep.Findings {
+			var loc sarifLocation
+			loc.PhysicalLocation.ArtifactLocation.URI = uri
+			if f.Path != "" {
+				loc.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: f.Path}}
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    f.Rule,
+				RuleIndex: index[f.Rule],
+				Level:     sarifLevel(f.Level),
+				Message:   sarifMessage{Text: f.Message},
+				Locations: []sarifLocation{loc},
+			})
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}})
+}
@@ -0,0 +1,85 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestWriteSARIF(t *testing.T) {
+	reports := []Report{
+		{Target: "12345", URI: "https://zenodo.org/records/12345", Findings: []Finding{
+			{Rule: "keywords", Level: LevelWarning, Path: "metadata.keywords", Message: "no keywords"},
+			{Rule: "grants", Level: LevelInfo, Path: "metadata.grants", Message: "no grants listed"},
+		}},
+		{Target: "clean.json", Findings: []Finding{}},
+	}
+	cfg := Config{Rules: map[string]Level{"software-version": LevelOff}}
+
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, reports, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID                   string `json:"id"`
+						DefaultConfiguration struct {
+							Level   string `json:"level"`
+							Enabled bool   `json:"enabled"`
+						} `json:"defaultConfiguration"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				RuleIndex int    `json:"ruleIndex"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+					} `json:"physicalLocation"`
+					LogicalLocations []struct {
+						FullyQualifiedName string `json:"fullyQualifiedName"`
+					} `json:"logicalLocations"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("log = %+v", log)
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "zenodo-lint" || len(run.Tool.Driver.Rules) != len(Rules) {
+		t.Errorf("driver = %+v", run.Tool.Driver)
+	}
+	for i, r := range run.Tool.Driver.Rules {
+		if r.ID == "software-version" && r.DefaultConfiguration.Enabled {
+			t.Error("disabled rule reported as enabled")
+		}
+		if r.ID == "open-access-license" && r.DefaultConfiguration.Level != "error" {
+			t.Errorf("rules[%d] level = %s", i, r.DefaultConfiguration.Level)
+		}
+	}
+	if len(run.Results) != 2 {
+		t.Fatalf("results = %+v", run.Results)
+	}
+	res := run.Results[1]
+	if res.RuleID != "grants" || res.Level != "note" || run.Tool.Driver.Rules[res.RuleIndex].ID != "grants" {
+		t.Errorf("result = %+v", res)
+	}
+	loc := res.Locations[0]
+	if loc.PhysicalLocation.ArtifactLocation.URI != "https://zenodo.org/records/12345" || loc.LogicalLocations[0].FullyQualifiedName != "metadata.grants" {
+		t.Errorf("location = %+v", loc)
+	}
+}
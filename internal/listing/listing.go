@@ -0,0 +1,222 @@
+// Package listing implements the record listing modes shared by `records
+// list` and the MCP tools: records authored by an ORCID, records uploaded by
+// the account, one community's records, and records across all of the
+// user's communities.
+//
+// Results are rows (maps) with a top-level "community" field holding the
+// record's community slugs, ready for the output formatters.
+package listing
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ran-codes/zenodo-cli/internal/api"
+)
+
+// Result is a page of rows and the total number of matches.
+type Result struct {
+	Rows  []map[string]interface{}
+	Total int
+	// More reports whether a later page has further rows.
+	More bool
+}
+
+// hasMore reports whether matches remain after the page in params. When
+// total is unknown (negative), a full page is taken to mean there are more.
+func hasMore(params api.RecordListParams, got, total int) bool {
+	if params.Size <= 0 {
+		return false
+	}
+	page := params.Page
+	if page < 1 {
+		page = 1
+	}
+	if total < 0 {
+		return got == params.Size
+	}
+	return page*params.Size < total
+}
+
+// ORCIDQuery returns an Elasticsearch query that matches records where the given
+// ORCID appears as either a creator or contributor.
+func ORCIDQuery(orcid string) string {
+	return fmt.Sprintf("creators.orcid:%s OR contributors.orcid:%s", orcid, orcid)
+}
+
+// Authored searches for published records where orcid appears as creator or
+// contributor, optionally within a community.
+func Authored(client *api.Client, orcid string, params api.RecordListParams) (*Result, error) {
+	if params.Status == "draft" {
+		return nil, fmt.Errorf("authored records cannot be filtered by status draft (drafts are not available via the search API)")
+	}
+	params.Status = ""
+	return Search(client, ORCIDQuery(orcid), params)
+}
+
+// Search runs a published-records search.
+func Search(client *api.Client, q string, params api.RecordListParams) (*Result, error) {
+	result, err := client.SearchRecords(q, params)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := NormalizeCommunities(result.Hits.Hits)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Rows: rows, Total: result.Hits.Total, More: hasMore(params, len(rows), result.Hits.Total)}, nil
+}
+
+// Uploaded lists records and drafts the authenticated account uploaded.
+func Uploaded(client *api.Client, params api.RecordListParams) (*Result, error) {
+	depositions, err := client.ListUserRecords(params)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := NormalizeCommunities(depositions)
+	if err != nil {
+		return nil, err
+	}
+	// The deposit API reports no total, so a full page may be followed by more.
+	return &Result{Rows: rows, Total: len(rows), More: hasMore(params, len(rows), -1)}, nil
+}
+
+// Community lists the records in one community.
+func Community(client *api.Client, slug string, params api.RecordListParams) (*Result, error) {
+	params.Community = slug
+	result, err := client.SearchRecords("", params)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := InjectCommunity(result.Hits.Hits, slug)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Rows: rows, Total: result.Hits.Total, More: hasMore(params, len(rows), result.Hits.Total)}, nil
+}
+
+// AllCommunities aggregates records across the user's communities. A
+// community that fails is reported to warn and skipped. If progress is not
+// nil it is called after each community. It returns the rows and the number
+// of communities.
+func AllCommunities(client *api.Client, params api.RecordListParams, warn func(slug string, err error), progress func(done, total int, slug string)) (*Result, int, error) {
+	communities, err := client.ListUserCommunities("", 0, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+	res := &Result{}
+	n := len(communities.Hits.Hits)
+	for i, c := range communities.Hits.Hits {
+		page, err := Community(client, c.Slug, params)
+		if progress != nil {
+			progress(i+1, n, c.Slug)
+		}
+		if err != nil {
+			if warn != nil {
+				warn(c.Slug, err)
+			}
+			continue
+		}
+		res.Rows = append(res.Rows, page.Rows...)
+		res.Total += page.Total
+		res.More = res.More || page.More
+	}
+	return res, n, nil
+}
+
+// collectPageSize is the page size Collect requests.
+const collectPageSize = 100
+
+// Collect fetches every page of a listing mode, calling fetch with
+// successive pages. If progress is not nil it is called after each page
+// with the rows fetched so far and the total.
+func Collect(params api.RecordListParams, fetch func(api.RecordListParams) (*Result, error), progress func(fetched, total int)) (*Result, error) {
+	params.Size = collectPageSize
+	all := &Result{}
+	for page := 1; ; page++ {
+		params.Page = page
+		res, err := fetch(params)
+		if err != nil {
+			return all, err
+		}
+		all.Rows = append(all.Rows, res.Rows...)
+		all.Total = max(res.Total, len(all.Rows))
+		if progress != nil {
+			progress(len(all.Rows), all.Total)
+		}
+		if !res.More || len(res.Rows) == 0 {
+			return all, nil
+		}
+	}
+}
+
+// NormalizeCommunities converts records or depositions to maps and extracts
+// metadata.communities into a top-level "community" field as a
+// comma-separated string of identifiers.
+func NormalizeCommunities(data interface{}) ([]map[string]interface{}, error) {
+	rows, err := toMaps(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		row["community"] = ExtractCommunities(row)
+	}
+	return rows, nil
+}
+
+// InjectCommunity converts records to maps and adds a top-level "community" field.
+func InjectCommunity(data interface{}, slug string) ([]map[string]interface{}, error) {
+	rows, err := toMaps(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		row["community"] = slug
+	}
+	return rows, nil
+}
+
+// ExtractCommunities pulls community slugs from metadata.communities,
+// handling both "identifier" (depositions) and "id" (records) keys.
+func ExtractCommunities(row map[string]interface{}) string {
+	mc, ok := row["metadata"]
+	if !ok {
+		return ""
+	}
+	meta, ok := mc.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	communities, ok := meta["communities"]
+	if !ok {
+		return ""
+	}
+	arr, ok := communities.([]interface{})
+	if !ok {
+		return ""
+	}
+	var slugs []string
+	for _, item := range arr {
+		if m, ok := item.(map[string]interface{}); ok {
+			if id, ok := m["identifier"]; ok && fmt.Sprintf("%v", id) != "" {
+				slugs = append(slugs, fmt.Sprintf("%v", id))
+			} else if id, ok := m["id"]; ok && fmt.Sprintf("%v", id) != "" {
+				slugs = append(slugs, fmt.Sprintf("%v", id))
+			}
+		}
+	}
+	return strings.Join(slugs, ", ")
+}
+
+func toMaps(data interface{}) ([]map[string]interface{}, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var rows []map[string]interface{}
+	if err := json.Unmarshal(b, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,140 @@
+package listing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch {
+		case r.URL.Path == "/user/communities":
+			json.NewEncoder(w).Encode(model.CommunitySearchResult{Hits: model.CommunityHits{
+				Hits: []model.Community{{Slug: "lab"}, {Slug: "broken"}, {Slug: "funder"}}, Total: 3,
+			}})
+		case r.URL.Path == "/records" && q.Get("communities") == "broken":
+			http.Error(w, `{"status":500,"message":"boom"}`, http.StatusInternalServerError)
+		case r.URL.Path == "/records":
+			rec := model.Record{ID: 1, Metadata: model.Metadata{
+				Title:       q.Get("q") + "|" + q.Get("communities"),
+				Communities: []model.CommunityRef{{ID: "lab"}, {ID: "funder"}},
+			}}
+			json.NewEncoder(w).Encode(model.RecordSearchResult{Hits: model.RecordHits{Hits: []model.Record{rec}, Total: 1}})
+		case r.URL.Path == "/deposit/depositions":
+			json.NewEncoder(w).Encode([]model.Deposition{{ID: 2, Metadata: model.Metadata{
+				Communities: []model.CommunityRef{{Identifier: "lab"}},
+			}}})
+		default:
+			t.Errorf("unexpected %s", r.URL)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func title(row map[string]interface{}) string {
+	return row["metadata"].(map[string]interface{})["title"].(string)
+}
+
+func TestAuthored(t *testing.T) {
+	client := api.NewClient(newServer(t).URL, "tok")
+	res, err := Authored(client, "0000-0002-1825-0097", api.RecordListParams{Community: "lab"})
+	if err != nil {
+		t.Fatalf("Authored() error: %v", err)
+	}
+	want := "creators.orcid:0000-0002-1825-0097 OR contributors.orcid:0000-0002-1825-0097|lab"
+	if got := title(res.Rows[0]); got != want {
+		t.Errorf("query|community = %q, want %q", got, want)
+	}
+	if res.Rows[0]["community"] != "lab, funder" {
+		t.Errorf("community = %v", res.Rows[0]["community"])
+	}
+
+	if _, err := Authored(client, "x", api.RecordListParams{Status: "draft"}); err == nil {
+		t.Error("expected error for drafts")
+	}
+}
+
+func TestUploaded(t *testing.T) {
+	res, err := Uploaded(api.NewClient(newServer(t).URL, "tok"), api.RecordListParams{})
+	if err != nil {
+		t.Fatalf("Uploaded() error: %v", err)
+	}
+	if res.Total != 1 || res.Rows[0]["community"] != "lab" {
+		t.Errorf("result = %+v", res)
+	}
+}
+
+func TestAllCommunities(t *testing.T) {
+	var warned, progressed []string
+	res, n, err := AllCommunities(api.NewClient(newServer(t).URL, "tok"), api.RecordListParams{}, func(slug string, err error) {
+		warned = append(warned, slug)
+	}, func(done, total int, slug string) {
+		progressed = append(progressed, fmt.Sprintf("%d/%d %s", done, total, slug))
+	})
+	if err != nil {
+		t.Fatalf("AllCommunities() error: %v", err)
+	}
+	if n != 3 || len(res.Rows) != 2 || res.Total != 2 {
+		t.Errorf("n = %d, rows = %d, total = %d", n, len(res.Rows), res.Total)
+	}
+	if res.Rows[0]["community"] != "lab" || res.Rows[1]["community"] != "funder" {
+		t.Errorf("communities = %v, %v", res.Rows[0]["community"], res.Rows[1]["community"])
+	}
+	if len(warned) != 1 || warned[0] != "broken" {
+		t.Errorf("warned = %v", warned)
+	}
+	if strings.Join(progressed, ",") != "1/3 lab,2/3 broken,3/3 funder" {
+		t.Errorf("progress = %v", progressed)
+	}
+}
+
+func TestHasMore(t *testing.T) {
+	tests := []struct {
+		page, size, got, total int
+		want                   bool
+	}{
+		{1, 10, 10, 25, true},
+		{3, 10, 5, 25, false},
+		{0, 10, 10, 10, false},
+		{1, 10, 10, -1, true},
+		{2, 10, 4, -1, false},
+		{1, 0, 10, 25, false},
+	}
+	for _, tt := range tests {
+		params := api.RecordListParams{Page: tt.page, Size: tt.size}
+		if got := hasMore(params, tt.got, tt.total); got != tt.want {
+			t.Errorf("hasMore(page %d, size %d, got %d, total %d) = %v, want %v", tt.page, tt.size, tt.got, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestCollect(t *testing.T) {
+	var pages []int
+	res, err := Collect(api.RecordListParams{Community: "lab"}, func(p api.RecordListParams) (*Result, error) {
+		pages = append(pages, p.Page)
+		if p.Size != 100 || p.Community != "lab" {
+			t.Errorf("params = %+v", p)
+		}
+		rows := make([]map[string]interface{}, 100)
+		if p.Page == 3 {
+			rows = rows[:20]
+		}
+		return &Result{Rows: rows, Total: 220, More: hasMore(p, len(rows), 220)}, nil
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Rows) != 220 || res.Total != 220 || len(pages) != 3 {
+		t.Errorf("rows = %d, total = %d, pages = %v", len(res.Rows), res.Total, pages)
+	}
+}
@@ -2,11 +2,30 @@ package model
 
 import "time"
 
+// Permission levels of share links and access grants. Each includes the
+// ones before it.
+const (
+	// PermissionView allows viewing the record and its files, even if
+	// they are restricted or embargoed.
+	PermissionView = "view"
+	// PermissionPreview also allows previewing the record's drafts.
+	PermissionPreview = "preview"
+	// PermissionEdit also allows editing the record's drafts.
+	PermissionEdit = "edit"
+	// PermissionManage also allows managing access and publishing. It can
+	// only be granted to users and groups, not to links.
+	PermissionManage = "manage"
+)
+
 // AccessLink represents a share/access link for a record.
 type AccessLink struct {
-	ID        string    `json:"id"`
-	Token     string    `json:"token,omitempty"`
-	Created   time.Time `json:"created"`
+	ID          string `json:"id"`
+	Token       string `json:"token,omitempty"`
+	Permission  string `json:"permission,omitempty"`
+	Description string `json:"description,omitempty"`
+	// ExpiresAt is when the link stops working, or "" if it never does.
+	ExpiresAt string    `json:"expires_at,omitempty"`
+	Created   time.Time `json:"created_at,omitzero"`
 	Links     Links     `json:"links,omitempty"`
 }
 
@@ -20,3 +39,32 @@ type AccessLinkHits struct {
 	Hits  []AccessLink `json:"hits"`
 	Total int          `json:"total"`
 }
+
+// Access grant subject types.
+const (
+	GrantUser = "user"
+	// GrantRole is a group of users.
+	GrantRole = "role"
+)
+
+// AccessGrant shares a record with a user or group.
+type AccessGrant struct {
+	// ID is the grant's index among the record's grants.
+	ID      int `json:"id"`
+	Subject struct {
+		// Type is GrantUser or GrantRole.
+		Type string `json:"type"`
+		ID   string `json:"id"`
+	} `json:"subject"`
+	Permission string `json:"permission"`
+	// Origin is where the grant was made, e.g. "api" or "ui".
+	Origin string `json:"origin,omitempty"`
+}
+
+// AccessGrantList is the response from the access grants endpoint.
+type AccessGrantList struct {
+	Hits struct {
+		Hits  []AccessGrant `json:"hits"`
+		Total int           `json:"total"`
+	} `json:"hits"`
+}
@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Community represents a Zenodo community.
 type Community struct {
@@ -8,15 +11,23 @@ type Community struct {
 	Slug     string            `json:"slug,omitempty"`
 	URL      string            `json:"url,omitempty"`
 	Metadata CommunityMetadata `json:"metadata,omitempty"`
-	Created  time.Time         `json:"created"`
-	Updated  time.Time         `json:"updated"`
-	Links    CommunityLinks    `json:"links,omitempty"`
+	Access   CommunityAccess   `json:"access,omitzero"`
+	// CustomFields are kept as returned, so an update preserves them.
+	CustomFields json.RawMessage `json:"custom_fields,omitempty"`
+	Revision     int             `json:"revision_id,omitempty"`
+	Created      time.Time       `json:"created"`
+	Updated      time.Time       `json:"updated"`
+	Links        CommunityLinks  `json:"links,omitempty"`
 }
 
 // CommunityLinks contains links returned by the communities API.
 type CommunityLinks struct {
 	Self     string `json:"self,omitempty"`
 	SelfHTML string `json:"self_html,omitempty"`
+	Logo     string `json:"logo,omitempty"`
+	Members  string `json:"members,omitempty"`
+	Records  string `json:"records,omitempty"`
+	Requests string `json:"requests,omitempty"`
 }
 
 // CommunityMetadata contains the community's metadata fields.
@@ -24,7 +35,43 @@ type CommunityMetadata struct {
 	Title          string `json:"title,omitempty"`
 	Description    string `json:"description,omitempty"`
 	CurationPolicy string `json:"curation_policy,omitempty"`
-	Website        string `json:"website,omitempty"`
+	// Page is the community's "About" page, in HTML.
+	Page    string `json:"page,omitempty"`
+	Website string `json:"website,omitempty"`
+	// Type is the community type, e.g. "organization", "event", "topic"
+	// or "project".
+	Type          *RDMVocabRef            `json:"type,omitempty"`
+	Organizations []CommunityOrganization `json:"organizations,omitempty"`
+	Funding       []RDMFunding            `json:"funding,omitempty"`
+}
+
+// MergeJSON overlays a partial JSON metadata object onto m. Top-level keys
+// present in data replace the current values; all other fields are kept.
+func (m *CommunityMetadata) MergeJSON(data []byte) error {
+	return overlayJSON(m, data)
+}
+
+// CommunityOrganization is an organization behind a community, by ROR ID
+// when it is in the affiliations vocabulary, or else by name.
+type CommunityOrganization struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// CommunityAccess holds a community's visibility and review policies.
+type CommunityAccess struct {
+	// Visibility is "public" or "restricted".
+	Visibility        string `json:"visibility,omitempty"`
+	MembersVisibility string `json:"members_visibility,omitempty"`
+	// MemberPolicy is "open" if users may ask to join, else "closed".
+	MemberPolicy string `json:"member_policy,omitempty"`
+	// RecordPolicy is "open" if anyone may submit records, else "closed";
+	// newer InvenioRDM versions call it record_submission_policy.
+	RecordPolicy           string `json:"record_policy,omitempty"`
+	RecordSubmissionPolicy string `json:"record_submission_policy,omitempty"`
+	// ReviewPolicy says who may publish without review: "closed" (nobody),
+	// "open" (curators and owners) or "members".
+	ReviewPolicy string `json:"review_policy,omitempty"`
 }
 
 // CommunitySearchResult is the paginated response from communities search.
@@ -38,3 +85,57 @@ type CommunityHits struct {
 	Hits  []Community `json:"hits"`
 	Total int         `json:"total"`
 }
+
+// Community member roles, from most to least privileged.
+const (
+	RoleOwner   = "owner"
+	RoleManager = "manager"
+	RoleCurator = "curator"
+	RoleReader  = "reader"
+)
+
+// CommunityMember is a user or group with a role in a community.
+type CommunityMember struct {
+	ID      string `json:"id,omitempty"`
+	Role    string `json:"role"`
+	Visible bool   `json:"visible"`
+	Member  struct {
+		// Type is "user" or "group".
+		Type string `json:"type"`
+		ID   string `json:"id"`
+		Name string `json:"name,omitempty"`
+		// Description is the user's affiliation or the group's description.
+		Description string `json:"description,omitempty"`
+	} `json:"member"`
+	Created time.Time `json:"created,omitzero"`
+}
+
+// CommunityMemberSearchResult is the paginated response from a members search.
+type CommunityMemberSearchResult struct {
+	Hits struct {
+		Hits  []CommunityMember `json:"hits"`
+		Total int               `json:"total"`
+	} `json:"hits"`
+}
+
+// CommunityInclusion is a community a record was submitted to, and the
+// request the community reviews it through.
+type CommunityInclusion struct {
+	CommunityID string   `json:"community_id"`
+	RequestID   string   `json:"request_id,omitempty"`
+	Request     *Request `json:"request,omitempty"`
+}
+
+// CommunityError is a community a record could not be added to or removed
+// from.
+type CommunityError struct {
+	CommunityID string `json:"community"`
+	Message     string `json:"message"`
+}
+
+// RecordCommunitiesResult is the response from adding a record to, or
+// removing it from, communities.
+type RecordCommunitiesResult struct {
+	Processed []CommunityInclusion `json:"processed,omitempty"`
+	Errors    []CommunityError     `json:"errors,omitempty"`
+}
@@ -0,0 +1,36 @@
+package model
+
+import "strings"
+
+// File represents a file attached to a record.
+type File struct {
+	ID       string    `json:"id,omitempty"`
+	Key      string    `json:"key"`
+	Size     int64     `json:"size"`
+	Checksum string    `json:"checksum,omitempty"`
+	Links    FileLinks `json:"links,omitempty"`
+}
+
+// FileLinks contains links returned for a record file.
+type FileLinks struct {
+	Self    string `json:"self,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+// DownloadURL returns the link to the file's content.
+func (f File) DownloadURL() string {
+	if f.Links.Content != "" {
+		return f.Links.Content
+	}
+	return f.Links.Self
+}
+
+// MD5 returns the hex MD5 digest from the checksum field, which the API
+// reports as "md5:<hex>". Returns "" for other algorithms.
+func (f File) MD5() string {
+	algo, sum, ok := strings.Cut(f.Checksum, ":")
+	if !ok || algo != "md5" {
+		return ""
+	}
+	return sum
+}
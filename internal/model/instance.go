@@ -0,0 +1,42 @@
+package model
+
+import "slices"
+
+// Endpoint names recorded in Capabilities.Endpoints.
+const (
+	EndpointRecords      = "records"      // /records search and record API
+	EndpointDeposit      = "deposit"      // legacy /deposit/depositions API
+	EndpointDrafts       = "drafts"       // InvenioRDM draft API (/user/records)
+	EndpointCommunities  = "communities"  // /communities
+	EndpointRequests     = "requests"     // /requests (community inclusion reviews)
+	EndpointLicenses     = "licenses"     // legacy /licenses search
+	EndpointVocabularies = "vocabularies" // /vocabularies/<kind>
+	EndpointFunders      = "funders"      // /funders and /awards vocabularies
+	EndpointOAI          = "oai-pmh"      // /oai2d, outside the REST API base
+)
+
+// Capabilities describes what an InvenioRDM instance supports, as probed
+// by `zenodo config add-instance` and stored in the profile. A nil
+// *Capabilities means nothing was probed, and Zenodo is assumed.
+type Capabilities struct {
+	// Probed is when the instance was probed, in RFC 3339.
+	Probed       string   `json:"probed" mapstructure:"probed"`
+	Endpoints    []string `json:"endpoints" mapstructure:"endpoints"`
+	Vocabularies []string `json:"vocabularies" mapstructure:"vocabularies"`
+	// RateLimit and SearchRateLimit are the requests per minute allowed
+	// in general and on search endpoints; 0 means unknown.
+	RateLimit       int `json:"rate_limit,omitempty" mapstructure:"rate_limit"`
+	SearchRateLimit int `json:"search_rate_limit,omitempty" mapstructure:"search_rate_limit"`
+	// SearchPrefixes are the API paths that count against the search limit.
+	SearchPrefixes []string `json:"search_prefixes,omitempty" mapstructure:"search_prefixes"`
+}
+
+// Has reports whether the instance serves an endpoint.
+func (c *Capabilities) Has(endpoint string) bool {
+	return c == nil || slices.Contains(c.Endpoints, endpoint)
+}
+
+// HasVocabulary reports whether the instance serves /vocabularies/<kind>.
+func (c *Capabilities) HasVocabulary(kind string) bool {
+	return c == nil || slices.Contains(c.Vocabularies, kind)
+}
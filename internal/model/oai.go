@@ -0,0 +1,54 @@
+package model
+
+import "encoding/xml"
+
+// OAIResponse is an OAI-PMH response envelope.
+type OAIResponse struct {
+	XMLName      xml.Name        `xml:"OAI-PMH"`
+	ResponseDate string          `xml:"responseDate"`
+	Errors       []OAIError      `xml:"error"`
+	ListRecords  *OAIListRecords `xml:"ListRecords"`
+}
+
+// OAIError is an OAI-PMH protocol error, e.g. noRecordsMatch or badResumptionToken.
+type OAIError struct {
+	Code    string `xml:"code,attr"`
+	Message string `xml:",chardata"`
+}
+
+func (e OAIError) Error() string {
+	return "OAI-PMH error " + e.Code + ": " + e.Message
+}
+
+// OAIListRecords is the payload of a ListRecords response.
+type OAIListRecords struct {
+	Records         []OAIRecord        `xml:"record"`
+	ResumptionToken OAIResumptionToken `xml:"resumptionToken"`
+}
+
+// OAIResumptionToken continues an incomplete list. An empty token marks the last page.
+type OAIResumptionToken struct {
+	Token            string `xml:",chardata"`
+	CompleteListSize int    `xml:"completeListSize,attr"`
+	Cursor           int    `xml:"cursor,attr"`
+	ExpirationDate   string `xml:"expirationDate,attr"`
+}
+
+// OAIRecord is a single harvested record.
+type OAIRecord struct {
+	Header   OAIHeader   `xml:"header"`
+	Metadata OAIMetadata `xml:"metadata"`
+}
+
+// OAIHeader identifies a harvested record.
+type OAIHeader struct {
+	Status     string   `xml:"status,attr"`
+	Identifier string   `xml:"identifier"`
+	Datestamp  string   `xml:"datestamp"`
+	SetSpecs   []string `xml:"setSpec"`
+}
+
+// OAIMetadata holds the record metadata as raw XML in the requested format.
+type OAIMetadata struct {
+	Inner []byte `xml:",innerxml"`
+}
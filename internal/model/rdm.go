@@ -0,0 +1,401 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// RDMRecord is a record or draft in the InvenioRDM schema, as returned by
+// /records with the application/vnd.inveniordm.v1+json media type and by
+// the /records/{id}/draft endpoints. Unlike Record, whose Metadata mirrors
+// the legacy deposit schema, its fields are typed as InvenioRDM defines them.
+type RDMRecord struct {
+	ID           string            `json:"id,omitempty"`
+	PIDs         map[string]RDMPID `json:"pids,omitempty"`
+	Metadata     RDMMetadata       `json:"metadata"`
+	CustomFields RDMCustomFields   `json:"custom_fields,omitzero"`
+	Access       RDMAccess         `json:"access,omitzero"`
+	Files        RDMFiles          `json:"files,omitzero"`
+	Parent       *RDMParent        `json:"parent,omitempty"`
+	Versions     *RDMVersions      `json:"versions,omitempty"`
+	Status       string            `json:"status,omitempty"`
+	IsPublished  bool              `json:"is_published,omitempty"`
+	IsDraft      bool              `json:"is_draft,omitempty"`
+	Revision     int               `json:"revision_id,omitempty"`
+	Created      time.Time         `json:"created,omitzero"`
+	Updated      time.Time         `json:"updated,omitzero"`
+	Links        map[string]string `json:"links,omitempty"`
+}
+
+// DOI returns the record's DOI, if it has one.
+func (r *RDMRecord) DOI() string {
+	return r.PIDs["doi"].Identifier
+}
+
+// RDMPID is a persistent identifier registered for a record.
+type RDMPID struct {
+	Identifier string `json:"identifier"`
+	Provider   string `json:"provider,omitempty"`
+	Client     string `json:"client,omitempty"`
+}
+
+// RDMMetadata holds a record's descriptive metadata.
+type RDMMetadata struct {
+	ResourceType           *RDMVocabRef           `json:"resource_type,omitempty"`
+	Creators               []RDMCreatorship       `json:"creators,omitempty"`
+	Title                  string                 `json:"title,omitempty"`
+	PublicationDate        string                 `json:"publication_date,omitempty"`
+	AdditionalTitles       []RDMTitle             `json:"additional_titles,omitempty"`
+	Description            string                 `json:"description,omitempty"`
+	AdditionalDescriptions []RDMDescription       `json:"additional_descriptions,omitempty"`
+	Rights                 []RDMRight             `json:"rights,omitempty"`
+	Contributors           []RDMCreatorship       `json:"contributors,omitempty"`
+	Subjects               []RDMSubject           `json:"subjects,omitempty"`
+	Languages              []RDMVocabRef          `json:"languages,omitempty"`
+	Dates                  []RDMDate              `json:"dates,omitempty"`
+	Version                string                 `json:"version,omitempty"`
+	Publisher              string                 `json:"publisher,omitempty"`
+	Copyright              string                 `json:"copyright,omitempty"`
+	Identifiers            []RDMIdentifier        `json:"identifiers,omitempty"`
+	RelatedIdentifiers     []RDMRelatedIdentifier `json:"related_identifiers,omitempty"`
+	Sizes                  []string               `json:"sizes,omitempty"`
+	Formats                []string               `json:"formats,omitempty"`
+	Locations              *RDMLocations          `json:"locations,omitempty"`
+	Funding                []RDMFunding           `json:"funding,omitempty"`
+	References             []RDMReference         `json:"references,omitempty"`
+}
+
+// RDMVocabRef refers to a vocabulary term by ID. Responses also carry the
+// term's title, keyed by language.
+type RDMVocabRef struct {
+	ID    string            `json:"id"`
+	Title map[string]string `json:"title,omitempty"`
+}
+
+// TitleString returns the English title, or the ID if there is none.
+func (v RDMVocabRef) TitleString() string {
+	if t := v.Title["en"]; t != "" {
+		return t
+	}
+	return v.ID
+}
+
+// RDMCreatorship is a creator or contributor: a person or organization with
+// a role and affiliations.
+type RDMCreatorship struct {
+	PersonOrOrg  RDMPersonOrOrg   `json:"person_or_org"`
+	Role         *RDMVocabRef     `json:"role,omitempty"`
+	Affiliations []RDMAffiliation `json:"affiliations,omitempty"`
+}
+
+// Person-or-organization types.
+const (
+	RDMPersonal       = "personal"
+	RDMOrganizational = "organizational"
+)
+
+// RDMPersonOrOrg names a person ("personal") or organization
+// ("organizational"). Name is "Family, Given" for people.
+type RDMPersonOrOrg struct {
+	Type        string          `json:"type"`
+	Name        string          `json:"name,omitempty"`
+	GivenName   string          `json:"given_name,omitempty"`
+	FamilyName  string          `json:"family_name,omitempty"`
+	Identifiers []RDMIdentifier `json:"identifiers,omitempty"`
+}
+
+// Identifier returns the person's or organization's identifier in the
+// given scheme, such as "orcid", "gnd" or "ror".
+func (p RDMPersonOrOrg) Identifier(scheme string) string {
+	for _, id := range p.Identifiers {
+		if id.Scheme == scheme {
+			return id.Identifier
+		}
+	}
+	return ""
+}
+
+// RDMAffiliation is an organization, by ROR ID when it is in the
+// affiliations vocabulary, or else by name.
+type RDMAffiliation struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// RDMIdentifier is an identifier with its scheme.
+type RDMIdentifier struct {
+	Identifier string `json:"identifier"`
+	Scheme     string `json:"scheme,omitempty"`
+}
+
+// RDMTitle is an alternative or translated title.
+type RDMTitle struct {
+	Title string       `json:"title"`
+	Type  RDMVocabRef  `json:"type"`
+	Lang  *RDMVocabRef `json:"lang,omitempty"`
+}
+
+// RDMDescription is an additional description, such as notes or methods.
+type RDMDescription struct {
+	Description string       `json:"description"`
+	Type        RDMVocabRef  `json:"type"`
+	Lang        *RDMVocabRef `json:"lang,omitempty"`
+}
+
+// RDMRight is a license, by ID from the licenses vocabulary or as free text.
+type RDMRight struct {
+	ID          string            `json:"id,omitempty"`
+	Title       map[string]string `json:"title,omitempty"`
+	Description map[string]string `json:"description,omitempty"`
+	Link        string            `json:"link,omitempty"`
+}
+
+// RDMSubject is a keyword (Subject only) or a term from a subject
+// vocabulary (ID and Scheme).
+type RDMSubject struct {
+	ID      string `json:"id,omitempty"`
+	Subject string `json:"subject,omitempty"`
+	Scheme  string `json:"scheme,omitempty"`
+}
+
+// RDMDate is a date or EDTF interval with its type, such as "collected".
+type RDMDate struct {
+	Date        string      `json:"date"`
+	Type        RDMVocabRef `json:"type"`
+	Description string      `json:"description,omitempty"`
+}
+
+// RDMRelatedIdentifier links the record to another resource.
+type RDMRelatedIdentifier struct {
+	Identifier   string       `json:"identifier"`
+	Scheme       string       `json:"scheme,omitempty"`
+	RelationType RDMVocabRef  `json:"relation_type"`
+	ResourceType *RDMVocabRef `json:"resource_type,omitempty"`
+}
+
+// RDMLocations holds the places a record covers.
+type RDMLocations struct {
+	Features []RDMLocation `json:"features"`
+}
+
+// RDMLocation is a place, by name, GeoJSON geometry, identifiers, or all
+// of these.
+type RDMLocation struct {
+	Geometry    *RDMGeometry    `json:"geometry,omitempty"`
+	Identifiers []RDMIdentifier `json:"identifiers,omitempty"`
+	Place       string          `json:"place,omitempty"`
+	Description string          `json:"description,omitempty"`
+}
+
+// RDMGeometry is a GeoJSON geometry. Coordinates are kept raw because their
+// nesting depends on the type (Point, Polygon, ...).
+type RDMGeometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// RDMFunding names a funder and, optionally, the award.
+type RDMFunding struct {
+	Funder RDMFunder `json:"funder"`
+	Award  *RDMAward `json:"award,omitempty"`
+}
+
+// RDMFunder is a funder from the funders vocabulary (ID is a ROR ID) or by
+// name.
+type RDMFunder struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// RDMAward is an award from the awards vocabulary (ID is
+// "<funder>::<number>") or described inline.
+type RDMAward struct {
+	ID          string            `json:"id,omitempty"`
+	Title       map[string]string `json:"title,omitempty"`
+	Number      string            `json:"number,omitempty"`
+	Acronym     string            `json:"acronym,omitempty"`
+	Identifiers []RDMIdentifier   `json:"identifiers,omitempty"`
+}
+
+// RDMReference is a bibliographic reference.
+type RDMReference struct {
+	Reference  string `json:"reference"`
+	Scheme     string `json:"scheme,omitempty"`
+	Identifier string `json:"identifier,omitempty"`
+}
+
+// RDMCustomFields holds Zenodo's custom fields, which carry the legacy
+// journal, conference, imprint and thesis metadata. Other custom fields
+// (such as an instance's own) are kept in Other, so a record can be read
+// and written back without losing them.
+type RDMCustomFields struct {
+	Journal          *RDMJournal `json:"journal:journal,omitempty"`
+	Meeting          *RDMMeeting `json:"meeting:meeting,omitempty"`
+	Imprint          *RDMImprint `json:"imprint:imprint,omitempty"`
+	ThesisUniversity string      `json:"thesis:university,omitempty"`
+
+	Other map[string]json.RawMessage `json:"-"`
+}
+
+// rdmCustomFields has the typed fields of RDMCustomFields, without its
+// JSON methods.
+type rdmCustomFields RDMCustomFields
+
+// rdmCustomFieldKeys are the custom fields RDMCustomFields types.
+var rdmCustomFieldKeys = []string{"journal:journal", "meeting:meeting", "imprint:imprint", "thesis:university"}
+
+// IsZero reports whether no custom field is set.
+func (f RDMCustomFields) IsZero() bool {
+	return f.Journal == nil && f.Meeting == nil && f.Imprint == nil && f.ThesisUniversity == "" && len(f.Other) == 0
+}
+
+// MarshalJSON writes the typed fields and Other as one object.
+func (f RDMCustomFields) MarshalJSON() ([]byte, error) {
+	typed, err := json.Marshal(rdmCustomFields(f))
+	if err != nil || len(f.Other) == 0 {
+		return typed, err
+	}
+	all := make(map[string]json.RawMessage, len(f.Other)+len(rdmCustomFieldKeys))
+	for k, v := range f.Other {
+		all[k] = v
+	}
+	if err := json.Unmarshal(typed, &all); err != nil {
+		return nil, err
+	}
+	return json.Marshal(all)
+}
+
+// UnmarshalJSON reads the typed fields and keeps the rest in Other.
+func (f *RDMCustomFields) UnmarshalJSON(data []byte) error {
+	var typed rdmCustomFields
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	for _, k := range rdmCustomFieldKeys {
+		delete(all, k)
+	}
+	if len(all) > 0 {
+		typed.Other = all
+	}
+	*f = RDMCustomFields(typed)
+	return nil
+}
+
+// RDMJournal describes the journal an article appeared in.
+type RDMJournal struct {
+	Title  string `json:"title,omitempty"`
+	ISSN   string `json:"issn,omitempty"`
+	Volume string `json:"volume,omitempty"`
+	Issue  string `json:"issue,omitempty"`
+	Pages  string `json:"pages,omitempty"`
+}
+
+// RDMMeeting describes the conference a record was presented at.
+type RDMMeeting struct {
+	Title       string `json:"title,omitempty"`
+	Acronym     string `json:"acronym,omitempty"`
+	Dates       string `json:"dates,omitempty"`
+	Place       string `json:"place,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Session     string `json:"session,omitempty"`
+	SessionPart string `json:"session_part,omitempty"`
+}
+
+// RDMImprint describes the book or report a chapter is part of.
+type RDMImprint struct {
+	Title string `json:"title,omitempty"`
+	ISBN  string `json:"isbn,omitempty"`
+	Place string `json:"place,omitempty"`
+	Pages string `json:"pages,omitempty"`
+}
+
+// Access levels of a record or its files.
+const (
+	RDMPublic     = "public"
+	RDMRestricted = "restricted"
+)
+
+// RDMAccess controls who can see the record and its files.
+type RDMAccess struct {
+	Record  string      `json:"record,omitempty"`
+	Files   string      `json:"files,omitempty"`
+	Embargo *RDMEmbargo `json:"embargo,omitempty"`
+	Status  string      `json:"status,omitempty"`
+}
+
+// RDMEmbargo restricts access until a date.
+type RDMEmbargo struct {
+	Active bool   `json:"active"`
+	Until  string `json:"until,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// RDMFiles summarizes a record's files.
+type RDMFiles struct {
+	Enabled        bool   `json:"enabled"`
+	Count          int    `json:"count,omitempty"`
+	TotalBytes     int64  `json:"total_bytes,omitempty"`
+	DefaultPreview string `json:"default_preview,omitempty"`
+}
+
+// RDMParent holds what all versions of a record share.
+type RDMParent struct {
+	ID          string            `json:"id,omitempty"`
+	PIDs        map[string]RDMPID `json:"pids,omitempty"`
+	Communities *RDMCommunities   `json:"communities,omitempty"`
+	Access      *RDMParentAccess  `json:"access,omitempty"`
+}
+
+// RDMCommunities lists the communities that include the record.
+type RDMCommunities struct {
+	IDs     []string            `json:"ids,omitempty"`
+	Default string              `json:"default,omitempty"`
+	Entries []RDMCommunityEntry `json:"entries,omitempty"`
+}
+
+// RDMCommunityEntry is an expanded community in RDMCommunities.
+type RDMCommunityEntry struct {
+	ID   string `json:"id"`
+	Slug string `json:"slug"`
+}
+
+// RDMParentAccess holds the owner and access-request settings.
+type RDMParentAccess struct {
+	OwnedBy  map[string]any     `json:"owned_by,omitempty"`
+	Settings *RDMAccessSettings `json:"settings,omitempty"`
+}
+
+// RDMAccessSettings controls whether users may request access to
+// restricted files, and the conditions they must accept.
+type RDMAccessSettings struct {
+	AllowUserRequests    bool   `json:"allow_user_requests"`
+	AllowGuestRequests   bool   `json:"allow_guest_requests"`
+	AcceptConditionsText string `json:"accept_conditions_text,omitempty"`
+	SecretLinkExpiration int    `json:"secret_link_expiration,omitempty"`
+}
+
+// RDMVersions places a record among its versions.
+type RDMVersions struct {
+	Index    int  `json:"index"`
+	IsLatest bool `json:"is_latest"`
+}
+
+// RDMDraftFile is a file of a draft. Uploading one takes three steps:
+// register the key, upload the content, then commit it.
+type RDMDraftFile struct {
+	Key      string            `json:"key"`
+	Status   string            `json:"status,omitempty"`
+	Size     int64             `json:"size,omitempty"`
+	Checksum string            `json:"checksum,omitempty"`
+	Links    map[string]string `json:"links,omitempty"`
+}
+
+// RDMDraftFiles is the response from the draft files endpoint.
+type RDMDraftFiles struct {
+	Enabled bool           `json:"enabled"`
+	Entries []RDMDraftFile `json:"entries"`
+}
@@ -13,6 +13,7 @@ type Record struct {
 	ConceptDOI  string    `json:"conceptdoi,omitempty"`
 	Title       string    `json:"title,omitempty"`
 	Metadata    Metadata  `json:"metadata"`
+	Files       []File    `json:"files,omitempty"`
 	Stats       Stats     `json:"stats,omitempty"`
 	Links       Links     `json:"links,omitempty"`
 	Created     time.Time `json:"created"`
@@ -160,6 +161,68 @@ func (m *Metadata) LicenseString() string {
 	return string(m.License)
 }
 
+// MergeJSON overlays a partial JSON metadata object onto m. Top-level keys
+// present in data replace the current values; all other fields are kept.
+func (m *Metadata) MergeJSON(data []byte) error {
+	return overlayJSON(m, data)
+}
+
+// overlayJSON overlays the top-level keys of the JSON object data onto v,
+// replacing the values of those keys and keeping all other fields.
+func overlayJSON[T any](v *T, data []byte) error {
+	current, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	var base map[string]json.RawMessage
+	if err := json.Unmarshal(current, &base); err != nil {
+		return err
+	}
+
+	var overlay map[string]json.RawMessage
+	if err := json.Unmarshal(data, &overlay); err != nil {
+		return err
+	}
+
+	// Overlay wins for any key it specifies.
+	for k, val := range overlay {
+		base[k] = val
+	}
+
+	merged, err := json.Marshal(base)
+	if err != nil {
+		return err
+	}
+
+	// Decode into a zero value so nothing of v leaks into replaced keys.
+	var out T
+	if err := json.Unmarshal(merged, &out); err != nil {
+		return err
+	}
+	*v = out
+	return nil
+}
+
+// ParseMetadataJSON parses metadata from a bare metadata object, or from a
+// deposition or record that wraps it under "metadata".
+func ParseMetadataJSON(data []byte) (*Metadata, error) {
+	var wrapper struct {
+		Metadata json.RawMessage `json:"metadata"`
+	}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return nil, err
+	}
+	if len(wrapper.Metadata) > 0 {
+		data = wrapper.Metadata
+	}
+	var m Metadata
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
 // Subject represents a subject classification.
 type Subject struct {
 	Term       string `json:"term"`
@@ -0,0 +1,169 @@
+package model
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Request types for getting a record into a community.
+const (
+	// RequestCommunitySubmission asks a community to review a draft
+	// submitted to it; accepting it publishes the record.
+	RequestCommunitySubmission = "community-submission"
+	// RequestCommunityInclusion asks a community to include an already
+	// published record.
+	RequestCommunityInclusion = "community-inclusion"
+)
+
+// Request types for getting access to a restricted record. The record's
+// owner receives them.
+const (
+	// RequestUserAccess is an access request from a logged-in user.
+	RequestUserAccess = "user-access-request"
+	// RequestGuestAccess is an access request from a guest, by email;
+	// accepting it sends them a share link.
+	RequestGuestAccess = "guest-access-request"
+)
+
+// Request statuses. A request is open while it is submitted.
+const (
+	RequestSubmitted = "submitted"
+	RequestAccepted  = "accepted"
+	RequestDeclined  = "declined"
+	RequestCancelled = "cancelled"
+	RequestExpired   = "expired"
+)
+
+// Request is an InvenioRDM request, such as a record awaiting review by a
+// community.
+type Request struct {
+	ID        string    `json:"id"`
+	Number    string    `json:"number,omitempty"`
+	Type      string    `json:"type"`
+	Title     string    `json:"title,omitempty"`
+	Status    string    `json:"status"`
+	IsOpen    bool      `json:"is_open"`
+	Created   time.Time `json:"created,omitzero"`
+	Updated   time.Time `json:"updated,omitzero"`
+	ExpiresAt string    `json:"expires_at,omitempty"`
+	// CreatedBy, Receiver and Topic reference an entity by type, e.g.
+	// {"user": "123"}, {"community": "<uuid>"} or {"record": "abcd-1234"}.
+	CreatedBy map[string]string `json:"created_by,omitempty"`
+	Receiver  map[string]string `json:"receiver,omitempty"`
+	Topic     map[string]string `json:"topic,omitempty"`
+	// Expanded holds the referenced entities when the request was fetched
+	// with expand=1.
+	Expanded *RequestExpanded `json:"expanded,omitempty"`
+	Links    RequestLinks     `json:"links,omitempty"`
+}
+
+// RequestExpanded holds a request's expanded references.
+type RequestExpanded struct {
+	CreatedBy *RequestUser `json:"created_by,omitempty"`
+}
+
+// RequestUser is an expanded user reference.
+type RequestUser struct {
+	Username string `json:"username,omitempty"`
+	Profile  struct {
+		FullName     string `json:"full_name,omitempty"`
+		Affiliations string `json:"affiliations,omitempty"`
+	} `json:"profile,omitempty"`
+}
+
+// RequestLinks contains links returned by the requests API.
+type RequestLinks struct {
+	Self     string            `json:"self,omitempty"`
+	SelfHTML string            `json:"self_html,omitempty"`
+	Comments string            `json:"comments,omitempty"`
+	Timeline string            `json:"timeline,omitempty"`
+	Actions  map[string]string `json:"actions,omitempty"`
+}
+
+// RecordID returns the ID of the record the request is about, or "".
+func (r *Request) RecordID() string {
+	return r.Topic["record"]
+}
+
+// Submitter describes who created the request.
+func (r *Request) Submitter() string {
+	return creatorName(r.CreatedBy, r.Expanded)
+}
+
+// creatorName describes who created a request or event: their name or
+// username if it was expanded, else their user ID or, for guests, email.
+func creatorName(ref map[string]string, exp *RequestExpanded) string {
+	if exp != nil && exp.CreatedBy != nil {
+		if u := exp.CreatedBy; u.Profile.FullName != "" {
+			return u.Profile.FullName
+		} else if u.Username != "" {
+			return u.Username
+		}
+	}
+	if id, ok := ref["user"]; ok {
+		return "user " + id
+	}
+	if email, ok := ref["email"]; ok {
+		return email
+	}
+	if _, ok := ref["system"]; ok {
+		return "system"
+	}
+	return ""
+}
+
+// RequestSearchResult is the paginated response from a requests search.
+type RequestSearchResult struct {
+	Hits  RequestHits `json:"hits"`
+	Links Links       `json:"links,omitempty"`
+}
+
+// RequestHits contains the search result hits and total count.
+type RequestHits struct {
+	Hits  []Request `json:"hits"`
+	Total int       `json:"total"`
+}
+
+// Request event types.
+const (
+	RequestEventComment = "C"
+	RequestEventLog     = "L"
+)
+
+// RequestEvent is an entry in a request's timeline: a comment, or a log
+// entry such as a status change.
+type RequestEvent struct {
+	ID        string            `json:"id"`
+	Type      string            `json:"type"`
+	Created   time.Time         `json:"created,omitzero"`
+	CreatedBy map[string]string `json:"created_by,omitempty"`
+	Payload   struct {
+		// Content is the comment, in HTML.
+		Content string `json:"content,omitempty"`
+		// Event names a logged action, e.g. "accepted".
+		Event string `json:"event,omitempty"`
+	} `json:"payload,omitempty"`
+	Expanded *RequestExpanded `json:"expanded,omitempty"`
+}
+
+// Author describes who created the event.
+func (e *RequestEvent) Author() string {
+	return creatorName(e.CreatedBy, e.Expanded)
+}
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// Text returns the comment as plain text.
+func (e *RequestEvent) Text() string {
+	return strings.TrimSpace(html.UnescapeString(htmlTag.ReplaceAllString(e.Payload.Content, " ")))
+}
+
+// RequestTimeline is the paginated response from a request's timeline.
+type RequestTimeline struct {
+	Hits struct {
+		Hits  []RequestEvent `json:"hits"`
+		Total int            `json:"total"`
+	} `json:"hits"`
+}
@@ -0,0 +1,86 @@
+package model
+
+// VocabularyTerm is one entry of a Zenodo vocabulary, such as a license,
+// resource type or language.
+type VocabularyTerm struct {
+	ID    string            `json:"id"`
+	Title map[string]string `json:"title,omitempty"`
+	Props map[string]any    `json:"props,omitempty"`
+}
+
+// TitleString returns the English title, falling back to the first available.
+func (t *VocabularyTerm) TitleString() string {
+	if s, ok := t.Title["en"]; ok {
+		return s
+	}
+	for _, s := range t.Title {
+		return s
+	}
+	return ""
+}
+
+// VocabularySearchResult is the paginated response from a vocabulary search.
+type VocabularySearchResult struct {
+	Hits  VocabularyHits `json:"hits"`
+	Links Links          `json:"links,omitempty"`
+}
+
+// VocabularyHits contains the vocabulary terms and total count.
+type VocabularyHits struct {
+	Hits  []VocabularyTerm `json:"hits"`
+	Total int              `json:"total"`
+}
+
+// Funder is an entry in the funders vocabulary. Its ID is the funder's ROR
+// ID; legacy grant IDs name it by its Crossref Funder Registry DOI instead.
+type Funder struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Title       map[string]string `json:"title,omitempty"`
+	Country     string            `json:"country,omitempty"`
+	Identifiers []RDMIdentifier   `json:"identifiers,omitempty"`
+}
+
+// DOI returns the funder's Crossref Funder Registry DOI, or "".
+func (f *Funder) DOI() string {
+	for _, id := range f.Identifiers {
+		if id.Scheme == "doi" {
+			return id.Identifier
+		}
+	}
+	return ""
+}
+
+// FunderSearchResult is the paginated response from a funders search.
+type FunderSearchResult struct {
+	Hits struct {
+		Hits  []Funder `json:"hits"`
+		Total int      `json:"total"`
+	} `json:"hits"`
+}
+
+// Award is an entry in the awards vocabulary: a grant, identified by
+// "<funder ROR ID>::<number>".
+type Award struct {
+	ID          string            `json:"id"`
+	Number      string            `json:"number,omitempty"`
+	Title       map[string]string `json:"title,omitempty"`
+	Acronym     string            `json:"acronym,omitempty"`
+	Program     string            `json:"program,omitempty"`
+	Funder      RDMFunder         `json:"funder,omitzero"`
+	Identifiers []RDMIdentifier   `json:"identifiers,omitempty"`
+}
+
+// TitleString returns the English title, falling back to the first available.
+func (a *Award) TitleString() string {
+	t := VocabularyTerm{Title: a.Title}
+	return t.TitleString()
+}
+
+// AwardSearchResult is the paginated response from an awards search.
+type AwardSearchResult struct {
+	Hits struct {
+		Hits  []Award `json:"hits"`
+		Total int     `json:"total"`
+	} `json:"hits"`
+}
@@ -25,6 +25,21 @@ func Format(w io.Writer, data interface{}, format string, fields string) error {
 	}
 }
 
+// Project keeps only the given comma-separated fields (dotted paths allowed)
+// of each item in data, as the json format does with --fields. With no
+// fields, data is returned unchanged.
+func Project(data interface{}, fields string) (interface{}, error) {
+	fieldList := parseFields(fields)
+	if len(fieldList) == 0 {
+		return data, nil
+	}
+	rows, err := toRows(data)
+	if err != nil {
+		return nil, err
+	}
+	return filterFields(rows, fieldList), nil
+}
+
 // toRows converts data into a slice of maps for tabular rendering.
 // Handles both single items and slices.
 func toRows(data interface{}) ([]map[string]interface{}, error) {
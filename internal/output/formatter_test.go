@@ -157,6 +157,24 @@ func TestNestedFields(t *testing.T) {
 	}
 }
 
+func TestProject(t *testing.T) {
+	got, err := Project(sampleRecords, "id,doi")
+	if err != nil {
+		t.Fatalf("Project() error: %v", err)
+	}
+	rows := got.([]map[string]interface{})
+	if len(rows) != 2 || rows[1]["doi"] != "10.5281/2" {
+		t.Errorf("rows = %v", rows)
+	}
+	if _, ok := rows[0]["title"]; ok {
+		t.Error("title should be projected out")
+	}
+
+	if got, _ := Project(sampleRecords, ""); len(got.([]testRecord)) != 2 {
+		t.Error("Project with no fields should return data unchanged")
+	}
+}
+
 func TestUnsupportedFormat(t *testing.T) {
 	var buf bytes.Buffer
 	err := Format(&buf, sampleRecords, "xml", "")
@@ -0,0 +1,19 @@
+// Package safepath maps untrusted relative paths, such as file keys from
+// record JSON or ids from an RO-Crate, to paths inside a directory.
+package safepath
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Join maps a slash-separated relative path to a path inside dir, rejecting
+// absolute paths and ones that would escape dir.
+func Join(dir, rel string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(rel))
+	if clean == "." || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("unsafe file name %q", rel)
+	}
+	return filepath.Join(dir, clean), nil
+}
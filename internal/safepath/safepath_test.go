@@ -0,0 +1,18 @@
+package safepath
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestJoin_RejectsTraversal(t *testing.T) {
+	for _, rel := range []string{"../evil", "/etc/passwd", "a/../../b", "..", "."} {
+		if _, err := Join("/tmp/mirror", rel); err == nil {
+			t.Errorf("Join(%q) should fail", rel)
+		}
+	}
+	got, err := Join("/tmp/mirror", "sub/data.csv")
+	if err != nil || got != filepath.Join("/tmp/mirror", "sub", "data.csv") {
+		t.Errorf("Join(sub/data.csv) = %q, %v", got, err)
+	}
+}
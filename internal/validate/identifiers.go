@@ -0,0 +1,349 @@
+package validate
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Identifier checks run offline: they verify syntax and check digits, not
+// that the identifier is registered.
+
+var (
+	orcidPattern   = regexp.MustCompile(`^\d{4}-\d{4}-\d{4}-\d{3}[\dX]$`)
+	isniPattern    = regexp.MustCompile(`^\d{15}[\dX]$`)
+	doiPattern     = regexp.MustCompile(`^10\.\d{4,9}/\S+$`)
+	rorPattern     = regexp.MustCompile(`^0[0-9a-hjkmnp-tv-z]{6}\d{2}$`)
+	gndPattern     = regexp.MustCompile(`^(1[012]?\d{7}[\dX]|[47]\d{6}-\d|[1-9]\d{0,7}-[\dX]|3\d{7}[\dX])$`)
+	issnPattern    = regexp.MustCompile(`^\d{4}-?\d{3}[\dX]$`)
+	arxivNew       = regexp.MustCompile(`^\d{4}\.\d{4,5}(v\d+)?$`)
+	arxivOld       = regexp.MustCompile(`^[a-z-]+(\.[A-Z]{2})?/\d{7}(v\d+)?$`)
+	handlePattern  = regexp.MustCompile(`^\d+(\.\d+)*/\S+$`)
+	arkPattern     = regexp.MustCompile(`^ark:/?\d{5,}/\S+$`)
+	urnPattern     = regexp.MustCompile(`^urn:[a-z0-9][a-z0-9-]{0,31}:\S+$`)
+	lsidPattern    = regexp.MustCompile(`^urn:lsid:[^:\s]+:[^:\s]+:[^:\s]+(:[^:\s]+)?$`)
+	pmidPattern    = regexp.MustCompile(`^\d{1,9}$`)
+	bibcodePattern = regexp.MustCompile(`^\d{4}[A-Za-z0-9&.]{14}[A-Z.]$`)
+	igsnPattern    = regexp.MustCompile(`^[A-Za-z0-9]{5,}$`)
+	istcPattern    = regexp.MustCompile(`^[0-9A-Fa-f]{16}$`)
+	rorInText      = regexp.MustCompile(`ror\.org/([0-9a-zA-Z]+)`)
+)
+
+// stripPrefixes removes the first matching prefix, compared case-insensitively.
+func stripPrefixes(s string, prefixes ...string) string {
+	s = strings.TrimSpace(s)
+	for _, p := range prefixes {
+		if len(s) >= len(p) && strings.EqualFold(s[:len(p)], p) {
+			return s[len(p):]
+		}
+	}
+	return s
+}
+
+// mod112 reports whether digits (ending in a check character, 0-9 or X)
+// pass the ISO 7064 mod 11-2 checksum used by ORCID and ISNI.
+func mod112(digits string) bool {
+	total := 0
+	for _, c := range digits[:len(digits)-1] {
+		total = (total + int(c-'0')) * 2
+	}
+	check := (12 - total%11) % 11
+	want := byte('0' + check)
+	if check == 10 {
+		want = 'X'
+	}
+	return digits[len(digits)-1] == want
+}
+
+// ORCID checks an ORCID iD, bare or as an orcid.org URL, including its
+// ISO 7064 mod 11-2 check digit.
+func ORCID(s string) error {
+	id := stripPrefixes(s, "https://orcid.org/", "http://orcid.org/", "orcid.org/")
+	if !orcidPattern.MatchString(id) {
+		return fmt.Errorf("ORCID %q must look like 0000-0002-1825-0097", s)
+	}
+	if !mod112(strings.ReplaceAll(id, "-", "")) {
+		return fmt.Errorf("ORCID %q has an invalid check digit (check for a mistyped digit)", s)
+	}
+	return nil
+}
+
+// ISNI checks an ISNI, with or without spaces, including its check digit.
+func ISNI(s string) error {
+	id := strings.Join(strings.Fields(stripPrefixes(s, "https://isni.org/isni/", "http://isni.org/isni/", "isni:")), "")
+	if !isniPattern.MatchString(id) {
+		return fmt.Errorf("ISNI %q must be 16 digits, the last of which may be X", s)
+	}
+	if !mod112(id) {
+		return fmt.Errorf("ISNI %q has an invalid check digit", s)
+	}
+	return nil
+}
+
+// GND checks the syntax of a GND (Gemeinsame Normdatei) identifier.
+func GND(s string) error {
+	id := stripPrefixes(s, "https://d-nb.info/gnd/", "http://d-nb.info/gnd/", "gnd:")
+	if !gndPattern.MatchString(id) {
+		return fmt.Errorf("GND identifier %q is not valid", s)
+	}
+	return nil
+}
+
+// DOI checks the syntax of a DOI, bare or as a doi.org URL.
+func DOI(s string) error {
+	if !doiPattern.MatchString(normalizeDOI(s)) {
+		return fmt.Errorf("DOI %q must look like 10.5281/zenodo.123", s)
+	}
+	return nil
+}
+
+func normalizeDOI(s string) string {
+	return stripPrefixes(s, "https://doi.org/", "http://doi.org/", "https://dx.doi.org/", "http://dx.doi.org/", "doi:")
+}
+
+// crockford is the Crockford base32 alphabet used by ROR IDs.
+const crockford = "0123456789abcdefghjkmnpqrstvwxyz"
+
+// ROR checks a ROR ID, bare or as a ror.org URL, including its mod 97-10
+// checksum.
+func ROR(s string) error {
+	id := strings.ToLower(stripPrefixes(s, "https://ror.org/", "http://ror.org/", "ror.org/"))
+	if !rorPattern.MatchString(id) {
+		return fmt.Errorf("ROR ID %q must look like 05dxps055", s)
+	}
+	n := 0
+	for _, c := range id[1:7] {
+		n = n*32 + strings.IndexRune(crockford, c)
+	}
+	if want := fmt.Sprintf("%02d", 98-(n*100)%97); id[7:] != want {
+		return fmt.Errorf("ROR ID %q has an invalid checksum", s)
+	}
+	return nil
+}
+
+// ISBN checks an ISBN-10 or ISBN-13, with or without hyphens and spaces.
+func ISBN(s string) error {
+	id := strings.NewReplacer("-", "", " ", "").Replace(stripPrefixes(s, "isbn:", "isbn "))
+	switch len(id) {
+	case 10:
+		total := 0
+		for i, c := range id {
+			var v int
+			switch {
+			case c >= '0' && c <= '9':
+				v = int(c - '0')
+			case (c == 'X' || c == 'x') && i == 9:
+				v = 10
+			default:
+				return fmt.Errorf("ISBN %q contains invalid characters", s)
+			}
+			total += (10 - i) * v
+		}
+		if total%11 != 0 {
+			return fmt.Errorf("ISBN %q has an invalid check digit", s)
+		}
+		return nil
+	case 13:
+		if !gtinChecksum(id) {
+			return fmt.Errorf("ISBN %q has an invalid check digit", s)
+		}
+		if !strings.HasPrefix(id, "978") && !strings.HasPrefix(id, "979") {
+			return fmt.Errorf("ISBN-13 %q must start with 978 or 979", s)
+		}
+		return nil
+	}
+	return fmt.Errorf("ISBN %q must have 10 or 13 digits", s)
+}
+
+// gtinChecksum validates the check digit of an EAN-13, ISBN-13 or UPC-A.
+func gtinChecksum(digits string) bool {
+	total := 0
+	for i := 0; i < len(digits); i++ {
+		c := digits[len(digits)-1-i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		d := int(c - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		total += d
+	}
+	return total%10 == 0
+}
+
+// ISSN checks an ISSN, including its mod 11 check digit.
+func ISSN(s string) error {
+	id := stripPrefixes(s, "issn:", "issn ")
+	if !issnPattern.MatchString(id) {
+		return fmt.Errorf("ISSN %q must look like 1234-5679", s)
+	}
+	id = strings.ReplaceAll(id, "-", "")
+	total := 0
+	for i, c := range id[:7] {
+		total += (8 - i) * int(c-'0')
+	}
+	check := (11 - total%11) % 11
+	want := byte('0' + check)
+	if check == 10 {
+		want = 'X'
+	}
+	if id[7] != want {
+		return fmt.Errorf("ISSN %q has an invalid check digit", s)
+	}
+	return nil
+}
+
+// Date checks an ISO 8601 calendar date (YYYY-MM-DD), as Zenodo requires for
+// publication and embargo dates.
+func Date(s string) error {
+	if _, err := time.Parse("2006-01-02", s); err != nil {
+		return fmt.Errorf("%q is not an ISO 8601 date (YYYY-MM-DD)", s)
+	}
+	return nil
+}
+
+// webURL reports whether s is an absolute http(s) URL, optionally on host.
+func webURL(s, host string) bool {
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return false
+	}
+	return host == "" || strings.EqualFold(u.Host, host) || strings.HasSuffix(strings.ToLower(u.Host), "."+host)
+}
+
+// schemeCheckers validate identifiers for the related-identifier schemes
+// Zenodo accepts.
+var schemeCheckers = map[string]func(string) bool{
+	"ark": func(s string) bool {
+		return arkPattern.MatchString(s) || (webURL(s, "") && strings.Contains(s, "/ark:"))
+	},
+	"arxiv": func(s string) bool {
+		id := stripPrefixes(s, "arxiv:")
+		return arxivNew.MatchString(id) || arxivOld.MatchString(id)
+	},
+	"bibcode": bibcodePattern.MatchString,
+	"doi":     func(s string) bool { return DOI(s) == nil },
+	"ean13":   func(s string) bool { return len(s) == 13 && gtinChecksum(s) },
+	"eissn":   func(s string) bool { return ISSN(s) == nil },
+	"gnd":     func(s string) bool { return GND(s) == nil },
+	"handle": func(s string) bool {
+		return handlePattern.MatchString(stripPrefixes(s, "https://hdl.handle.net/", "http://hdl.handle.net/", "hdl:"))
+	},
+	"igsn":  func(s string) bool { return igsnPattern.MatchString(stripPrefixes(s, "igsn:")) },
+	"isbn":  func(s string) bool { return ISBN(s) == nil },
+	"isni":  func(s string) bool { return ISNI(s) == nil },
+	"issn":  func(s string) bool { return ISSN(s) == nil },
+	"istc":  func(s string) bool { return istcPattern.MatchString(strings.ReplaceAll(s, "-", "")) },
+	"lissn": func(s string) bool { return ISSN(s) == nil },
+	"lsid":  lsidPattern.MatchString,
+	"orcid": func(s string) bool { return ORCID(s) == nil },
+	"pmid":  func(s string) bool { return pmidPattern.MatchString(stripPrefixes(s, "pmid:")) },
+	"purl":  func(s string) bool { return webURL(s, "purl.org") || webURL(s, "purl.oclc.org") },
+	"ror":   func(s string) bool { return ROR(s) == nil },
+	"upc":   func(s string) bool { return len(s) == 12 && gtinChecksum(s) },
+	"url":   func(s string) bool { return webURL(s, "") },
+	"urn":   urnPattern.MatchString,
+	"w3id":  func(s string) bool { return webURL(s, "w3id.org") },
+}
+
+// detectOrder lists schemes from most to least specific, so that a DOI URL
+// is detected as a DOI rather than a URL and an LSID as an LSID rather than
+// a URN.
+var detectOrder = []string{
+	"doi", "orcid", "ror", "ark", "arxiv", "isbn", "issn", "ean13", "upc",
+	"lsid", "urn", "purl", "w3id", "handle", "bibcode", "pmid", "url",
+}
+
+// DetectScheme returns the scheme of a related identifier, or "" if it
+// cannot be determined.
+func DetectScheme(id string) string {
+	id = strings.TrimSpace(id)
+	// URLs of resolvers are detected as the scheme they resolve.
+	for _, host := range []struct{ prefix, scheme string }{
+		{"https://doi.org/", "doi"}, {"http://doi.org/", "doi"}, {"https://dx.doi.org/", "doi"},
+		{"https://orcid.org/", "orcid"}, {"https://ror.org/", "ror"},
+		{"https://hdl.handle.net/", "handle"}, {"https://arxiv.org/abs/", "arxiv"},
+	} {
+		if strings.HasPrefix(strings.ToLower(id), host.prefix) {
+			if host.scheme == "arxiv" {
+				id = "arXiv:" + id[len(host.prefix):]
+			}
+			if schemeCheckers[host.scheme](id) {
+				return host.scheme
+			}
+		}
+	}
+	for _, scheme := range detectOrder {
+		if schemeCheckers[scheme](id) {
+			return scheme
+		}
+	}
+	return ""
+}
+
+// RelatedIdentifier checks id against scheme. An empty scheme is detected
+// from the identifier.
+func RelatedIdentifier(scheme, id string) error {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return fmt.Errorf("identifier is empty")
+	}
+	if scheme == "" {
+		if DetectScheme(id) == "" {
+			return fmt.Errorf("cannot determine the scheme of %q; set scheme", id)
+		}
+		return nil
+	}
+	check, ok := schemeCheckers[strings.ToLower(scheme)]
+	if !ok {
+		return fmt.Errorf("unknown scheme %q", scheme)
+	}
+	if !check(id) {
+		return fmt.Errorf("%q is not a valid %s", id, strings.ToLower(scheme))
+	}
+	return nil
+}
+
+// AffiliationRORs returns the ROR IDs mentioned in an affiliation, such as
+// "CERN (https://ror.org/01ggx4157)".
+func AffiliationRORs(affiliation string) []string {
+	var ids []string
+	for _, m := range rorInText.FindAllStringSubmatch(affiliation, -1) {
+		ids = append(ids, m[1])
+	}
+	return ids
+}
+
+var (
+	crossrefFunderPattern = regexp.MustCompile(`^10\.13039/\d+$`)
+	ecGrantPattern        = regexp.MustCompile(`^\d{6,9}$`)
+)
+
+// GrantID checks the syntax of a grant ID: "<funder>::<number>", where the
+// funder is a Crossref Funder Registry DOI or a ROR ID, or a bare European
+// Commission grant number.
+func GrantID(s string) error {
+	funder, number, ok := strings.Cut(s, "::")
+	if !ok {
+		if !ecGrantPattern.MatchString(s) {
+			return fmt.Errorf("grant ID %q must look like 10.13039/501100000780::101001234 or 00k4n6c32::101001234 (funder::number), or be a European Commission grant number", s)
+		}
+		return nil
+	}
+	if number == "" || strings.ContainsAny(number, " \t") {
+		return fmt.Errorf("grant ID %q needs a grant number without spaces after ::", s)
+	}
+	if strings.HasPrefix(funder, "10.") {
+		if !crossrefFunderPattern.MatchString(funder) {
+			return fmt.Errorf("grant ID %q: funder %q is not a Crossref Funder Registry DOI (10.13039/...)", s, funder)
+		}
+		return nil
+	}
+	if err := ROR(funder); err != nil {
+		return fmt.Errorf("grant ID %q: funder must be a Crossref Funder Registry DOI or a ROR ID: %v", s, err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package validate
+
+import "testing"
+
+func TestCheckers(t *testing.T) {
+	tests := []struct {
+		name  string
+		check func(string) error
+		valid []string
+		bad   []string
+	}{
+		{"ORCID", ORCID,
+			[]string{"0000-0002-1825-0097", "0000-0002-1694-233X", "https://orcid.org/0000-0001-5109-3700"},
+			[]string{"0000-0002-1825-0098", "0000-0002-1825-009", "0000000218250097"}},
+		{"ISNI", ISNI,
+			[]string{"0000000218250097", "0000 0002 1825 0097"},
+			[]string{"0000000218250098", "00000002182500"}},
+		{"GND", GND,
+			[]string{"118540238", "4021477-1", "https://d-nb.info/gnd/118540238"},
+			[]string{"abc", "0123"}},
+		{"DOI", DOI,
+			[]string{"10.5281/zenodo.123", "https://doi.org/10.1000/xyz", "doi:10.1234/ABC"},
+			[]string{"10.12/abc", "zenodo.123", "10.5281/"}},
+		{"ROR", ROR,
+			[]string{"05dxps055", "https://ror.org/01ggx4157"},
+			[]string{"05dxps056", "15dxps055", "05dxps05"}},
+		{"ISBN", ISBN,
+			[]string{"0-306-40615-2", "978-0-306-40615-7", "080442957X"},
+			[]string{"0-306-40615-3", "978-0-306-40615-8", "123-0-306-40615-7", "12345"}},
+		{"ISSN", ISSN,
+			[]string{"0378-5955", "2049-3630", "0317-8471"},
+			[]string{"0378-5956", "378-5955"}},
+		{"Language", Language,
+			[]string{"eng", "deu", "ENG"},
+			[]string{"en", "xxq", ""}},
+		{"Date", Date,
+			[]string{"2024-02-29", "1999-12-31"},
+			[]string{"2023-02-29", "2024-1-1", "2024", "31/12/1999"}},
+	}
+	for _, tt := range tests {
+		for _, v := range tt.valid {
+			if err := tt.check(v); err != nil {
+				t.Errorf("%s(%q) error: %v", tt.name, v, err)
+			}
+		}
+		for _, v := range tt.bad {
+			if err := tt.check(v); err == nil {
+				t.Errorf("%s(%q) accepted", tt.name, v)
+			}
+		}
+	}
+}
+
+func TestDetectScheme(t *testing.T) {
+	tests := map[string]string{
+		"10.1234/foo":                      "doi",
+		"https://doi.org/10.1234/foo":      "doi",
+		"0000-0002-1825-0097":              "orcid",
+		"https://ror.org/05dxps055":        "ror",
+		"arXiv:2101.00001":                 "arxiv",
+		"https://arxiv.org/abs/2101.00001": "arxiv",
+		"ark:/13030/tf5p30086k":            "ark",
+		"978-0-306-40615-7":                "isbn",
+		"0378-5955":                        "issn",
+		"urn:lsid:ipni.org:names:77145-1":  "lsid",
+		"urn:nbn:de:1234-5678":             "urn",
+		"https://purl.org/dc/terms/":       "purl",
+		"https://w3id.org/ro/crate":        "w3id",
+		"hdl:2027/mdp.39015":               "handle",
+		"https://hdl.handle.net/2027/abc":  "handle",
+		"2019ApJ...882L..24A":              "bibcode",
+		"31452104":                         "pmid",
+		"https://example.org/data":         "url",
+		"not an identifier":                "",
+	}
+	for id, want := range tests {
+		if got := DetectScheme(id); got != want {
+			t.Errorf("DetectScheme(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
+
+func TestRelatedIdentifier(t *testing.T) {
+	if err := RelatedIdentifier("doi", "10.1234/foo"); err != nil {
+		t.Errorf("valid DOI: %v", err)
+	}
+	if err := RelatedIdentifier("", "https://example.org/x"); err != nil {
+		t.Errorf("detected URL: %v", err)
+	}
+	for _, tt := range []struct{ scheme, id string }{
+		{"doi", "https://example.org/x"},
+		{"isbn", "978-0-306-40615-8"},
+		{"nope", "10.1234/foo"},
+		{"", "not an identifier"},
+		{"url", ""},
+	} {
+		if err := RelatedIdentifier(tt.scheme, tt.id); err == nil {
+			t.Errorf("RelatedIdentifier(%q, %q) accepted", tt.scheme, tt.id)
+		}
+	}
+}
+
+func TestGrantID(t *testing.T) {
+	for _, id := range []string{"101004310", "00k4n6c32::101004310", "10.13039/100000002::1R01GM123456-01", "01cwqze88::R01GM123456"} {
+		if err := GrantID(id); err != nil {
+			t.Errorf("GrantID(%q): %v", id, err)
+		}
+	}
+	for _, id := range []string{"", "R01GM123456", "10.13039/NIH::R01", "nih::R01", "00k4n6c32::", "00k4n6c32::1010 04310"} {
+		if err := GrantID(id); err == nil {
+			t.Errorf("GrantID(%q) accepted", id)
+		}
+	}
+}
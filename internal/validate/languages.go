@@ -0,0 +1,20 @@
+package validate
+
+import (
+	"fmt"
+
+	"github.com/ran-codes/zenodo-cli/internal/vocab"
+)
+
+// Language checks that code is an ISO 639-3 language code, such as "eng",
+// against the languages vocabulary.
+func Language(code string) error {
+	languages := vocabularies.Get(vocab.Languages)
+	if languages.Contains(code) {
+		return nil
+	}
+	if t, ok := languages.Suggest(code); ok {
+		return fmt.Errorf("%q is not an ISO 639-3 language code; did you mean %q (%s)?", code, t.ID, t.Title)
+	}
+	return fmt.Errorf("%q is not an ISO 639-3 language code (e.g. eng, deu, fra)", code)
+}
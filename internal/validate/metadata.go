@@ -5,19 +5,34 @@ import (
 	"strings"
 
 	"github.com/ran-codes/zenodo-cli/internal/model"
+	"github.com/ran-codes/zenodo-cli/internal/vocab"
 )
 
-var validUploadTypes = map[string]bool{
-	"publication": true, "poster": true, "presentation": true,
-	"dataset": true, "image": true, "video": true,
-	"software": true, "lesson": true, "physicalobject": true,
-	"other": true,
-}
-
 var validAccessRights = map[string]bool{
 	"open": true, "embargoed": true, "restricted": true, "closed": true,
 }
 
+// vocabularies are the controlled vocabularies that enum fields are checked
+// against. The zero Set uses the copies embedded in the binary.
+var vocabularies = &vocab.Set{}
+
+// UseVocabularies sets the vocabularies validation checks against, such as
+// the cached ones from vocab.Store.LoadSet. It is not safe to call while
+// validating.
+func UseVocabularies(s *vocab.Set) {
+	vocabularies = s
+}
+
+// awards looks up grant IDs in the awards vocabulary. If it is nil, grant
+// IDs are only checked for syntax.
+var awards *vocab.Awards
+
+// UseAwards sets the grant lookups that grant IDs are checked against. It
+// is not safe to call while validating.
+func UseAwards(a *vocab.Awards) {
+	awards = a
+}
+
 // Metadata validates required fields on a metadata struct.
 // Returns a slice of validation errors (empty if valid).
 func Metadata(m model.Metadata) []string {
@@ -33,9 +48,8 @@ func Metadata(m model.Metadata) []string {
 
 	if m.UploadType == "" {
 		errs = append(errs, "upload_type is required")
-	} else if !validUploadTypes[m.UploadType] {
-		errs = append(errs, fmt.Sprintf("upload_type %q is invalid; valid types: %s",
-			m.UploadType, joinKeys(validUploadTypes)))
+	} else if e := termError("upload_type", m.UploadType, uploadTypes(), listValues); e != "" {
+		errs = append(errs, e)
 	}
 
 	if len(m.Creators) == 0 {
@@ -56,8 +70,11 @@ func Metadata(m model.Metadata) []string {
 		errs = append(errs, "access_right is required")
 	} else {
 		if !validAccessRights[m.AccessRight] {
-			errs = append(errs, fmt.Sprintf("access_right %q is invalid; valid values: %s",
-				m.AccessRight, joinKeys(validAccessRights)))
+			msg := fmt.Sprintf("access_right %q is invalid", m.AccessRight)
+			if s := closestKey(m.AccessRight, validAccessRights); s != "" {
+				msg += fmt.Sprintf("; did you mean %q?", s)
+			}
+			errs = append(errs, fmt.Sprintf("%s; valid values: %s", msg, joinKeys(validAccessRights)))
 		}
 
 		// License required for open/embargoed.
@@ -76,9 +93,223 @@ func Metadata(m model.Metadata) []string {
 		}
 	}
 
+	errs = append(errs, Vocabularies(m)...)
+	return append(errs, Identifiers(m)...)
+}
+
+// Vocabularies checks enum fields against the controlled vocabularies:
+// the license, publication and image types, contributor types and the
+// relations of related identifiers, and grant IDs if UseAwards was called.
+// Errors suggest the closest valid value. upload_type and language are
+// checked by Metadata and Identifiers.
+func Vocabularies(m model.Metadata) []string {
+	var errs []string
+	check := func(e string) {
+		if e != "" {
+			errs = append(errs, e)
+		}
+	}
+
+	if license := m.LicenseString(); license != "" {
+		check(termError("license", license, vocabularies.Get(vocab.Licenses), seeVocabulary(vocab.Licenses)))
+	}
+	if m.UploadType == "publication" && m.PublicationType != "" {
+		check(termError("publication_type", m.PublicationType, vocabularies.Get(vocab.ResourceTypes).Sub("publication"), listValues))
+	}
+	if m.UploadType == "image" && m.ImageType != "" {
+		check(termError("image_type", m.ImageType, vocabularies.Get(vocab.ResourceTypes).Sub("image"), listValues))
+	}
+
+	roles := vocabularies.Get(vocab.ContributorRoles)
+	for i, c := range m.Contributors {
+		if c.Type != "" {
+			check(termError(fmt.Sprintf("contributors[%d].type", i), c.Type, roles, seeVocabulary(vocab.ContributorRoles)))
+		}
+	}
+	relations := vocabularies.Get(vocab.RelationTypes)
+	for i, r := range m.RelatedIdentifiers {
+		if r.Relation != "" {
+			check(termError(fmt.Sprintf("related_identifiers[%d].relation", i), r.Relation, relations, seeVocabulary(vocab.RelationTypes)))
+		}
+	}
+	for i, g := range m.Grants {
+		check(grantError(fmt.Sprintf("grants[%d].id", i), g.ID))
+	}
 	return errs
 }
 
+// grantError returns an error message if a grant ID is not in the awards
+// vocabulary, or "". IDs with invalid syntax are left to Identifiers, and
+// IDs that cannot be looked up pass.
+func grantError(field, id string) string {
+	if awards == nil || GrantID(id) != nil {
+		return ""
+	}
+	g, ok := awards.Lookup(id)
+	if !ok || g.Found {
+		return ""
+	}
+	funder, _, scoped := strings.Cut(id, "::")
+	if !scoped {
+		funder = vocab.DefaultFunder
+	}
+	if g.UnknownFunder {
+		return fmt.Sprintf("%s %q is invalid: funder %s is not in the funders vocabulary; see: zenodo funders search", field, id, funder)
+	}
+	msg := fmt.Sprintf("%s %q is not in the awards vocabulary", field, id)
+	if s := g.Suggestion; s != nil {
+		suggestion := s.Number
+		if scoped {
+			suggestion = funder + "::" + s.Number
+		}
+		msg += fmt.Sprintf("; did you mean %q (%s)?", suggestion, awardLabel(s))
+	}
+	return msg + "; see: zenodo awards search --funder " + funder
+}
+
+// awardLabel names an award by acronym, else by title.
+func awardLabel(a *model.Award) string {
+	if a.Acronym != "" {
+		return a.Acronym
+	}
+	return a.TitleString()
+}
+
+// uploadTypes are the top-level resource types.
+func uploadTypes() *vocab.Vocabulary {
+	return vocabularies.Get(vocab.ResourceTypes).Sub("")
+}
+
+// spelling returns how the deposit API spells a term of a vocabulary:
+// relation types in camel case (isCitedBy), contributor types in Pascal
+// case (ContactPerson), everything else as the ID.
+func spelling(v *vocab.Vocabulary, t vocab.Term) string {
+	switch v.Kind {
+	case vocab.RelationTypes:
+		return t.Words(false)
+	case vocab.ContributorRoles:
+		return t.Words(true)
+	}
+	return t.ID
+}
+
+// listValues ends an invalid-term error with every valid value, for small
+// vocabularies.
+func listValues(v *vocab.Vocabulary) string {
+	values := make([]string, len(v.Terms))
+	for i, t := range v.Terms {
+		values[i] = spelling(v, t)
+	}
+	return "valid values: " + strings.Join(values, ", ")
+}
+
+// seeVocabulary ends an invalid-term error with the command that lists a
+// large vocabulary.
+func seeVocabulary(kind vocab.Kind) func(*vocab.Vocabulary) string {
+	return func(*vocab.Vocabulary) string {
+		return fmt.Sprintf("see: zenodo vocab show %s", kind)
+	}
+}
+
+// termError returns an error message if value is not a term of v, or "".
+func termError(field, value string, v *vocab.Vocabulary, hint func(*vocab.Vocabulary) string) string {
+	if v.Contains(value) {
+		return ""
+	}
+	msg := fmt.Sprintf("%s %q is invalid", field, value)
+	if t, ok := v.Suggest(value); ok {
+		msg += fmt.Sprintf("; did you mean %q?", spelling(v, t))
+	}
+	return msg + "; " + hint(v)
+}
+
+// closestKey returns the key of m closest to value, if it is a likely typo.
+func closestKey(value string, m map[string]bool) string {
+	best, bestDist := "", 0
+	for k := range m {
+		if d := vocab.Levenshtein(strings.ToLower(value), k); best == "" || d < bestDist || (d == bestDist && k < best) {
+			best, bestDist = k, d
+		}
+	}
+	if bestDist > len(value)/4+1 {
+		return ""
+	}
+	return best
+}
+
+// Identifiers checks the syntax and check digits of identifiers, dates and
+// codes in the metadata: ORCIDs, GNDs and ROR IDs of people, DOIs, ISBNs,
+// the language code, related identifiers and grant IDs.
+func Identifiers(m model.Metadata) []string {
+	var errs []string
+	check := func(field string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", field, err))
+		}
+	}
+	person := func(field, orcid, gnd, affiliation string) {
+		if orcid != "" {
+			check(field+".orcid", ORCID(orcid))
+		}
+		if gnd != "" {
+			check(field+".gnd", GND(gnd))
+		}
+		for _, id := range AffiliationRORs(affiliation) {
+			check(field+".affiliation", ROR(id))
+		}
+	}
+
+	for i, c := range m.Creators {
+		person(fmt.Sprintf("creators[%d]", i), c.ORCID, c.GND, c.Affiliation)
+	}
+	for i, c := range m.Contributors {
+		person(fmt.Sprintf("contributors[%d]", i), c.ORCID, c.GND, c.Affiliation)
+	}
+	for i, c := range m.ThesisSupervisors {
+		person(fmt.Sprintf("thesis_supervisors[%d]", i), c.ORCID, c.GND, c.Affiliation)
+	}
+
+	if m.DOI != "" {
+		check("doi", DOI(m.DOI))
+	}
+	if m.ImprintISBN != "" {
+		check("imprint_isbn", ISBN(m.ImprintISBN))
+	}
+	if m.Language != "" {
+		check("language", Language(m.Language))
+	}
+	if m.PublicationDate != "" {
+		check("publication_date", Date(m.PublicationDate))
+	}
+	if m.EmbargoDate != "" {
+		check("embargo_date", Date(m.EmbargoDate))
+	}
+	for i, r := range m.RelatedIdentifiers {
+		check(fmt.Sprintf("related_identifiers[%d]", i), RelatedIdentifier(r.Scheme, r.Identifier))
+	}
+	for i, g := range m.Grants {
+		check(fmt.Sprintf("grants[%d].id", i), GrantID(g.ID))
+	}
+	return errs
+}
+
+// FillSchemes sets the scheme of related identifiers that have none, where
+// it can be detected. It returns the number of schemes set.
+func FillSchemes(m *model.Metadata) int {
+	n := 0
+	for i := range m.RelatedIdentifiers {
+		r := &m.RelatedIdentifiers[i]
+		if r.Scheme != "" {
+			continue
+		}
+		if scheme := DetectScheme(r.Identifier); scheme != "" {
+			r.Scheme = scheme
+			n++
+		}
+	}
+	return n
+}
+
 func joinKeys(m map[string]bool) string {
 	keys := make([]string, 0, len(m))
 	for k := range m {
@@ -2,10 +2,15 @@ package validate
 
 import (
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/ran-codes/zenodo-cli/internal/api"
 	"github.com/ran-codes/zenodo-cli/internal/model"
+	"github.com/ran-codes/zenodo-cli/internal/vocab"
 )
 
 func validMetadata() model.Metadata {
@@ -129,3 +134,124 @@ func containsError(errs []string, substr string) bool {
 	}
 	return false
 }
+
+func TestIdentifierErrors(t *testing.T) {
+	m := validMetadata()
+	m.Creators = []model.Creator{{Name: "Doe, Jane", ORCID: "0000-0002-1825-0098", Affiliation: "CERN (https://ror.org/01ggx4158)"}}
+	m.Language = "english"
+	m.PublicationDate = "2024-13-01"
+	m.ImprintISBN = "978-0-306-40615-8"
+	m.RelatedIdentifiers = []model.RelatedIdentifier{{Identifier: "10.1234/x", Relation: "cites", Scheme: "isbn"}}
+	m.Grants = []model.Grant{{ID: "101004310"}, {ID: "NIH R01GM123456"}}
+	errs := Metadata(m)
+	for _, want := range []string{"creators[0].orcid", "creators[0].affiliation", "language", "publication_date", "imprint_isbn", "related_identifiers[0]", "grants[1].id"} {
+		if !containsError(errs, want) {
+			t.Errorf("expected %s error, got: %v", want, errs)
+		}
+	}
+}
+
+func TestFillSchemes(t *testing.T) {
+	m := validMetadata()
+	m.RelatedIdentifiers = []model.RelatedIdentifier{
+		{Identifier: "https://doi.org/10.1234/x", Relation: "cites"},
+		{Identifier: "10.1234/y", Relation: "cites", Scheme: "handle"},
+		{Identifier: "???", Relation: "cites"},
+	}
+	if n := FillSchemes(&m); n != 1 {
+		t.Errorf("FillSchemes() = %d, want 1", n)
+	}
+	got := m.RelatedIdentifiers
+	if got[0].Scheme != "doi" || got[1].Scheme != "handle" || got[2].Scheme != "" {
+		t.Errorf("schemes = %q, %q, %q", got[0].Scheme, got[1].Scheme, got[2].Scheme)
+	}
+}
+
+func TestVocabularyErrors(t *testing.T) {
+	m := validMetadata()
+	m.UploadType = "publication"
+	m.PublicationType = "artcle"
+	m.AccessRight = "opne"
+	m.License = json.RawMessage(`"cc-by-4"`)
+	m.Language = "en"
+	m.Contributors = []model.Contributor{{Name: "Roe, Rick", Type: "Contact Person"}, {Name: "Poe, Pat", Type: "Editor"}}
+	m.RelatedIdentifiers = []model.RelatedIdentifier{
+		{Identifier: "10.1234/x", Relation: "isCitedby_", Scheme: "doi"},
+		{Identifier: "10.1234/y", Relation: "isSupplementTo", Scheme: "doi"},
+	}
+	errs := Metadata(m)
+	for _, want := range []string{
+		`publication_type "artcle" is invalid; did you mean "article"?`,
+		`access_right "opne" is invalid; did you mean "open"?`,
+		`license "cc-by-4" is invalid; did you mean "cc-by-4.0"?`,
+		`did you mean "eng" (English)?`,
+		`contributors[0].type "Contact Person" is invalid; did you mean "ContactPerson"?`,
+		`related_identifiers[0].relation "isCitedby_" is invalid; did you mean "isCitedBy"?`,
+	} {
+		if !containsError(errs, want) {
+			t.Errorf("expected %q, got: %v", want, errs)
+		}
+	}
+	if len(errs) != 6 {
+		t.Errorf("got %d errors, want 6: %v", len(errs), errs)
+	}
+}
+
+func TestUploadTypeSuggestion(t *testing.T) {
+	m := validMetadata()
+	m.UploadType = "datset"
+	errs := Metadata(m)
+	if !containsError(errs, `did you mean "dataset"?`) || !containsError(errs, "valid values: dataset,") {
+		t.Errorf("got: %v", errs)
+	}
+}
+
+func TestUseVocabularies(t *testing.T) {
+	defer UseVocabularies(&vocab.Set{})
+	set := &vocab.Set{}
+	set.Add(vocab.New(vocab.Licenses, "test", []vocab.Term{{ID: "institutional-1.0"}}))
+	UseVocabularies(set)
+
+	m := validMetadata()
+	m.License = json.RawMessage(`"institutional-1.0"`)
+	if errs := Metadata(m); len(errs) != 0 {
+		t.Errorf("custom license rejected: %v", errs)
+	}
+	m.License = json.RawMessage(`"cc-by-4.0"`)
+	if errs := Metadata(m); !containsError(errs, "license") {
+		t.Errorf("license outside the vocabulary accepted: %v", errs)
+	}
+}
+
+func TestUseAwards(t *testing.T) {
+	defer UseAwards(nil)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		switch {
+		case r.URL.Path == "/funders" && strings.Contains(q, "00k4n6c32"):
+			w.Write([]byte(`{"hits": {"hits": [{"id": "00k4n6c32", "name": "European Commission"}], "total": 1}}`))
+		case r.URL.Path == "/awards" && (strings.Contains(q, `"101004310"`) || strings.Contains(q, "~")):
+			w.Write([]byte(`{"hits": {"hits": [{"id": "00k4n6c32::101004310", "number": "101004310", "acronym": "FAIRsFAIR"}], "total": 1}}`))
+		default:
+			w.Write([]byte(`{"hits": {"hits": [], "total": 0}}`))
+		}
+	}))
+	defer srv.Close()
+	store := &vocab.Store{Dir: t.TempDir(), BaseURL: srv.URL, Client: api.NewClient(srv.URL, ""), MaxAge: time.Hour}
+	UseAwards(store.Awards(true))
+
+	m := validMetadata()
+	m.Grants = []model.Grant{{ID: "101004310"}, {ID: "101004301"}, {ID: "01cwqze88::R01GM123456"}}
+	errs := Metadata(m)
+	for _, want := range []string{
+		`grants[1].id "101004301" is not in the awards vocabulary; did you mean "101004310" (FAIRsFAIR)?`,
+		`grants[2].id "01cwqze88::R01GM123456" is invalid: funder 01cwqze88 is not in the funders vocabulary`,
+	} {
+		if !containsError(errs, want) {
+			t.Errorf("expected %q, got: %v", want, errs)
+		}
+	}
+	if len(errs) != 2 {
+		t.Errorf("got %d errors, want 2: %v", len(errs), errs)
+	}
+}
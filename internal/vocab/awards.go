@@ -0,0 +1,199 @@
+package vocab
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// The funders and awards vocabularies are too large to download whole, so
+// grant IDs are looked up one at a time and the answers cached on disk.
+
+// DefaultFunder is the funder of a grant ID given as a bare number: the
+// European Commission (by ROR ID), as in Zenodo's deposit API.
+const DefaultFunder = "00k4n6c32"
+
+// funderRORs maps the Crossref Funder Registry DOIs of common funders to
+// their ROR IDs, which key the funders and awards vocabularies.
+var funderRORs = map[string]string{
+	"10.13039/501100000780": DefaultFunder, // European Commission
+	"10.13039/100000002":    "01cwqze88",   // National Institutes of Health
+	"10.13039/100000001":    "021nxhr62",   // National Science Foundation
+}
+
+// FunderROR returns the ROR ID of the funder of a grant ID, given as a ROR
+// ID or a Crossref Funder Registry DOI. ok is false for DOIs it does not
+// know; the funders vocabulary can resolve those (see Awards).
+func FunderROR(funder string) (ror string, ok bool) {
+	if !strings.HasPrefix(funder, "10.") {
+		return funder, true
+	}
+	ror, ok = funderRORs[funder]
+	return ror, ok
+}
+
+// Grant is what the awards vocabulary says about a grant ID.
+type Grant struct {
+	// Found is set if the award exists. AwardID, Title, Acronym and
+	// Funder describe it.
+	Found   bool   `json:"found"`
+	AwardID string `json:"award_id,omitempty"`
+	Title   string `json:"title,omitempty"`
+	Acronym string `json:"acronym,omitempty"`
+	Funder  string `json:"funder,omitempty"`
+	// UnknownFunder is set if the award was not found because its funder
+	// is not in the funders vocabulary.
+	UnknownFunder bool `json:"unknown_funder,omitempty"`
+	// Suggestion is an award of the same funder with a similar number,
+	// when the award was not found.
+	Suggestion *model.Award `json:"suggestion,omitempty"`
+	Checked    time.Time    `json:"checked"`
+}
+
+// awardsFile is the on-disk form of the grant lookup cache.
+type awardsFile struct {
+	Format int              `json:"format"`
+	Grants map[string]Grant `json:"grants"`
+}
+
+// Awards looks up grant IDs in an instance's awards vocabulary, caching
+// the answers.
+type Awards struct {
+	path   string
+	client Client
+	maxAge time.Duration
+
+	mu     sync.Mutex
+	grants map[string]Grant
+}
+
+// Awards returns the grant lookups of the store's instance. If fetch is not
+// set, or the instance does not serve the funders and awards vocabularies,
+// only cached answers are used.
+func (s *Store) Awards(fetch bool) *Awards {
+	a := &Awards{path: filepath.Join(s.Dir, "awards.json"), maxAge: s.MaxAge}
+	if fetch && s.Client != nil && s.Caps.Has(model.EndpointFunders) {
+		a.client = s.Client
+	}
+	return a
+}
+
+// load reads the cache on first use. A missing or unreadable cache is
+// empty.
+func (a *Awards) load() {
+	if a.grants != nil {
+		return
+	}
+	a.grants = map[string]Grant{}
+	data, err := os.ReadFile(a.path)
+	if err != nil {
+		return
+	}
+	var f awardsFile
+	if json.Unmarshal(data, &f) == nil && f.Format == CacheFormat && f.Grants != nil {
+		a.grants = f.Grants
+	}
+}
+
+// save writes the cache atomically.
+func (a *Awards) save() error {
+	if err := os.MkdirAll(filepath.Dir(a.path), 0700); err != nil {
+		return fmt.Errorf("creating vocabulary cache: %w", err)
+	}
+	data, err := json.MarshalIndent(awardsFile{Format: CacheFormat, Grants: a.grants}, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := a.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("writing awards cache: %w", err)
+	}
+	return os.Rename(tmp, a.path)
+}
+
+// Lookup returns what the awards vocabulary says about a grant ID: a fresh
+// cached answer, else one fetched from the API (and cached), else a stale
+// cached one. ok is false if there is no answer, e.g. when offline.
+func (a *Awards) Lookup(grantID string) (g Grant, ok bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.load()
+	cached, ok := a.grants[grantID]
+	if (ok && time.Since(cached.Checked) < a.maxAge) || a.client == nil {
+		return cached, ok
+	}
+	g, err := a.resolve(grantID)
+	if err != nil {
+		return cached, ok
+	}
+	a.grants[grantID] = g
+	_ = a.save()
+	return g, true
+}
+
+// resolve looks up a grant ID in the API. It searches rather than getting
+// the award, so that a missing one is an empty result and not a 404.
+func (a *Awards) resolve(grantID string) (Grant, error) {
+	g := Grant{Checked: time.Now().UTC()}
+	funder, number, ok := strings.Cut(grantID, "::")
+	if !ok {
+		funder, number = DefaultFunder, grantID
+	}
+	field := "id"
+	if strings.HasPrefix(funder, "10.") {
+		field = "identifiers.identifier"
+	}
+	funders, err := a.client.SearchFunders(field+":"+strconv.Quote(funder), 1, 1)
+	if err != nil {
+		return g, err
+	}
+	if len(funders.Hits.Hits) == 0 {
+		g.UnknownFunder = true
+		return g, nil
+	}
+	funder = funders.Hits.Hits[0].ID
+
+	awards, err := a.client.SearchAwards("number:"+strconv.Quote(number), funder, 1, 1)
+	if err != nil {
+		return g, err
+	}
+	if len(awards.Hits.Hits) > 0 && awards.Hits.Hits[0].Number == number {
+		award := awards.Hits.Hits[0]
+		g.Found, g.AwardID, g.Title, g.Acronym, g.Funder = true, award.ID, award.TitleString(), award.Acronym, award.Funder.Name
+		return g, nil
+	}
+	g.Suggestion = a.suggest(funder, number)
+	return g, nil
+}
+
+// queryEscaper escapes query string syntax in award numbers.
+var queryEscaper = regexp.MustCompile(`[+\-=&|><!(){}\[\]^"~*?:\\/ ]`)
+
+// suggest returns the funder's award whose number is closest to number, if
+// it is a likely typo.
+func (a *Awards) suggest(funder, number string) *model.Award {
+	q := "number:" + queryEscaper.ReplaceAllString(number, `\$0`) + "~2"
+	res, err := a.client.SearchAwards(q, funder, 1, 25)
+	if err != nil {
+		return nil
+	}
+	var best *model.Award
+	bestDist := 0
+	for i, award := range res.Hits.Hits {
+		if d := Levenshtein(number, award.Number); best == nil || d < bestDist {
+			best, bestDist = &res.Hits.Hits[i], d
+		}
+	}
+	if best == nil || bestDist > maxDistance(number) {
+		return nil
+	}
+	return best
+}
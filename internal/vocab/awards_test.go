@@ -0,0 +1,105 @@
+package vocab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// awardsServer serves one funder with two awards, counting requests.
+func awardsServer(t *testing.T, requests *int) *httptest.Server {
+	t.Helper()
+	ec := model.Funder{ID: "00k4n6c32", Name: "European Commission",
+		Identifiers: []model.RDMIdentifier{{Scheme: "doi", Identifier: "10.13039/501100000780"}}}
+	awards := []model.Award{
+		{ID: "00k4n6c32::101004310", Number: "101004310", Acronym: "FAIRsFAIR", Title: map[string]string{"en": "Fostering FAIR data"}},
+		{ID: "00k4n6c32::831644", Number: "831644", Acronym: "ACME"},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		q := r.URL.Query().Get("q")
+		switch r.URL.Path {
+		case "/funders":
+			var res model.FunderSearchResult
+			if strings.Contains(q, ec.ID) || strings.Contains(q, ec.Identifiers[0].Identifier) {
+				res.Hits.Hits, res.Hits.Total = []model.Funder{ec}, 1
+			}
+			json.NewEncoder(w).Encode(res)
+		case "/awards":
+			// Exact number queries match one award; fuzzy ones all of them.
+			var res model.AwardSearchResult
+			for _, a := range awards {
+				if strings.Contains(q, `funder.id:"`+ec.ID+`"`) && (strings.Contains(q, "~") || strings.Contains(q, `"`+a.Number+`"`)) {
+					res.Hits.Hits = append(res.Hits.Hits, a)
+				}
+			}
+			res.Hits.Total = len(res.Hits.Hits)
+			json.NewEncoder(w).Encode(res)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestAwards_Lookup(t *testing.T) {
+	requests := 0
+	srv := awardsServer(t, &requests)
+	a := newTestStore(t, srv.URL).Awards(true)
+
+	for _, id := range []string{"101004310", "00k4n6c32::101004310", "10.13039/501100000780::101004310"} {
+		g, ok := a.Lookup(id)
+		if !ok || !g.Found || g.Acronym != "FAIRsFAIR" || g.Title != "Fostering FAIR data" {
+			t.Errorf("Lookup(%q) = %+v, %v", id, g, ok)
+		}
+	}
+
+	g, ok := a.Lookup("101004301")
+	if !ok || g.Found || g.UnknownFunder {
+		t.Fatalf("mistyped grant = %+v, %v", g, ok)
+	}
+	if g.Suggestion == nil || g.Suggestion.Number != "101004310" {
+		t.Errorf("suggestion = %+v", g.Suggestion)
+	}
+
+	for _, id := range []string{"04pw6fb54::1R01", "10.13039/100000002::1R01"} {
+		if g, ok := a.Lookup(id); !ok || g.Found || !g.UnknownFunder {
+			t.Errorf("Lookup(%q) = %+v, %v", id, g, ok)
+		}
+	}
+}
+
+func TestAwards_Caches(t *testing.T) {
+	requests := 0
+	srv := awardsServer(t, &requests)
+	s := newTestStore(t, srv.URL)
+
+	if g, ok := s.Awards(true).Lookup("831644"); !ok || !g.Found {
+		t.Fatalf("lookup = %+v, %v", g, ok)
+	}
+	before := requests
+
+	// A new Awards reads the answer from disk without fetching.
+	if g, ok := s.Awards(true).Lookup("831644"); !ok || !g.Found {
+		t.Errorf("cached lookup = %+v, %v", g, ok)
+	}
+	if requests != before {
+		t.Errorf("requests = %d, want %d", requests, before)
+	}
+
+	// Without fetching, unknown grant IDs have no answer.
+	if _, ok := s.Awards(false).Lookup("101004310"); ok {
+		t.Error("offline lookup of an uncached grant answered")
+	}
+
+	// An instance without the funders endpoint is never asked.
+	s.Caps = &model.Capabilities{Endpoints: []string{model.EndpointRecords}}
+	if _, ok := s.Awards(true).Lookup("101004310"); ok || requests != before {
+		t.Errorf("lookup on an instance without funders: ok = %v, requests = %d", ok, requests)
+	}
+}
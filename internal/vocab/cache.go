@@ -0,0 +1,220 @@
+package vocab
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/ran-codes/zenodo-cli/internal/config"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// CacheFormat is the version of the cache file layout. Cache files written
+// with another format are ignored and refetched.
+const CacheFormat = 1
+
+// DefaultMaxAge is how long a cached vocabulary is used before it is
+// refetched.
+const DefaultMaxAge = 30 * 24 * time.Hour
+
+// fetchPageSize is the page size used when fetching a vocabulary.
+const fetchPageSize = 500
+
+// maxFetchPages bounds a fetch, in case an API never reports the total.
+const maxFetchPages = 100
+
+// cacheFile is the on-disk form of a cached vocabulary.
+type cacheFile struct {
+	Format int `json:"format"`
+	*Vocabulary
+}
+
+// Client fetches vocabularies from an instance; *api.Client implements it.
+// Keeping vocab free of the API client lets metadata packages such as
+// crosswalk use the embedded vocabularies without depending on it.
+type Client interface {
+	ListVocabulary(vocabulary string, page, size int) (*model.VocabularySearchResult, error)
+	SearchFunders(q string, page, size int) (*model.FunderSearchResult, error)
+	SearchAwards(q, funderID string, page, size int) (*model.AwardSearchResult, error)
+}
+
+// Store caches the vocabularies of one Zenodo instance on disk.
+type Store struct {
+	// Dir holds one <kind>.json file per cached vocabulary.
+	Dir     string
+	BaseURL string
+	Client  Client
+	// MaxAge is how long a cached vocabulary is fresh.
+	MaxAge time.Duration
+	// Caps, if set, are the instance's probed capabilities; vocabularies
+	// it does not serve are never fetched.
+	Caps *model.Capabilities
+}
+
+var unsafeName = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// CacheDir returns the cache directory for an instance's base URL under the
+// config directory, e.g. vocab/zenodo.org.
+func CacheDir(baseURL string) string {
+	name := baseURL
+	if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+		name = u.Host
+	}
+	return filepath.Join(config.GetConfigDir(), "vocab", unsafeName.ReplaceAllString(name, "_"))
+}
+
+// NewStore returns a store for the instance at baseURL, caching under
+// CacheDir(baseURL).
+func NewStore(baseURL string, client Client) *Store {
+	return &Store{Dir: CacheDir(baseURL), BaseURL: baseURL, Client: client, MaxAge: DefaultMaxAge}
+}
+
+func (s *Store) path(kind Kind) string {
+	return filepath.Join(s.Dir, string(kind)+".json")
+}
+
+// Cached reads a cached vocabulary. A missing cache, or one in another
+// format, returns (nil, nil).
+func (s *Store) Cached(kind Kind) (*Vocabulary, error) {
+	data, err := os.ReadFile(s.path(kind))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s cache: %w", kind, err)
+	}
+	var f cacheFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parsing %s cache: %w", kind, err)
+	}
+	if f.Format != CacheFormat || f.Vocabulary == nil || f.Kind != kind {
+		return nil, nil
+	}
+	return f.Vocabulary, nil
+}
+
+// Fresh reports whether v was fetched within the store's MaxAge.
+func (s *Store) Fresh(v *Vocabulary) bool {
+	return v != nil && time.Since(v.Fetched) < s.MaxAge
+}
+
+// Fetch downloads a vocabulary from the API.
+func (s *Store) Fetch(kind Kind) (*Vocabulary, error) {
+	if s.Client == nil {
+		return nil, fmt.Errorf("fetching %s: no API client", kind)
+	}
+	if !s.Caps.HasVocabulary(string(kind)) {
+		return nil, fmt.Errorf("fetching %s: the instance does not serve /vocabularies/%s", kind, kind)
+	}
+	var terms []Term
+	for page := 1; page <= maxFetchPages; page++ {
+		res, err := s.Client.ListVocabulary(string(kind), page, fetchPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("fetching %s: %w", kind, err)
+		}
+		for _, h := range res.Hits.Hits {
+			terms = append(terms, termFromAPI(h))
+		}
+		if len(res.Hits.Hits) == 0 || len(terms) >= res.Hits.Total {
+			break
+		}
+	}
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("fetching %s: the API returned no terms", kind)
+	}
+	v := New(kind, s.BaseURL, terms)
+	v.Fetched = time.Now().UTC()
+	return v, nil
+}
+
+// termFromAPI converts an API term. Languages carry their ISO 639-1 code in
+// props.alpha_2, which becomes an alias.
+func termFromAPI(h model.VocabularyTerm) Term {
+	t := Term{ID: h.ID, Title: h.TitleString()}
+	if a, ok := h.Props["alpha_2"].(string); ok && a != "" {
+		t.Aliases = []string{a}
+	}
+	return t
+}
+
+// Save writes v to the cache atomically, creating the directory if needed.
+func (s *Store) Save(v *Vocabulary) error {
+	if err := os.MkdirAll(s.Dir, 0700); err != nil {
+		return fmt.Errorf("creating vocabulary cache: %w", err)
+	}
+	data, err := json.MarshalIndent(cacheFile{Format: CacheFormat, Vocabulary: v}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling %s: %w", v.Kind, err)
+	}
+	path := s.path(v.Kind)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("writing %s cache: %w", v.Kind, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("writing %s cache: %w", v.Kind, err)
+	}
+	return nil
+}
+
+// Update fetches a vocabulary and caches it. It also returns the version of
+// the vocabulary it replaced, or "" if none was cached.
+func (s *Store) Update(kind Kind) (*Vocabulary, string, error) {
+	var previous string
+	if old, err := s.Cached(kind); err == nil && old != nil {
+		previous = old.Version
+	}
+	v, err := s.Fetch(kind)
+	if err != nil {
+		return nil, previous, err
+	}
+	if err := s.Save(v); err != nil {
+		return nil, previous, err
+	}
+	return v, previous, nil
+}
+
+// Load returns the best available vocabulary: a fresh cache, else (if
+// fetch is set and the instance serves it) a newly fetched and cached one,
+// else a stale cache, else the embedded one. A non-nil error is a warning
+// about why a better source was not used; the vocabulary is always usable.
+func (s *Store) Load(kind Kind, fetch bool) (*Vocabulary, error) {
+	cached, cacheErr := s.Cached(kind)
+	if s.Fresh(cached) {
+		return cached, nil
+	}
+	var err error = cacheErr
+	if fetch && s.Caps.HasVocabulary(string(kind)) {
+		var v *Vocabulary
+		if v, _, err = s.Update(kind); err == nil {
+			return v, nil
+		}
+	}
+	if cached != nil {
+		return cached, err
+	}
+	return Embedded(kind), err
+}
+
+// LoadSet loads every vocabulary as Load does, reporting warnings to warn
+// if it is not nil. After a failed fetch it stops fetching, so an offline
+// run fails once rather than once per vocabulary.
+func (s *Store) LoadSet(fetch bool, warn func(kind Kind, err error)) *Set {
+	set := &Set{}
+	for _, kind := range Kinds {
+		v, err := s.Load(kind, fetch)
+		if err != nil {
+			fetch = false
+			if warn != nil {
+				warn(kind, err)
+			}
+		}
+		set.Add(v)
+	}
+	return set
+}
@@ -0,0 +1,171 @@
+package vocab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ran-codes/zenodo-cli/internal/api"
+	"github.com/ran-codes/zenodo-cli/internal/model"
+)
+
+// vocabServer serves a languages vocabulary of three terms in pages of two,
+// counting requests.
+func vocabServer(t *testing.T, requests *int) *httptest.Server {
+	t.Helper()
+	terms := []model.VocabularyTerm{
+		{ID: "eng", Title: map[string]string{"en": "English"}, Props: map[string]any{"alpha_2": "en"}},
+		{ID: "deu", Title: map[string]string{"en": "German"}, Props: map[string]any{"alpha_2": "de"}},
+		{ID: "tlh", Title: map[string]string{"en": "Klingon"}},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if r.URL.Path != "/vocabularies/languages" {
+			http.NotFound(w, r)
+			return
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		start := (page - 1) * 2
+		end := min(start+2, len(terms))
+		json.NewEncoder(w).Encode(model.VocabularySearchResult{
+			Hits: model.VocabularyHits{Hits: terms[start:end], Total: len(terms)},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestStore(t *testing.T, baseURL string) *Store {
+	return &Store{Dir: t.TempDir(), BaseURL: baseURL, Client: api.NewClient(baseURL, ""), MaxAge: time.Hour}
+}
+
+func TestStore_FetchPagesAndCaches(t *testing.T) {
+	requests := 0
+	srv := vocabServer(t, &requests)
+	s := newTestStore(t, srv.URL)
+
+	v, err := s.Load(Languages, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v.Terms) != 3 || v.Source != srv.URL || v.Fetched.IsZero() {
+		t.Fatalf("vocabulary = %+v", v)
+	}
+	if term, ok := v.Suggest("de"); !ok || term.ID != "deu" {
+		t.Errorf("alias from props not kept: %+v", term)
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2 pages", requests)
+	}
+
+	// A fresh cache is used without fetching.
+	cached, err := s.Load(Languages, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 || cached.Version != v.Version || !cached.Contains("tlh") {
+		t.Errorf("requests = %d, cached = %+v", requests, cached)
+	}
+}
+
+func TestStore_UpdateReportsPreviousVersion(t *testing.T) {
+	requests := 0
+	srv := vocabServer(t, &requests)
+	s := newTestStore(t, srv.URL)
+
+	first, previous, err := s.Update(Languages)
+	if err != nil || previous != "" {
+		t.Fatalf("first update: previous %q, err %v", previous, err)
+	}
+	_, previous, err = s.Update(Languages)
+	if err != nil || previous != first.Version {
+		t.Errorf("second update: previous %q, want %q (err %v)", previous, first.Version, err)
+	}
+}
+
+func TestStore_FallsBack(t *testing.T) {
+	requests := 0
+	srv := vocabServer(t, &requests)
+
+	// No cache and the fetch fails: the embedded vocabulary, with a warning.
+	s := newTestStore(t, srv.URL)
+	v, err := s.Load(Licenses, true)
+	if err == nil || v.Source != SourceEmbedded {
+		t.Errorf("source = %q, err = %v", v.Source, err)
+	}
+
+	// A stale cache beats the embedded vocabulary when offline.
+	if _, _, err := s.Update(Languages); err != nil {
+		t.Fatal(err)
+	}
+	s.MaxAge = 0
+	v, err = s.Load(Languages, false)
+	if err != nil || v.Source != srv.URL {
+		t.Errorf("source = %q, err = %v", v.Source, err)
+	}
+}
+
+func TestStore_SkipsVocabulariesTheInstanceLacks(t *testing.T) {
+	requests := 0
+	srv := vocabServer(t, &requests)
+	s := newTestStore(t, srv.URL)
+	s.Caps = &model.Capabilities{Vocabularies: []string{"languages"}}
+
+	v, err := s.Load(Licenses, true)
+	if err != nil || v.Source != SourceEmbedded || requests != 0 {
+		t.Errorf("source = %q, err = %v, requests = %d", v.Source, err, requests)
+	}
+	if v, err := s.Load(Languages, true); err != nil || v.Source != srv.URL {
+		t.Errorf("source = %q, err = %v", v.Source, err)
+	}
+	if _, _, err := s.Update(Licenses); err == nil {
+		t.Error("expected an error updating a vocabulary the instance lacks")
+	}
+}
+
+func TestStore_IgnoresOtherFormats(t *testing.T) {
+	s := &Store{Dir: t.TempDir(), MaxAge: time.Hour}
+	data := `{"format": 99, "kind": "languages", "source": "x", "terms": [{"id": "xxx"}]}`
+	if err := os.WriteFile(filepath.Join(s.Dir, "languages.json"), []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	v, err := s.Cached(Languages)
+	if err != nil || v != nil {
+		t.Errorf("Cached = %+v, %v; want nil, nil", v, err)
+	}
+	if v, _ := s.Load(Languages, false); v.Source != SourceEmbedded {
+		t.Errorf("source = %q", v.Source)
+	}
+}
+
+func TestLoadSet_StopsFetchingAfterFailure(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	s := newTestStore(t, srv.URL)
+
+	var warned []Kind
+	set := s.LoadSet(true, func(kind Kind, err error) { warned = append(warned, kind) })
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+	if len(warned) != 1 || set.Get(Languages).Source != SourceEmbedded {
+		t.Errorf("warned = %v", warned)
+	}
+}
+
+func TestCacheDir(t *testing.T) {
+	a := CacheDir("https://zenodo.org/api")
+	b := CacheDir("https://sandbox.zenodo.org/api")
+	if filepath.Base(a) != "zenodo.org" || filepath.Base(b) != "sandbox.zenodo.org" {
+		t.Errorf("dirs = %q, %q", a, b)
+	}
+}
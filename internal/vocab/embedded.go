@@ -0,0 +1,56 @@
+package vocab
+
+import (
+	"embed"
+	"strings"
+	"sync"
+)
+
+// data holds the offline fallback of each vocabulary, one term per line:
+// ID, then optionally a tab and the title, then optionally a tab and
+// comma-separated aliases. Lines starting with "#" are comments naming the
+// source of the list.
+//
+//go:embed data/*.tsv
+var data embed.FS
+
+var (
+	embeddedMu sync.Mutex
+	embedded   = make(map[Kind]*Vocabulary)
+)
+
+// Embedded returns the vocabulary shipped with the binary.
+func Embedded(kind Kind) *Vocabulary {
+	embeddedMu.Lock()
+	defer embeddedMu.Unlock()
+	if v, ok := embedded[kind]; ok {
+		return v
+	}
+	b, err := data.ReadFile("data/" + string(kind) + ".tsv")
+	if err != nil {
+		// Every Kind has a file; an unknown kind is an empty vocabulary.
+		return &Vocabulary{Kind: kind, Source: SourceEmbedded, Terms: []Term{}}
+	}
+	v := New(kind, SourceEmbedded, parseTSV(string(b)))
+	embedded[kind] = v
+	return v
+}
+
+func parseTSV(s string) []Term {
+	var terms []Term
+	for _, line := range strings.Split(s, "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		cols := strings.Split(line, "\t")
+		t := Term{ID: cols[0]}
+		if len(cols) > 1 {
+			t.Title = cols[1]
+		}
+		if len(cols) > 2 && cols[2] != "" {
+			t.Aliases = strings.Split(cols[2], ",")
+		}
+		terms = append(terms, t)
+	}
+	return terms
+}
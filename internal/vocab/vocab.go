@@ -0,0 +1,259 @@
+// Package vocab provides Zenodo's controlled vocabularies (licenses,
+// resource types, relation types, languages and contributor roles) for
+// offline validation. Vocabularies are fetched from the API once and cached
+// on disk; an embedded copy is used when there is no cache.
+package vocab
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Kind names a vocabulary, as in the API path /vocabularies/<kind>.
+type Kind string
+
+const (
+	Licenses         Kind = "licenses"
+	ResourceTypes    Kind = "resourcetypes"
+	RelationTypes    Kind = "relationtypes"
+	Languages        Kind = "languages"
+	ContributorRoles Kind = "contributorsroles"
+)
+
+// Kinds lists every supported vocabulary.
+var Kinds = []Kind{Licenses, ResourceTypes, RelationTypes, Languages, ContributorRoles}
+
+// ParseKind returns the vocabulary named s.
+func ParseKind(s string) (Kind, error) {
+	for _, k := range Kinds {
+		if string(k) == strings.ToLower(s) {
+			return k, nil
+		}
+	}
+	names := make([]string, len(Kinds))
+	for i, k := range Kinds {
+		names[i] = string(k)
+	}
+	return "", fmt.Errorf("unknown vocabulary %q (supported: %s)", s, strings.Join(names, ", "))
+}
+
+// Term is one vocabulary entry. Aliases are alternative spellings that are
+// not valid themselves but map to the term in suggestions, such as the
+// ISO 639-1 code "en" for "eng".
+type Term struct {
+	ID      string   `json:"id"`
+	Title   string   `json:"title,omitempty"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// Words returns the term in the deposit API's spelling, built from its
+// title: "Is cited by" becomes isCitedBy, or IsCitedBy when upper is set.
+// Terms whose title does not spell out the ID are returned as the ID.
+func (t Term) Words(upper bool) string {
+	var b strings.Builder
+	for i, w := range strings.Fields(t.Title) {
+		r := []rune(strings.ToLower(w))
+		if i > 0 || upper {
+			r[0] = unicode.ToUpper(r[0])
+		}
+		b.WriteString(string(r))
+	}
+	if !strings.EqualFold(b.String(), t.ID) {
+		return t.ID
+	}
+	return b.String()
+}
+
+// Source values for vocabularies that were not fetched.
+const SourceEmbedded = "embedded"
+
+// Vocabulary is a set of terms, from the API or the embedded fallback.
+type Vocabulary struct {
+	Kind Kind `json:"kind"`
+	// Source is the API base URL the terms were fetched from, or "embedded".
+	Source string `json:"source"`
+	// Version identifies the set of term IDs, so a refresh can tell whether
+	// the vocabulary changed.
+	Version string    `json:"version"`
+	Fetched time.Time `json:"fetched,omitzero"`
+	Terms   []Term    `json:"terms"`
+
+	indexOnce sync.Once
+	index     map[string]int
+}
+
+// New returns a vocabulary of terms, sorted by ID, with its version
+// computed from the IDs.
+func New(kind Kind, source string, terms []Term) *Vocabulary {
+	sort.Slice(terms, func(i, j int) bool { return terms[i].ID < terms[j].ID })
+	h := sha256.New()
+	for _, t := range terms {
+		fmt.Fprintln(h, strings.ToLower(t.ID))
+	}
+	return &Vocabulary{
+		Kind:    kind,
+		Source:  source,
+		Version: hex.EncodeToString(h.Sum(nil))[:12],
+		Terms:   terms,
+	}
+}
+
+func (v *Vocabulary) buildIndex() {
+	v.indexOnce.Do(func() {
+		v.index = make(map[string]int, len(v.Terms))
+		for i, t := range v.Terms {
+			v.index[strings.ToLower(t.ID)] = i
+		}
+	})
+}
+
+// Lookup returns the term with the given ID, ignoring case and surrounding
+// space.
+func (v *Vocabulary) Lookup(id string) (Term, bool) {
+	v.buildIndex()
+	i, ok := v.index[strings.ToLower(strings.TrimSpace(id))]
+	if !ok {
+		return Term{}, false
+	}
+	return v.Terms[i], true
+}
+
+// Contains reports whether id is a term of the vocabulary.
+func (v *Vocabulary) Contains(id string) bool {
+	_, ok := v.Lookup(id)
+	return ok
+}
+
+// Sub returns the terms of a hierarchical vocabulary under parent, with the
+// "parent-" prefix removed: Sub("image") of the resource types holds
+// "figure", "photo", and so on. Sub("") returns the top-level terms.
+func (v *Vocabulary) Sub(parent string) *Vocabulary {
+	sub := &Vocabulary{Kind: v.Kind, Source: v.Source, Version: v.Version, Fetched: v.Fetched}
+	for _, t := range v.Terms {
+		switch {
+		case parent == "" && !strings.Contains(t.ID, "-"):
+			sub.Terms = append(sub.Terms, t)
+		case parent != "" && strings.HasPrefix(t.ID, parent+"-"):
+			t.ID = strings.TrimPrefix(t.ID, parent+"-")
+			sub.Terms = append(sub.Terms, t)
+		}
+	}
+	return sub
+}
+
+// IDs returns the term IDs in order.
+func (v *Vocabulary) IDs() []string {
+	ids := make([]string, len(v.Terms))
+	for i, t := range v.Terms {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+// Suggest returns the term closest to an invalid value: one whose alias or
+// title matches it, or else the ID with the smallest edit distance, if that
+// is close enough to be a likely typo.
+func (v *Vocabulary) Suggest(value string) (Term, bool) {
+	norm := strings.ToLower(strings.TrimSpace(value))
+	if norm == "" {
+		return Term{}, false
+	}
+	// Aliases are codes, so they win over titles: "en" is English (eng),
+	// not the language named "En" (enc).
+	for _, t := range v.Terms {
+		for _, a := range t.Aliases {
+			if strings.EqualFold(a, norm) {
+				return t, true
+			}
+		}
+	}
+	for _, t := range v.Terms {
+		if strings.EqualFold(t.Title, norm) {
+			return t, true
+		}
+	}
+
+	// Also try the value as if it were written as an ID: "CC BY 4.0" for
+	// "cc-by-4.0", "Contact Person" for "contactperson".
+	candidates := []string{norm, strings.ReplaceAll(norm, " ", "-"), strings.ReplaceAll(norm, " ", "")}
+	best, bestDist := -1, 0
+	for i, t := range v.Terms {
+		id := strings.ToLower(t.ID)
+		for _, c := range candidates {
+			d := Levenshtein(c, id)
+			if best < 0 || d < bestDist || (d == bestDist && betterTie(id, strings.ToLower(v.Terms[best].ID), c)) {
+				best, bestDist = i, d
+			}
+		}
+	}
+	if best < 0 || bestDist > maxDistance(norm) {
+		return Term{}, false
+	}
+	return v.Terms[best], true
+}
+
+// maxDistance is the largest edit distance still taken for a typo.
+func maxDistance(value string) int {
+	return len(value)/4 + 1
+}
+
+// betterTie prefers, among equally distant IDs, one that extends the value
+// ("cc-by-4" suggests "cc-by-4.0"), then the shorter one.
+func betterTie(id, current, value string) bool {
+	if p, q := strings.HasPrefix(id, value), strings.HasPrefix(current, value); p != q {
+		return p
+	}
+	return len(id) < len(current)
+}
+
+// Levenshtein returns the edit distance between a and b.
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// Set holds one vocabulary of each kind. Kinds that were not added fall
+// back to the embedded vocabulary, so the zero Set is usable offline.
+type Set struct {
+	vocabs map[Kind]*Vocabulary
+}
+
+// Add puts v in the set, replacing the vocabulary of its kind.
+func (s *Set) Add(v *Vocabulary) {
+	if s.vocabs == nil {
+		s.vocabs = make(map[Kind]*Vocabulary)
+	}
+	s.vocabs[v.Kind] = v
+}
+
+// Get returns the vocabulary of the given kind.
+func (s *Set) Get(kind Kind) *Vocabulary {
+	if s != nil {
+		if v, ok := s.vocabs[kind]; ok {
+			return v
+		}
+	}
+	return Embedded(kind)
+}
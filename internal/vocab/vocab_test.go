@@ -0,0 +1,131 @@
+package vocab
+
+import "testing"
+
+func TestEmbedded(t *testing.T) {
+	for _, kind := range Kinds {
+		v := Embedded(kind)
+		if len(v.Terms) == 0 || v.Source != SourceEmbedded || v.Version == "" {
+			t.Errorf("%s: %d terms, source %q, version %q", kind, len(v.Terms), v.Source, v.Version)
+		}
+	}
+	for kind, id := range map[Kind]string{
+		Licenses:         "cc-by-4.0",
+		ResourceTypes:    "publication-article",
+		RelationTypes:    "iscitedby",
+		Languages:        "eng",
+		ContributorRoles: "contactperson",
+	} {
+		if !Embedded(kind).Contains(id) {
+			t.Errorf("%s does not contain %q", kind, id)
+		}
+	}
+}
+
+func TestLookup_IgnoresCase(t *testing.T) {
+	v := Embedded(RelationTypes)
+	term, ok := v.Lookup(" isCitedBy ")
+	if !ok || term.ID != "iscitedby" {
+		t.Errorf("Lookup = %+v, %v", term, ok)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		kind  Kind
+		value string
+		want  string
+	}{
+		{Licenses, "cc-by-4", "cc-by-4.0"},
+		{Licenses, "CC BY 4.0", "cc-by-4.0"},
+		{Licenses, "Apache License 2.0", "apache-2.0"},
+		{ResourceTypes, "datset", "dataset"},
+		{RelationTypes, "isCitedby_", "iscitedby"},
+		{RelationTypes, "isSuplementTo", "issupplementto"},
+		{Languages, "en", "eng"},
+		{Languages, "German", "deu"},
+		{ContributorRoles, "Contact Person", "contactperson"},
+		{ContributorRoles, "DataColector", "datacollector"},
+	}
+	for _, tt := range tests {
+		got, ok := Embedded(tt.kind).Suggest(tt.value)
+		if !ok || got.ID != tt.want {
+			t.Errorf("%s.Suggest(%q) = %q, %v; want %q", tt.kind, tt.value, got.ID, ok, tt.want)
+		}
+	}
+	for _, value := range []string{"", "completely-unrelated-words"} {
+		if got, ok := Embedded(Licenses).Suggest(value); ok {
+			t.Errorf("Suggest(%q) = %q, want none", value, got.ID)
+		}
+	}
+}
+
+func TestSub(t *testing.T) {
+	types := Embedded(ResourceTypes)
+	if top := types.Sub(""); !top.Contains("dataset") || top.Contains("publication-article") {
+		t.Errorf("top level = %v", top.IDs())
+	}
+	if pub := types.Sub("publication"); !pub.Contains("article") || pub.Contains("dataset") {
+		t.Errorf("publication types = %v", pub.IDs())
+	}
+	if img := types.Sub("image"); !img.Contains("figure") || len(img.Terms) != 6 {
+		t.Errorf("image types = %v", img.IDs())
+	}
+}
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		term  Term
+		upper bool
+		want  string
+	}{
+		{Term{ID: "iscitedby", Title: "Is cited by"}, false, "isCitedBy"},
+		{Term{ID: "contactperson", Title: "Contact person"}, true, "ContactPerson"},
+		{Term{ID: "researcher", Title: "Researcher"}, true, "Researcher"},
+		{Term{ID: "cc-by-4.0", Title: "Creative Commons Attribution 4.0 International"}, false, "cc-by-4.0"},
+	}
+	for _, tt := range tests {
+		if got := tt.term.Words(tt.upper); got != tt.want {
+			t.Errorf("Words(%q) = %q, want %q", tt.term.Title, got, tt.want)
+		}
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"dataset", "datset", 1},
+		{"über", "uber", 1},
+	}
+	for _, tt := range tests {
+		if got := Levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("Levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSet_FallsBackToEmbedded(t *testing.T) {
+	var s Set
+	if s.Get(Languages) != Embedded(Languages) {
+		t.Error("zero Set did not return the embedded vocabulary")
+	}
+	custom := New(Languages, "test", []Term{{ID: "xyz"}})
+	s.Add(custom)
+	if s.Get(Languages) != custom || s.Get(Licenses) != Embedded(Licenses) {
+		t.Error("Add did not replace only its kind")
+	}
+}
+
+func TestParseKind(t *testing.T) {
+	if k, err := ParseKind("Licenses"); err != nil || k != Licenses {
+		t.Errorf("ParseKind = %q, %v", k, err)
+	}
+	if _, err := ParseKind("colors"); err == nil {
+		t.Error("expected error")
+	}
+}